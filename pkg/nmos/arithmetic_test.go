@@ -1,6 +1,7 @@
 package nmos
 
 import (
+	"go6502/pkg/processor"
 	"testing"
 )
 
@@ -18,7 +19,203 @@ import (
 // 0 is stored in ERROR if successful, and 1 is stored in ERROR if unsuccessful.
 //
 // This is done not by executing the program from APPENDIX B, but by generating all of
-// the possible combinations as tests.
+// the possible combinations as tests. Each ADC and SBC is executed by the Cpu and the
+// results compared with those predicted by the program, which are calculated using
+// binary arithmetic in the same way as the routines A6502, S6502, A65C02 and S65C02.
 func TestBCDWithAccumulator(t *testing.T) {
-	// TODO: Add tests
+
+	tests := []struct {
+		name       string
+		newCpu     func(processor.Memory) (processor.Cpu, error)
+		predictAdd func(n1, n2 uint8, carry bool) decimalResult
+		predictSub func(n1, n2 uint8, carry bool) decimalResult
+	}{
+		{name: "6502", newCpu: New6502Cpu, predictAdd: predictAdd6502, predictSub: predictSubtract6502},
+		{name: "Extended 6502", newCpu: NewExtended6502Cpu, predictAdd: predictAdd6502, predictSub: predictSubtract6502},
+		{name: "65C02", newCpu: New65C02Cpu, predictAdd: predictAdd65C02, predictSub: predictSubtract65C02},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram := processor.FlatRam{}
+			cpu, err := tt.newCpu(&ram)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			errors := 0
+			for _, carry := range [...]bool{true, false} {
+				for n1 := range 256 {
+					for n2 := range 256 {
+						add := tt.predictAdd(uint8(n1), uint8(n2), carry)
+						sub := tt.predictSub(uint8(n1), uint8(n2), carry)
+
+						for _, want := range [...]struct {
+							name   string
+							opcode uint8
+							result decimalResult
+						}{
+							{name: "ADC", opcode: 0x69, result: add},
+							{name: "SBC", opcode: 0xE9, result: sub},
+						} {
+							got, err := executeDecimal(&cpu, &ram, want.opcode, uint8(n1), uint8(n2), carry)
+							if err != nil {
+								t.Fatal(err)
+							}
+							if got != want.result {
+								t.Errorf("%s $%02X, $%02X, carry %v got = %+v, want = %+v", want.name, n1, n2, carry, got, want.result)
+								errors++
+							}
+							if errors > 10 {
+								t.Fatal("too many errors")
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// decimalResult is the accumulator and the flags checked by the program. This is
+// the equivalent of DA and DNVZC, or AR, NF, VF, ZF and CF in the program.
+type decimalResult struct {
+	a                  uint8
+	negative, overflow bool
+	zero, carry        bool
+}
+
+// executeDecimal executes ADC or SBC immediate in decimal mode with the given operands
+// and carry, returning the actual accumulator and flags.
+func executeDecimal(cpu *processor.Cpu, ram *processor.FlatRam, opcode, n1, n2 uint8, carry bool) (decimalResult, error) {
+	ram[0x0200] = opcode
+	ram[0x0201] = n2
+
+	p := processor.Status(processor.FlagConstant | processor.FlagDecimal)
+	if carry {
+		p.SetCarry()
+	}
+	cpu.State = processor.State{PC: 0x0200, SP: processor.StackPointerStart, A: n1, P: p}
+
+	if _, err := cpu.Step(); err != nil {
+		return decimalResult{}, err
+	}
+
+	flags := cpu.State.P.ToFlags()
+	return decimalResult{
+		a:        cpu.State.A,
+		negative: flags.Negative,
+		overflow: flags.Overflow,
+		zero:     flags.Zero,
+		carry:    flags.Carry,
+	}, nil
+}
+
+// binaryAdd is ADC in binary mode, returning the result and the N, V, Z and C flags.
+func binaryAdd(a, b uint8, carry bool) decimalResult {
+	sum := uint16(a) + uint16(b)
+	if carry {
+		sum++
+	}
+	r := uint8(sum)
+	return decimalResult{
+		a:        r,
+		negative: r&0x80 != 0,
+		overflow: (a^r)&(b^r)&0x80 != 0,
+		zero:     r == 0,
+		carry:    sum > 0xFF,
+	}
+}
+
+// binarySubtract is SBC in binary mode, returning the result and the N, V, Z and C flags.
+func binarySubtract(a, b uint8, carry bool) decimalResult {
+	return binaryAdd(a, ^b, carry)
+}
+
+// predictAdd predicts the accumulator, carry and overflow of an ADC in decimal mode. The
+// overflow and negative flags are those of the addition before the decimal carry from
+// the upper nibble is adjusted for. This is the ADD routine of the program.
+func predictAdd(n1, n2 uint8, carry bool) decimalResult {
+	n1l, n1h := n1&0x0F, n1&0xF0
+	n2l, n2h := n2&0x0F, n2&0xF0
+
+	// If N1L + N2L >= $0A, then add $06 to the lower nibble and carry into the upper.
+	lower := binaryAdd(n1l, n2l, carry)
+	if lower.a >= 0x0A {
+		lower = binaryAdd(lower.a, 0x05, true)
+		lower.a &= 0x0F
+		n2h += 0x0F
+		lower.carry = true
+	}
+
+	vf := binaryAdd(lower.a|n1h, n2h, lower.carry)
+
+	// If the upper nibble exceeds 9, then add $60 which always carries.
+	ar := vf
+	if vf.carry || vf.a >= 0xA0 {
+		ar = binaryAdd(vf.a, 0x5F, true)
+		ar.carry = true
+	}
+
+	return decimalResult{a: ar.a, negative: vf.negative, overflow: vf.overflow, carry: ar.carry}
+}
+
+// predictAdd6502 is the A6502 routine. The zero flag is that of the binary addition.
+func predictAdd6502(n1, n2 uint8, carry bool) decimalResult {
+	result := predictAdd(n1, n2, carry)
+	result.zero = binaryAdd(n1, n2, carry).zero
+	return result
+}
+
+// predictAdd65C02 is the A65C02 routine. The negative and zero flags reflect the
+// accumulator.
+func predictAdd65C02(n1, n2 uint8, carry bool) decimalResult {
+	result := predictAdd(n1, n2, carry)
+	result.negative = result.a&0x80 != 0
+	result.zero = result.a == 0
+	return result
+}
+
+// predictSubtract6502 is the S6502 routine, using SUB1 to predict the accumulator. All
+// the flags are those of the binary subtraction.
+func predictSubtract6502(n1, n2 uint8, carry bool) decimalResult {
+	n1l, n1h := n1&0x0F, n1&0xF0
+	n2l, n2h := n2&0x0F, n2&0xF0
+
+	// If N1L - N2L < 0, then subtract $06 from the lower nibble and borrow from the upper.
+	lower := binarySubtract(n1l, n2l, carry)
+	if !lower.carry {
+		lower = binarySubtract(lower.a, 0x05, false)
+		lower.a &= 0x0F
+		n2h += 0x0F
+		lower.carry = false
+	}
+
+	ar := binarySubtract(lower.a|n1h, n2h, lower.carry)
+	if !ar.carry {
+		ar = binarySubtract(ar.a, 0x5F, false)
+	}
+
+	result := binarySubtract(n1, n2, carry)
+	result.a = ar.a
+	return result
+}
+
+// predictSubtract65C02 is the S65C02 routine, using SUB2 to predict the accumulator. The
+// negative and zero flags reflect the accumulator, the others the binary subtraction.
+func predictSubtract65C02(n1, n2 uint8, carry bool) decimalResult {
+	lower := binarySubtract(n1&0x0F, n2&0x0F, carry)
+
+	ar := binarySubtract(n1, n2, carry)
+	if !ar.carry {
+		ar = binarySubtract(ar.a, 0x5F, false)
+	}
+	if !lower.carry {
+		ar = binarySubtract(ar.a, 0x05, false)
+	}
+
+	result := binarySubtract(n1, n2, carry)
+	result.a = ar.a
+	result.negative = ar.a&0x80 != 0
+	result.zero = ar.a == 0
+	return result
 }
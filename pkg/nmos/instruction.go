@@ -5,27 +5,79 @@ import (
 )
 
 // New6502InstructionSet returns a correctly initialised InstructionSet
-// for the 6502 CPU.
+// for the 6502 CPU. The unused opcodes can be filled with NOPs or traps
+// using the Builder of the InstructionSet.
 func New6502InstructionSet() (processor.InstructionSet, error) {
+	return processor.NewInstructionSetBuilder().
+		AddMnemonics(processor.AllOpcodes()...).
+		Build()
+}
 
-	instructions := make(processor.Instructions, 0, 0xFF)
+// NewExtended6502InstructionSet returns a correctly initialised InstructionSet
+// for the 6502 CPU including the undocumented opcodes. The magic constants are
+// used by the unstable ANE and LXA opcodes.
+func NewExtended6502InstructionSet(constants processor.MagicConstants) (processor.InstructionSet, error) {
+	return processor.NewInstructionSetBuilder().
+		AddMnemonics(processor.AllOpcodes()...).
+		AddMnemonics(processor.AllUndocumentedOpcodes(constants)...).
+		Build()
+}
 
-	for _, mnemonic := range processor.AllOpcodes() {
-		instruction := processor.NewInstruction(mnemonic)
-		instructions = append(instructions, instruction)
-	}
-	return processor.NewInstructionSet(instructions)
+// New2A03InstructionSet returns a correctly initialised InstructionSet for the
+// Ricoh 2A03 and 2A07 CPUs used by the NES. This is the 6502 instruction set
+// except that ADC and SBC ignore the decimal flag.
+func New2A03InstructionSet() (processor.InstructionSet, error) {
+	return processor.NewInstructionSetBuilder().
+		AddMnemonics(processor.All2A03Opcodes()...).
+		Build()
+}
+
+// NewExtended2A03InstructionSet returns a correctly initialised InstructionSet
+// for the Ricoh 2A03 and 2A07 CPUs including the undocumented opcodes. The
+// operations that would use decimal mode on the 6502 ignore the decimal flag.
+func NewExtended2A03InstructionSet(constants processor.MagicConstants) (processor.InstructionSet, error) {
+	return processor.NewInstructionSetBuilder().
+		AddMnemonics(processor.All2A03Opcodes()...).
+		AddMnemonics(processor.All2A03UndocumentedOpcodes(constants)...).
+		Build()
 }
 
 // New65C02InstructionSet returns a correctly initialised InstructionSet
-// for the 65C02 CPU.
+// for the 65C02 CPU. This includes the Rockwell bit manipulation and bit
+// branch instructions and the undefined opcodes as NOPs. Hardware interrupts
+// clear the decimal flag.
+//
+// The cycle-stepped core only models the bus activity of the NMOS 6502, so
+// Tick executes each 65C02 instruction in a single cycle followed by the
+// correct number of idle cycles.
 func New65C02InstructionSet() (processor.InstructionSet, error) {
-	instructions, err := New6502InstructionSet()
-	if err != nil {
-		return processor.InstructionSet{}, err
-	}
+	return newCmosInstructionSet(processor.All65C02Opcodes())
+}
+
+// NewW65C02SInstructionSet returns a correctly initialised InstructionSet
+// for the WDC W65C02S CPU. This is the 65C02 instruction set with the
+// addition of WAI and STP.
+func NewW65C02SInstructionSet() (processor.InstructionSet, error) {
+	return newCmosInstructionSet(processor.AllW65C02SOpcodes())
+}
+
+// newCmosInstructionSet builds an InstructionSet from the CMOS mnemonics
+// without the bus information used by the cycle-stepped core, as the bus
+// activity of the 65C02 differs from the NMOS 6502. Cpu.Tick therefore
+// executes each instruction in its first cycle and idles for the rest. The
+// mnemonics still describe the instructions, including their addressing modes.
+func newCmosInstructionSet(mnemonics []processor.Mnemonic) (processor.InstructionSet, error) {
 
-	// TODO: Add in 65C02 specific instructions.
+	builder := processor.NewInstructionSetBuilder()
+
+	for _, mnemonic := range mnemonics {
+		instruction := processor.NewInstruction(mnemonic)
+		instruction.Mode = processor.UnknownMode
+		builder.Add(instruction).Describe(mnemonic)
+	}
 
-	return instructions, nil
+	return builder.
+		WithInterruptOperations(processor.InterruptCmos, processor.NmiCmos).
+		WithResetOperation(processor.ResetCmos).
+		Build()
 }
@@ -7,10 +7,23 @@ import (
 )
 
 const (
+	bne          = 0xD0
 	brk          = 0x00
 	adcZeroPage  = 0x65
 	adcImmediate = 0x69
 	nop          = 0xEA
+	dcpZeroPage  = 0xC7
+	laxZeroPage  = 0xA7
+	nopAbsoluteX = 0x1C
+	sbxImmediate = 0xCB
+	sbcImmediate = 0xE9
+	rraZeroPage  = 0x67
+	iscZeroPage  = 0xE7
+	bbs0         = 0x8F
+	bra          = 0x80
+	incA         = 0x1A
+	nopImplied   = 0x03
+	stzZeroPage  = 0x64
 )
 
 // The following struct and harness function are used to test an instruction test
@@ -33,7 +46,7 @@ func testInstructionSet(t *testing.T, tests []testCpuInstructionSet, instruction
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			callback := func(cpu *processor.Cpu) error {
-				if err := cpu.Reset(); err != nil {
+				if _, err := cpu.Reset(); err != nil {
 					return err
 				}
 
@@ -100,7 +113,7 @@ func instructionSetTests6502() []testCpuInstructionSet {
 			startRam:   []uint8{adcImmediate, 0x20, 0, 0, 0, 0, 0, 0},
 			wantCycles: 2,
 			wantRam:    []uint8{adcImmediate, 0x20, 0, 0, 0, 0, 0, 0},
-			wantState:  processor.State{PC: 2, A: 0x20, SP: processor.StackPointerStart},
+			wantState:  processor.State{PC: 2, A: 0x20, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
 		},
 		{
 			name:       "ADC immediate ; $21 to $20",
@@ -108,14 +121,14 @@ func instructionSetTests6502() []testCpuInstructionSet {
 			startRam:   []uint8{adcImmediate, 0x20, adcImmediate, 0x21, 0, 0, 0, 0},
 			wantCycles: 4,
 			wantRam:    []uint8{adcImmediate, 0x20, adcImmediate, 0x21, 0, 0, 0, 0},
-			wantState:  processor.State{PC: 4, A: 0x41, SP: processor.StackPointerStart},
+			wantState:  processor.State{PC: 4, A: 0x41, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
 		},
 		{
 			name:       "ADC zpg ; value at $0F ($20) to $0",
 			startRam:   []uint8{adcZeroPage, 0x0F, 0, 0, 0, 0, 0, 0x20},
 			wantCycles: 3,
 			wantRam:    []uint8{adcZeroPage, 0x0F, 0, 0, 0, 0, 0, 0x20},
-			wantState:  processor.State{PC: 2, A: 0x20, SP: processor.StackPointerStart},
+			wantState:  processor.State{PC: 2, A: 0x20, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
 		},
 		{
 			name:       "ADC zpg ; value $0F (0x20) to $11",
@@ -123,7 +136,31 @@ func instructionSetTests6502() []testCpuInstructionSet {
 			startRam:   []uint8{adcImmediate, 0x11, adcZeroPage, 0x0F, 0, 0, 0, 0x20},
 			wantCycles: 5,
 			wantRam:    []uint8{adcImmediate, 0x11, adcZeroPage, 0x0f, 0, 0, 0, 0x20},
-			wantState:  processor.State{PC: 4, A: 0x31, SP: processor.StackPointerStart},
+			wantState:  processor.State{PC: 4, A: 0x31, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
+		},
+		{
+			name:       "BNE not taken",
+			startState: processor.State{PC: 0x0000, SP: processor.StackPointerStart, P: processor.FlagZero},
+			startRam:   []uint8{bne, 0x04, 0, 0, 0, 0, 0, 0},
+			wantCycles: 2,
+			wantRam:    []uint8{bne, 0x04, 0, 0, 0, 0, 0, 0},
+			wantState:  processor.State{PC: 2, SP: processor.StackPointerStart, P: processor.FlagZero},
+		},
+		{
+			name:       "BNE taken",
+			startState: processor.State{PC: 0x0000, SP: processor.StackPointerStart},
+			startRam:   []uint8{bne, 0x04, 0, 0, 0, 0, 0, 0},
+			wantCycles: 3,
+			wantRam:    []uint8{bne, 0x04, 0, 0, 0, 0, 0, 0},
+			wantState:  processor.State{PC: 6, SP: processor.StackPointerStart},
+		},
+		{
+			name:       "BNE taken across a page boundary",
+			startState: processor.State{PC: 0x00F8, SP: processor.StackPointerStart},
+			startRam:   []uint8{bne, 0x10, 0, 0, 0, 0, 0, 0},
+			wantCycles: 4,
+			wantRam:    []uint8{bne, 0x10, 0, 0, 0, 0, 0, 0},
+			wantState:  processor.State{PC: 0x010A, SP: processor.StackPointerStart},
 		},
 		{
 			name:       "BRK",
@@ -138,7 +175,7 @@ func instructionSetTests6502() []testCpuInstructionSet {
 			wantCycles: 2,
 			startRam:   []uint8{nop, 0, 0, 0, 0, 0, 0, 0},
 			wantRam:    []uint8{nop, 0, 0, 0, 0, 0, 0, 0},
-			wantState:  processor.State{PC: 1, SP: processor.StackPointerStart},
+			wantState:  processor.State{PC: 1, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
 		},
 	}
 
@@ -161,6 +198,142 @@ func TestNew6502InstructionSet(t *testing.T) {
 	testInstructionSet(t, instructionSetTests6502(), instructionSet)
 }
 
+// This tests some of the undocumented 6502 instructions, validating the CPU and memory
+// state is correct afterward. It makes use of the testCpuMethod() function.
+func TestNewExtended6502InstructionSet(t *testing.T) {
+
+	instructionSetTestsExtended6502 := func() []testCpuInstructionSet {
+		tests := instructionSetTests6502()
+
+		tests = append(tests, []testCpuInstructionSet{
+			{
+				name:       "DCP zpg ; decrement $07 and compare with A",
+				startState: processor.State{A: 0x20, SP: processor.StackPointerStart},
+				startRam:   []uint8{dcpZeroPage, 0x07, 0, 0, 0, 0, 0, 0x21},
+				wantCycles: 5,
+				wantRam:    []uint8{dcpZeroPage, 0x07, 0, 0, 0, 0, 0, 0x20},
+				wantState:  processor.State{PC: 2, A: 0x20, SP: processor.StackPointerStart, P: processor.FlagZero | processor.FlagCarry},
+			},
+			{
+				name:       "LAX zpg ; load $07 into A and X",
+				startRam:   []uint8{laxZeroPage, 0x07, 0, 0, 0, 0, 0, 0x81},
+				wantCycles: 3,
+				wantRam:    []uint8{laxZeroPage, 0x07, 0, 0, 0, 0, 0, 0x81},
+				wantState:  processor.State{PC: 2, A: 0x81, X: 0x81, SP: processor.StackPointerStart, P: processor.FlagInterrupt | processor.FlagNegative},
+			},
+			{
+				name:       "NOP abs,X ; skips two bytes",
+				startRam:   []uint8{nopAbsoluteX, 0x00, 0x02, 0, 0, 0, 0, 0},
+				wantCycles: 4,
+				wantRam:    []uint8{nopAbsoluteX, 0x00, 0x02, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 3, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
+			},
+			{
+				name:       "NOP abs,X ; page boundary penalty",
+				startState: processor.State{X: 0x01, SP: processor.StackPointerStart},
+				startRam:   []uint8{nopAbsoluteX, 0xFF, 0x02, 0, 0, 0, 0, 0},
+				wantCycles: 5,
+				wantRam:    []uint8{nopAbsoluteX, 0xFF, 0x02, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 3, X: 0x01, SP: processor.StackPointerStart},
+			},
+			{
+				name:       "SBX immediate ; (A AND X) - $05",
+				startState: processor.State{A: 0xFF, X: 0x0F, SP: processor.StackPointerStart},
+				startRam:   []uint8{sbxImmediate, 0x05, 0, 0, 0, 0, 0, 0},
+				wantCycles: 2,
+				wantRam:    []uint8{sbxImmediate, 0x05, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 2, A: 0xFF, X: 0x0A, SP: processor.StackPointerStart, P: processor.FlagCarry},
+			},
+		}...)
+
+		return tests
+	}
+
+	instructionSet := func() processor.InstructionSet {
+		result, err := NewExtended6502InstructionSet(processor.DefaultMagicConstants)
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}
+
+	testInstructionSet(t, instructionSetTestsExtended6502(), instructionSet)
+}
+
+// This tests some 2A03 instructions, validating the CPU and memory state is
+// correct afterward. Everything but decimal mode is the same as the 6502.
+func TestNew2A03InstructionSet(t *testing.T) {
+
+	instructionSetTests2A03 := func() []testCpuInstructionSet {
+		tests := instructionSetTests6502()
+
+		tests = append(tests, []testCpuInstructionSet{
+			{
+				name:       "ADC immediate ; ignores the decimal flag",
+				startState: processor.State{A: 0x19, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+				startRam:   []uint8{adcImmediate, 0x28, 0, 0, 0, 0, 0, 0},
+				wantCycles: 2,
+				wantRam:    []uint8{adcImmediate, 0x28, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 2, A: 0x41, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+			},
+			{
+				name:       "SBC immediate ; ignores the decimal flag",
+				startState: processor.State{A: 0x20, SP: processor.StackPointerStart, P: processor.FlagDecimal | processor.FlagCarry},
+				startRam:   []uint8{sbcImmediate, 0x01, 0, 0, 0, 0, 0, 0},
+				wantCycles: 2,
+				wantRam:    []uint8{sbcImmediate, 0x01, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 2, A: 0x1F, SP: processor.StackPointerStart, P: processor.FlagDecimal | processor.FlagCarry},
+			},
+		}...)
+
+		return tests
+	}
+
+	instructionSet := func() processor.InstructionSet {
+		result, err := New2A03InstructionSet()
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}
+
+	testInstructionSet(t, instructionSetTests2A03(), instructionSet)
+}
+
+// This tests the undocumented 2A03 instructions that would use decimal mode on the
+// 6502, validating the CPU and memory state is correct afterward.
+func TestNewExtended2A03InstructionSet(t *testing.T) {
+
+	tests := []testCpuInstructionSet{
+		{
+			name:       "RRA zpg ; ignores the decimal flag",
+			startState: processor.State{A: 0x09, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+			startRam:   []uint8{rraZeroPage, 0x07, 0, 0, 0, 0, 0, 0x02},
+			wantCycles: 5,
+			wantRam:    []uint8{rraZeroPage, 0x07, 0, 0, 0, 0, 0, 0x01},
+			wantState:  processor.State{PC: 2, A: 0x0A, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+		},
+		{
+			name:       "ISC zpg ; ignores the decimal flag",
+			startState: processor.State{A: 0x10, SP: processor.StackPointerStart, P: processor.FlagDecimal | processor.FlagCarry},
+			startRam:   []uint8{iscZeroPage, 0x07, 0, 0, 0, 0, 0, 0x00},
+			wantCycles: 5,
+			wantRam:    []uint8{iscZeroPage, 0x07, 0, 0, 0, 0, 0, 0x01},
+			wantState:  processor.State{PC: 2, A: 0x0F, SP: processor.StackPointerStart, P: processor.FlagDecimal | processor.FlagCarry},
+		},
+	}
+
+	instructionSet := func() processor.InstructionSet {
+		result, err := NewExtended2A03InstructionSet(processor.DefaultMagicConstants)
+		if err != nil {
+			panic(err)
+		}
+		return result
+	}
+
+	testInstructionSet(t, tests, instructionSet)
+}
+
 // This tests some 65C02 instructions, validating the CPU and memory
 // state is correct afterward. It makes use of the testCpuMethod() function.
 // The exhaustive tests are done using the Klaus2m5 test suite.
@@ -169,7 +342,68 @@ func TestNew65C02InstructionSet(t *testing.T) {
 	instructionSetTests65C02 := func() []testCpuInstructionSet {
 		tests := instructionSetTests6502()
 
-		// TODO: Add some tests for specific 65C02 instructions.
+		tests = append(tests, []testCpuInstructionSet{
+			{
+				name:       "ADC immediate decimal ; $01 to $09 takes an extra cycle",
+				startState: processor.State{A: 0x09, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+				startRam:   []uint8{adcImmediate, 0x01, 0, 0, 0, 0, 0, 0},
+				wantCycles: 3,
+				wantRam:    []uint8{adcImmediate, 0x01, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 2, A: 0x10, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+			},
+			{
+				name:       "ADC immediate decimal ; $01 to $99 sets zero",
+				startState: processor.State{A: 0x99, SP: processor.StackPointerStart, P: processor.FlagDecimal},
+				startRam:   []uint8{adcImmediate, 0x01, 0, 0, 0, 0, 0, 0},
+				wantCycles: 3,
+				wantRam:    []uint8{adcImmediate, 0x01, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 2, A: 0x00, SP: processor.StackPointerStart, P: processor.FlagDecimal | processor.FlagZero | processor.FlagCarry},
+			},
+			{
+				name:       "BBS0 zpg,rel ; bit 0 of $07 is set so branch",
+				startRam:   []uint8{bbs0, 0x07, 0x02, 0, 0, 0, 0, 0x01},
+				wantCycles: 6,
+				wantRam:    []uint8{bbs0, 0x07, 0x02, 0, 0, 0, 0, 0x01},
+				wantState:  processor.State{PC: 5, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
+			},
+			{
+				name:       "BRA ; always branches",
+				startRam:   []uint8{bra, 0x02, 0, 0, 0, 0, 0, 0},
+				wantCycles: 3,
+				wantRam:    []uint8{bra, 0x02, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 4, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
+			},
+			{
+				name:       "BRK clears the decimal flag",
+				startState: processor.State{PC: 0xF0A0, SP: 0xFB, P: processor.FlagDecimal},
+				startRam:   []uint8{brk, 0, 0, 0, 0, 0, 0x02, 0xA0},
+				wantCycles: 7,
+				wantState:  processor.State{PC: 0xA002, SP: 0xF8, P: processor.FlagInterrupt},
+				wantRam:    []uint8{brk, processor.FlagConstant | processor.FlagBreak | processor.FlagDecimal, 0xA2, 0xF0, 0, 0, 0x02, 0xA0},
+			},
+			{
+				name:       "INC A",
+				startState: processor.State{A: 0x7F, SP: processor.StackPointerStart},
+				startRam:   []uint8{incA, 0, 0, 0, 0, 0, 0, 0},
+				wantCycles: 2,
+				wantRam:    []uint8{incA, 0, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 1, A: 0x80, SP: processor.StackPointerStart, P: processor.FlagNegative},
+			},
+			{
+				name:       "NOP ; undefined opcodes take a single cycle",
+				startRam:   []uint8{nopImplied, 0, 0, 0, 0, 0, 0, 0},
+				wantCycles: 1,
+				wantRam:    []uint8{nopImplied, 0, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 1, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
+			},
+			{
+				name:       "STZ zpg ; zero $07",
+				startRam:   []uint8{stzZeroPage, 0x07, 0, 0, 0, 0, 0, 0xAA},
+				wantCycles: 3,
+				wantRam:    []uint8{stzZeroPage, 0x07, 0, 0, 0, 0, 0, 0},
+				wantState:  processor.State{PC: 2, SP: processor.StackPointerStart, P: processor.FlagInterrupt},
+			},
+		}...)
 
 		return tests
 	}
@@ -184,3 +418,47 @@ func TestNew65C02InstructionSet(t *testing.T) {
 
 	testInstructionSet(t, instructionSetTests65C02(), instructionSet)
 }
+
+// Every instruction set must describe each of its instructions, so that they can be
+// disassembled without knowing which CPU the instruction set belongs to.
+func TestInstructionSet_Mnemonics(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func() (processor.InstructionSet, error)
+	}{
+		{name: "6502", new: New6502InstructionSet},
+		{name: "Extended 6502", new: func() (processor.InstructionSet, error) {
+			return NewExtended6502InstructionSet(processor.DefaultMagicConstants)
+		}},
+		{name: "2A03", new: New2A03InstructionSet},
+		{name: "Extended 2A03", new: func() (processor.InstructionSet, error) {
+			return NewExtended2A03InstructionSet(processor.DefaultMagicConstants)
+		}},
+		{name: "65C02", new: New65C02InstructionSet},
+		{name: "W65C02S", new: NewW65C02SInstructionSet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is, err := tt.new()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opcodes := is.Opcodes()
+			if got := len(is.Mnemonics()); got != len(opcodes) {
+				t.Errorf("Mnemonics() got = %v, want = %v", got, len(opcodes))
+			}
+			for _, opcode := range opcodes {
+				mnemonic, err := is.Mnemonic(opcode)
+				if err != nil {
+					t.Fatalf("Mnemonic($%02X) error = %v", opcode, err)
+				}
+				instruction, _ := is.Get(opcode)
+				details := processor.NewMnemonicDisplayDetails(mnemonic)
+				if mnemonic.Opcode != opcode || details.Cycles != instruction.Cycles+1 {
+					t.Errorf("Mnemonic($%02X) got = %+v, instruction = %+v", opcode, details, instruction)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package nmos
+
+import "go6502/pkg/processor"
+
+// The registers of the on-chip I/O port of the 6510.
+const (
+	IOPortDirectionAddress = 0x0000 // The data direction register; a set bit is an output.
+	IOPortDataAddress      = 0x0001 // The output latch, which reads back the level of the pins.
+)
+
+// IOPortFadeCycles is approximately how many cycles an unconnected pin holds the level it
+// was driven to before fading back to 1, once its direction is changed to an input.
+const IOPortFadeCycles = 350000
+
+// ioPortUnconnectedPins are the pins that have no external connection. The 6510 only has
+// six port pins, P0 to P5, so bits 6 and 7 of the port are not connected to anything.
+const ioPortUnconnectedPins = 0xC0
+
+// IOPort is the on-chip I/O port of the MOS 6510, as used by the Commodore 64. It wraps the
+// Memory of the Cpu, handling reads and writes to the data direction register at $0000 and
+// the port at $0001 and passing all other accesses to the Memory.
+//
+// On the C64 the port controls the banking of the ROMs (P0 to P2) and the cassette lines
+// (P3 to P5). Input pins are pulled up, so they read as 1 unless the host drives them low
+// using SetInputs. An unconnected pin that is changed to an input holds the level it was
+// driven to for a while (see IOPortFadeCycles) before fading back to 1. Time is measured
+// in the cycles of the Clock given to SetClock. Without a Clock it is measured in bus
+// accesses, assuming there is one per cycle. That only holds when the Cpu is driven by
+// Tick, as Step does not make the dummy reads, so the pins then take longer to fade.
+//
+// The kind of each access and any faults are passed on to and from the Memory if it is a
+// processor.AccessMemory or a processor.FaultingMemory, such as a processor.Bus.
+type IOPort struct {
+	memory    processor.Memory
+	accesses  processor.AccessMemory   // The memory if it is told the kind of access, otherwise nil.
+	faults    processor.FaultingMemory // The memory if it can report faults, otherwise nil.
+	output    func(pins uint8)
+	direction uint8
+	data      uint8
+	inputs    uint8 // The levels driven onto the input pins by the host.
+	charge    uint8 // The levels held by the unconnected pins that are fading.
+	fading    uint8 // The unconnected pins that are fading.
+	fade      uint  // The number of accesses until the fading pins read as 1, without a Clock.
+	clock     processor.Clock
+	fadeAt    uint64 // The cycle when the fading pins read as 1, with a Clock.
+	pins      uint8  // The levels of the pins last reported to output.
+}
+
+// NewIOPort returns an IOPort that wraps memory. After any change to the levels of the
+// pins, output is called with the new levels; it may be nil. All the pins are initially
+// inputs, so output is called with 0xFF.
+func NewIOPort(memory processor.Memory, output func(pins uint8)) *IOPort {
+	accesses, _ := memory.(processor.AccessMemory)
+	faults, _ := memory.(processor.FaultingMemory)
+	port := &IOPort{memory: memory, accesses: accesses, faults: faults, output: output, inputs: 0xFF}
+	port.pins = ^port.Pins()
+	port.notify()
+	return port
+}
+
+// SetClock sets the Clock used to time the fading of the unconnected pins, which is normally
+// the Cpu the port is attached to. As the Cpu is returned by value from New6510Cpu this
+// must be called once it is in its final place. A nil Clock counts bus accesses instead.
+func (p *IOPort) SetClock(clock processor.Clock) {
+	p.clock = clock
+}
+
+// Reset changes all the pins to inputs and clears the output latch, as a reset of the
+// 6510 does. A reset of the Cpu does not reset the port, so this must be called as well.
+func (p *IOPort) Reset() {
+	p.direction = 0
+	p.data = 0
+	p.fading = 0
+	p.fade = 0
+	p.notify()
+}
+
+// Pins returns the levels of the port pins. An output pin is at the level of the output
+// latch and an input pin is at the level driven by the host.
+func (p *IOPort) Pins() uint8 {
+	inputs := p.inputs&^p.fading | p.charge&p.fading
+	return p.data&p.direction | inputs&^p.direction
+}
+
+// SetInputs sets the levels the host drives onto the pins, such as the cassette sense line.
+// These are only seen on the pins that are inputs. A pin that is not driven by the host
+// should be set to 1, its pulled up level.
+func (p *IOPort) SetInputs(levels uint8) {
+	p.inputs = levels
+	p.notify()
+}
+
+// Read returns the data direction register, the levels of the port pins or the contents
+// of memory.
+func (p *IOPort) Read(address processor.Address) uint8 {
+	return p.AccessRead(address, processor.ReadAccess)
+}
+
+// Write sets the data direction register, the output latch or the contents of memory.
+func (p *IOPort) Write(address processor.Address, value uint8) {
+	p.AccessWrite(address, value, processor.WriteAccess)
+}
+
+// AccessRead is Read, passing the kind of access on to the memory. See
+// processor.AccessMemory.
+func (p *IOPort) AccessRead(address processor.Address, access processor.Access) uint8 {
+	p.elapse()
+
+	switch address {
+	case IOPortDirectionAddress:
+		return p.direction
+	case IOPortDataAddress:
+		return p.Pins()
+	}
+	if p.accesses != nil {
+		return p.accesses.AccessRead(address, access)
+	}
+	return p.memory.Read(address)
+}
+
+// AccessWrite is Write, passing the kind of access on to the memory. See
+// processor.AccessMemory.
+func (p *IOPort) AccessWrite(address processor.Address, value uint8, access processor.Access) {
+	p.elapse()
+
+	switch address {
+	case IOPortDirectionAddress:
+		// The unconnected pins that change to inputs hold their level, while any pins that
+		// change back to outputs stop fading.
+		if released := p.direction &^ value & ioPortUnconnectedPins; released != 0 {
+			p.charge = p.charge&^released | p.data&released
+			p.fading |= released
+			p.fade = IOPortFadeCycles
+			if p.clock != nil {
+				p.fadeAt = p.clock.Cycles() + IOPortFadeCycles
+			}
+		}
+		p.fading &^= value
+		p.direction = value
+	case IOPortDataAddress:
+		p.data = value
+	default:
+		if p.accesses != nil {
+			p.accesses.AccessWrite(address, value, access)
+		} else {
+			p.memory.Write(address, value)
+		}
+		return
+	}
+	p.notify()
+}
+
+// Fault returns the first fault reported by the memory since it was last called, clearing
+// it. See processor.FaultingMemory.
+func (p *IOPort) Fault() (processor.MemoryFaultError, bool) {
+	if p.faults == nil {
+		return processor.MemoryFaultError{}, false
+	}
+	return p.faults.Fault()
+}
+
+// elapse checks whether the unconnected pins have faded, counting down by a single access
+// if there is no Clock.
+func (p *IOPort) elapse() {
+	if p.fade == 0 {
+		return
+	}
+	if p.clock == nil {
+		p.fade--
+	} else if p.clock.Cycles() >= p.fadeAt {
+		p.fade = 0
+	}
+	if p.fade == 0 {
+		p.fading = 0
+		p.notify()
+	}
+}
+
+// notify calls output if the levels of the pins have changed.
+func (p *IOPort) notify() {
+	pins := p.Pins()
+	if pins == p.pins {
+		return
+	}
+	p.pins = pins
+	if p.output != nil {
+		p.output(pins)
+	}
+}
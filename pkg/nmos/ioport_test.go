@@ -0,0 +1,303 @@
+package nmos
+
+import (
+	"errors"
+	"go6502/pkg/processor"
+	"reflect"
+	"testing"
+)
+
+func TestIOPort(t *testing.T) {
+	type access struct {
+		write   bool
+		address processor.Address
+		value   uint8
+	}
+
+	tests := []struct {
+		name       string
+		accesses   []access
+		inputs     uint8
+		wantPins   uint8
+		wantOutput []uint8
+	}{
+		{
+			name:       "All the pins are pulled up inputs",
+			inputs:     0xFF,
+			wantPins:   0xFF,
+			wantOutput: []uint8{0xFF},
+		},
+		{
+			name:       "Output latch is ignored while the pins are inputs",
+			accesses:   []access{{write: true, address: IOPortDataAddress, value: 0x00}},
+			inputs:     0xFF,
+			wantPins:   0xFF,
+			wantOutput: []uint8{0xFF},
+		},
+		{
+			name: "The C64 default of $2F and $37",
+			accesses: []access{
+				{write: true, address: IOPortDirectionAddress, value: 0x2F},
+				{write: true, address: IOPortDataAddress, value: 0x37},
+			},
+			inputs:     0xFF,
+			wantPins:   0xF7,
+			wantOutput: []uint8{0xFF, 0xD0, 0xF7},
+		},
+		{
+			name: "Switching out the BASIC ROM",
+			accesses: []access{
+				{write: true, address: IOPortDirectionAddress, value: 0x2F},
+				{write: true, address: IOPortDataAddress, value: 0x37},
+				{write: true, address: IOPortDataAddress, value: 0x36},
+			},
+			inputs:     0xFF,
+			wantPins:   0xF6,
+			wantOutput: []uint8{0xFF, 0xD0, 0xF7, 0xF6},
+		},
+		{
+			name: "The host drives the cassette sense input low",
+			accesses: []access{
+				{write: true, address: IOPortDirectionAddress, value: 0x2F},
+				{write: true, address: IOPortDataAddress, value: 0x37},
+			},
+			inputs:     0xEF,
+			wantPins:   0xE7,
+			wantOutput: []uint8{0xFF, 0xD0, 0xF7, 0xE7},
+		},
+		{
+			name: "Memory is unaffected by the port",
+			accesses: []access{
+				{write: true, address: 0x0002, value: 0x12},
+				{address: 0x0002},
+			},
+			inputs:     0xFF,
+			wantPins:   0xFF,
+			wantOutput: []uint8{0xFF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram := processor.FlatRam{}
+			var output []uint8
+			port := NewIOPort(&ram, func(pins uint8) { output = append(output, pins) })
+
+			for _, a := range tt.accesses {
+				if a.write {
+					port.Write(a.address, a.value)
+				} else if got := port.Read(a.address); got != ram[a.address] {
+					t.Errorf("Read($%04X) got = $%02X, want = $%02X", a.address, got, ram[a.address])
+				}
+			}
+			port.SetInputs(tt.inputs)
+
+			if got := port.Pins(); got != tt.wantPins {
+				t.Errorf("Pins() got = $%02X, want = $%02X", got, tt.wantPins)
+			}
+			if got := port.Read(IOPortDataAddress); got != tt.wantPins {
+				t.Errorf("Read($0001) got = $%02X, want = $%02X", got, tt.wantPins)
+			}
+			if !reflect.DeepEqual(output, tt.wantOutput) {
+				t.Errorf("output got = %X, want = %X", output, tt.wantOutput)
+			}
+			if ram[IOPortDirectionAddress] != 0 || ram[IOPortDataAddress] != 0 {
+				t.Errorf("the port registers were written to memory")
+			}
+		})
+	}
+}
+
+func TestIOPort_Fade(t *testing.T) {
+	ram := processor.FlatRam{}
+	var output []uint8
+	port := NewIOPort(&ram, func(pins uint8) { output = append(output, pins) })
+
+	// Drive the unconnected pins low, then change all but the ROM banking pins to inputs.
+	port.Write(IOPortDirectionAddress, 0xFF)
+	port.Write(IOPortDataAddress, 0x07)
+	port.Write(IOPortDirectionAddress, 0x07)
+	if got := port.Read(IOPortDirectionAddress); got != 0x07 {
+		t.Errorf("Read($0000) got = $%02X, want = $07", got)
+	}
+
+	for range IOPortFadeCycles - 3 {
+		port.Read(0x0200)
+	}
+	if got := port.Read(IOPortDataAddress); got != 0x3F {
+		t.Errorf("Read($0001) before fading got = $%02X, want = $3F", got)
+	}
+	if got := port.Read(IOPortDataAddress); got != 0xFF {
+		t.Errorf("Read($0001) after fading got = $%02X, want = $FF", got)
+	}
+
+	want := []uint8{0xFF, 0x00, 0x07, 0x3F, 0xFF}
+	if !reflect.DeepEqual(output, want) {
+		t.Errorf("output got = %X, want = %X", output, want)
+	}
+}
+
+func TestIOPort_Fade_StopsWhenDriven(t *testing.T) {
+	ram := processor.FlatRam{}
+	port := NewIOPort(&ram, nil)
+
+	port.Write(IOPortDirectionAddress, 0xFF)
+	port.Write(IOPortDataAddress, 0x00)
+	port.Write(IOPortDirectionAddress, 0x00)
+	if got := port.Pins(); got != 0x3F {
+		t.Errorf("Pins() while fading got = $%02X, want = $3F", got)
+	}
+
+	// Driving the pins again stops them fading, so they remain low.
+	port.Write(IOPortDirectionAddress, 0xC0)
+	for range IOPortFadeCycles {
+		port.Read(0x0200)
+	}
+	if got := port.Pins(); got != 0x3F {
+		t.Errorf("Pins() when driven got = $%02X, want = $3F", got)
+	}
+}
+
+func TestIOPort_Reset(t *testing.T) {
+	ram := processor.FlatRam{}
+	var output []uint8
+	port := NewIOPort(&ram, func(pins uint8) { output = append(output, pins) })
+
+	port.Write(IOPortDirectionAddress, 0x07)
+	port.Reset()
+
+	if port.Read(IOPortDirectionAddress) != 0 || port.Pins() != 0xFF {
+		t.Errorf("Reset() did not change the pins to inputs, got = $%02X", port.Pins())
+	}
+	want := []uint8{0xFF, 0xF8, 0xFF}
+	if !reflect.DeepEqual(output, want) {
+		t.Errorf("output got = %X, want = %X", output, want)
+	}
+}
+
+func TestIOPort_FadeWithClock(t *testing.T) {
+	// Under Step the NOPs make fewer bus accesses than cycles, so the pins only fade after
+	// the same number of cycles as under Tick because they are timed by the Clock.
+	for _, tick := range []bool{false, true} {
+		ram := processor.FlatRam{}
+		copy(ram[0x0200:], []uint8{
+			0xA9, 0xFF, // LDA #$FF
+			0x85, 0x00, // STA $00
+			0xA9, 0x00, // LDA #$00
+			0x85, 0x01, // STA $01
+			0x85, 0x00, // STA $00
+			0xEA,             // NOP
+			0xEA,             // NOP
+			0x4C, 0x0A, 0x02, // JMP $020A
+		})
+
+		var cpu processor.Cpu
+		var released, faded uint64
+		cpu, err := New6510Cpu(&ram, func(pins uint8) {
+			switch pins {
+			case 0x3F:
+				released = cpu.Cycles()
+			case 0xFF:
+				faded = cpu.Cycles()
+			}
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		memory, _ := cpu.Memory()
+		memory.(*IOPort).SetClock(&cpu)
+		cpu.State.PC = 0x0200
+
+		for faded == 0 && cpu.Cycles() < 2*IOPortFadeCycles {
+			if tick {
+				_, err = cpu.Tick()
+			} else {
+				_, err = cpu.Step()
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// The fade is seen by the first access once the time has passed.
+		if elapsed := faded - released; elapsed < IOPortFadeCycles || elapsed > IOPortFadeCycles+7 {
+			t.Errorf("Tick %v the pins faded after %v cycles, want = %v", tick, elapsed, IOPortFadeCycles)
+		}
+	}
+}
+
+// accessRam is a full 64K processor.AccessMemory that records the kind of every access.
+type accessRam struct {
+	ram      processor.FlatRam
+	accesses []recordedAccess
+}
+
+type recordedAccess struct {
+	address processor.Address
+	access  processor.Access
+}
+
+func (m *accessRam) Read(address processor.Address) uint8 {
+	return m.AccessRead(address, processor.ReadAccess)
+}
+
+func (m *accessRam) Write(address processor.Address, value uint8) {
+	m.AccessWrite(address, value, processor.WriteAccess)
+}
+
+func (m *accessRam) AccessRead(address processor.Address, access processor.Access) uint8 {
+	m.accesses = append(m.accesses, recordedAccess{address: address, access: access})
+	return m.ram[address]
+}
+
+func (m *accessRam) AccessWrite(address processor.Address, value uint8, access processor.Access) {
+	m.accesses = append(m.accesses, recordedAccess{address: address, access: access})
+	m.ram[address] = value
+}
+
+func TestIOPort_Bus(t *testing.T) {
+	device := &accessRam{}
+	copy(device.ram[0x0200:], []uint8{
+		0x8D, 0x34, 0x12, // STA $1234
+		0xAD, 0x00, 0x90, // LDA $9000
+	})
+	vectors := processor.NewPopulatedRam(processor.EightBytes, nil)
+	bus, err := processor.NewBusBuilder().
+		Map("Device", 0x0000, 0x7FFF, device).
+		Map("Vectors", 0xFFF8, 0xFFFF, &vectors).
+		WithUnmappedPolicy(processor.FaultOnBus).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := processor.WriteResetVectorToMemory(bus, 0x0200); err != nil {
+		t.Fatal(err)
+	}
+	cpu, err := New6510Cpu(bus, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The kinds of the accesses made by the Cpu reach the device through the IOPort.
+	device.accesses = nil
+	if _, err := cpu.Step(); err != nil {
+		t.Fatal(err)
+	}
+	want := []recordedAccess{
+		{address: 0x0200, access: processor.OpcodeFetchAccess},
+		{address: 0x0201, access: processor.OperandAccess},
+		{address: 0x0202, access: processor.OperandAccess},
+		{address: 0x1234, access: processor.WriteAccess},
+	}
+	if !reflect.DeepEqual(device.accesses, want) {
+		t.Errorf("STA $1234 accesses got = %v, want = %v", device.accesses, want)
+	}
+
+	// A fault on the Bus is returned by Step through the IOPort.
+	var fault processor.MemoryFaultError
+	if _, err := cpu.Step(); !errors.As(err, &fault) || fault.Address != 0x9000 || fault.Access != processor.ReadAccess {
+		t.Errorf("Step() error got = %v, want a read fault at $9000", err)
+	}
+}
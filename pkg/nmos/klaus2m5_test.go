@@ -6,25 +6,6 @@ import (
 	"testing"
 )
 
-type Ram struct {
-	ram []uint8
-}
-
-func (r *Ram) Read(address processor.Address) uint8 {
-	if r == nil {
-		return 0
-	}
-	return r.ram[address]
-}
-
-// Write a value to the repeating RAM.
-func (r *Ram) Write(address processor.Address, value uint8) {
-	if r == nil {
-		return
-	}
-	r.ram[address] = value
-}
-
 //   - Validating your emulator. There are a range of resources for this, including:
 //     GitHub - Klaus2m5/6502_65C02_functional_tests: Tests for all valid opcodes of the 6502 and 65C02 processor
 //     https://github.com/Klaus2m5/6502_65C02_functional_tests
@@ -37,15 +18,100 @@ func (r *Ram) Write(address processor.Address, value uint8) {
 //     https://github.com/tom-seddon/b2/tree/master/etc/testsuite-2.15
 func TestUsingKlaus2m5FunctionalTest(t *testing.T) {
 
-	ram := Ram{
-		ram: make([]byte, 0x10000),
+	ram := loadKlaus2m5Test(t, "6502_functional_test.bin")
+
+	cpu, err := New6502Cpu(ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cpu.Reset()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const CYCLES = 100_000_000
+
+	for cpu.Cycles() < CYCLES {
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Early exist if we get to the correct success location.
+		if cpu.State.PC == 0x3469 {
+			break
+		}
+	}
+
+	t.Logf("State: %v, Counters %+v", cpu.State, cpu.Counters())
+	if cpu.Counters() != klaus2m5FunctionalCounters {
+		t.Errorf("Counters() got = %+v, want = %+v", cpu.Counters(), klaus2m5FunctionalCounters)
+	}
+	if cpu.State.PC == 0x3469 {
+		t.Logf("SUCCESS")
+	} else {
+		t.Fatal("FAIL")
+
+	}
+}
+
+// The same functional test driven one clock cycle at a time using Tick.
+func TestUsingKlaus2m5FunctionalTestWithTick(t *testing.T) {
+
+	ram := loadKlaus2m5Test(t, "6502_functional_test.bin")
+
+	cpu, err := New6502Cpu(ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cpu.Reset()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const CYCLES = 100_000_000
+
+	for cpu.Cycles() < CYCLES {
+		done, err := cpu.Tick()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Early exist if we get to the correct success location.
+		if done && cpu.State.PC == 0x3469 {
+			break
+		}
+	}
+
+	t.Logf("State: %v, Counters %+v", cpu.State, cpu.Counters())
+	if cpu.Counters() != klaus2m5FunctionalCounters {
+		t.Errorf("Counters() got = %+v, want = %+v", cpu.Counters(), klaus2m5FunctionalCounters)
+	}
+	if cpu.State.PC == 0x3469 {
+		t.Logf("SUCCESS")
+	} else {
+		t.Fatal("FAIL")
 	}
+}
+
+// klaus2m5FunctionalCounters are the counters of the Cpu once the functional test has
+// succeeded, including the reset. They are the same whether it is run by Step or Tick.
+var klaus2m5FunctionalCounters = processor.Counters{Cycles: 96241371, Instructions: 30646176}
 
-	f, err := os.Open("6502_functional_test.bin")
+// loadKlaus2m5Test loads the named test image into a FlatRam with the reset
+// vector pointing at the start of the test.
+func loadKlaus2m5Test(t testing.TB, name string) *processor.FlatRam {
+	ram := processor.NewFlatRam()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := ram.ReadFrom(f)
 	if err != nil {
 		t.Fatal(err)
 	}
-	count, err := f.Read(ram.ram)
 	if count != 0x10000 {
 		t.Errorf("wrong number of bytes read, expected 0x10000, got 0x%x", count)
 	}
@@ -56,44 +122,110 @@ func TestUsingKlaus2m5FunctionalTest(t *testing.T) {
 	}
 
 	// Override reset vector to correct starting location!
-	err = processor.WriteResetVectorToMemory(&ram, 0x400)
+	err = processor.WriteResetVectorToMemory(ram, 0x400)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	cpu, err := New6502Cpu(&ram)
+	return ram
+}
+
+// BenchmarkKlaus2m5FunctionalTest runs the functional test to completion using Step
+// and reports the speed of the emulated CPU in MHz.
+func BenchmarkKlaus2m5FunctionalTest(b *testing.B) {
+	image := loadKlaus2m5Test(b, "6502_functional_test.bin")
+	ram := processor.NewFlatRam()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	cycles := uint64(0)
+	for range b.N {
+		b.StopTimer()
+		*ram = *image
+		cpu, err := New6502Cpu(ram)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := cpu.Reset(); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		for cpu.State.PC != 0x3469 {
+			if _, err := cpu.Step(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		cycles += cpu.Cycles()
+	}
+	b.ReportMetric(float64(cycles)/b.Elapsed().Seconds()/1e6, "MHz")
+}
+
+// BenchmarkKlaus2m5FunctionalTestWithTick runs the functional test to completion using
+// Tick and reports the speed of the emulated CPU in MHz.
+func BenchmarkKlaus2m5FunctionalTestWithTick(b *testing.B) {
+	image := loadKlaus2m5Test(b, "6502_functional_test.bin")
+	ram := processor.NewFlatRam()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	cycles := uint64(0)
+	for range b.N {
+		b.StopTimer()
+		*ram = *image
+		cpu, err := New6502Cpu(ram)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := cpu.Reset(); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		for done := false; !done || cpu.State.PC != 0x3469; {
+			if done, err = cpu.Tick(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		cycles += cpu.Cycles()
+	}
+	b.ReportMetric(float64(cycles)/b.Elapsed().Seconds()/1e6, "MHz")
+}
+
+// The 65C02 extended opcode test from Klaus. The test image was assembled with the
+// Rockwell and WDC bit instructions enabled, WAI and STP disabled and the undefined
+// opcodes tested as NOPs.
+func TestUsingKlaus2m5ExtendedOpcodesTest(t *testing.T) {
+
+	ram := loadKlaus2m5Test(t, "65C02_extended_opcodes_test.bin")
+
+	cpu, err := New65C02Cpu(ram)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = cpu.Reset()
+	_, err = cpu.Reset()
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	const CYCLES = 100_000_000
-	cycles := uint(0)
 
-	for cycles < CYCLES {
-		c, err := cpu.Step()
-		cycles += c
-		if err != nil {
+	for cpu.Cycles() < CYCLES {
+		if _, err := cpu.Step(); err != nil {
 			t.Fatal(err)
 		}
 
 		// Early exist if we get to the correct success location.
-		if cpu.State.PC == 0x3469 {
+		if cpu.State.PC == 0x24F1 {
 			break
 		}
 	}
 
-	t.Logf("State: %v, Cycles %v", cpu.State, cycles)
-	if cpu.State.PC == 0x3469 {
+	t.Logf("State: %v, Counters %+v", cpu.State, cpu.Counters())
+	if cpu.State.PC == 0x24F1 {
 		t.Logf("SUCCESS")
 	} else {
 		t.Fatal("FAIL")
-
 	}
 }
-
-// TODO: Add the 65C02 extended opcode test from Klaus.
@@ -2,9 +2,6 @@ package nmos
 
 import "go6502/pkg/processor"
 
-// TODO: NewExtendedCpu(memory Memory): Returns a Cpu with the full extended instruction set.
-// TODO: Optionally fill in unused opcodes with Nops or Traps to catch issues.
-
 // New6502Cpu returns a Cpu with the standard 6502 instruction set.
 func New6502Cpu(memory processor.Memory) (processor.Cpu, error) {
 	is, err := New6502InstructionSet()
@@ -14,7 +11,70 @@ func New6502Cpu(memory processor.Memory) (processor.Cpu, error) {
 	return processor.NewCpu(is, memory)
 }
 
-// New65C02Cpu returns a Cpu with the standard 65C02 instruction set.
+// NewExtended6502Cpu returns a Cpu with the standard 6502 instruction set extended with
+// the undocumented NMOS 6502 opcodes. The unstable opcodes use the default magic constants;
+// use NewExtended6502InstructionSet() to create a Cpu with different magic constants.
+func NewExtended6502Cpu(memory processor.Memory) (processor.Cpu, error) {
+	is, err := NewExtended6502InstructionSet(processor.DefaultMagicConstants)
+	if err != nil {
+		return processor.Cpu{}, err
+	}
+	return processor.NewCpu(is, memory)
+}
+
+// New2A03Cpu returns a Cpu with the Ricoh 2A03 instruction set, as used by the NES.
+// This is the same as the 6502 except that the decimal flag is ignored by ADC and SBC.
+// The PAL 2A07 has the same CPU core.
+func New2A03Cpu(memory processor.Memory) (processor.Cpu, error) {
+	is, err := New2A03InstructionSet()
+	if err != nil {
+		return processor.Cpu{}, err
+	}
+	return processor.NewCpu(is, memory)
+}
+
+// NewExtended2A03Cpu returns a Cpu with the Ricoh 2A03 instruction set extended with
+// the undocumented opcodes, which are commonly used by NES software. The unstable
+// opcodes use the default magic constants.
+func NewExtended2A03Cpu(memory processor.Memory) (processor.Cpu, error) {
+	is, err := NewExtended2A03InstructionSet(processor.DefaultMagicConstants)
+	if err != nil {
+		return processor.Cpu{}, err
+	}
+	return processor.NewCpu(is, memory)
+}
+
+// New6510Cpu returns a Cpu with the standard 6502 instruction set and the on-chip I/O
+// port of the MOS 6510, as used by the C64. The memory is wrapped by an IOPort that handles
+// $0000 and $0001, calling output whenever the levels of the port pins change so that the
+// host can switch banks. The IOPort is returned by the Memory method of the Cpu.
+func New6510Cpu(memory processor.Memory, output func(pins uint8)) (processor.Cpu, error) {
+	if memory == nil {
+		return processor.Cpu{}, processor.MemoryMustBeProvided
+	}
+	is, err := New6502InstructionSet()
+	if err != nil {
+		return processor.Cpu{}, err
+	}
+	return processor.NewCpu(is, NewIOPort(memory, output))
+}
+
+// NewExtended6510Cpu returns a Cpu with the 6510 I/O port and the standard 6502 instruction
+// set extended with the undocumented opcodes, which are commonly used by C64 software. The
+// unstable opcodes use the default magic constants.
+func NewExtended6510Cpu(memory processor.Memory, output func(pins uint8)) (processor.Cpu, error) {
+	if memory == nil {
+		return processor.Cpu{}, processor.MemoryMustBeProvided
+	}
+	is, err := NewExtended6502InstructionSet(processor.DefaultMagicConstants)
+	if err != nil {
+		return processor.Cpu{}, err
+	}
+	return processor.NewCpu(is, NewIOPort(memory, output))
+}
+
+// New65C02Cpu returns a Cpu with the standard 65C02 instruction set. Tick is not
+// cycle-accurate for the 65C02, executing each instruction in its first cycle.
 func New65C02Cpu(memory processor.Memory) (processor.Cpu, error) {
 	is, err := New65C02InstructionSet()
 	if err != nil {
@@ -22,3 +82,13 @@ func New65C02Cpu(memory processor.Memory) (processor.Cpu, error) {
 	}
 	return processor.NewCpu(is, memory)
 }
+
+// NewW65C02SCpu returns a Cpu with the WDC W65C02S instruction set, which includes
+// WAI and STP. As for the 65C02, Tick executes each instruction in its first cycle.
+func NewW65C02SCpu(memory processor.Memory) (processor.Cpu, error) {
+	is, err := NewW65C02SInstructionSet()
+	if err != nil {
+		return processor.Cpu{}, err
+	}
+	return processor.NewCpu(is, memory)
+}
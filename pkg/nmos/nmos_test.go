@@ -69,6 +69,263 @@ func TestNew6502Cpu(t *testing.T) {
 	}
 }
 
+func TestNewExtended6502Cpu(t *testing.T) {
+	memory, err := processor.NewRepeatingRam(processor.SixteenBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	is, err := NewExtended6502InstructionSet(processor.DefaultMagicConstants)
+	if err != nil {
+		panic(err)
+	}
+
+	want, err := processor.NewCpu(is, &memory)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name    string
+		memory  processor.Memory
+		wantErr bool
+	}{
+		{
+			name:    "Nil memory should error",
+			wantErr: true,
+		},
+		{
+			name:   "Valid memory should be fine",
+			memory: &memory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExtended6502Cpu(tt.memory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewExtended6502Cpu() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got.State, want.State) {
+				t.Errorf("NewExtended6502Cpu() got State = %v, want State %v", got.State, want.State)
+			}
+
+			gotMemory, _ := got.Memory()
+			if !reflect.DeepEqual(gotMemory, tt.memory) {
+				t.Errorf("NewExtended6502Cpu() got memory = %v, want memory %v", gotMemory, tt.memory)
+			}
+
+			// We can only really compare opcode as reflect.DeepEqual does not work with function pointers.
+			wantOpcodes, _ := want.Opcodes()
+			gotOpcodes, _ := got.Opcodes()
+
+			if !reflect.DeepEqual(wantOpcodes, gotOpcodes) {
+				t.Errorf("NewExtended6502Cpu() got opcodes = %v, want opcodes %v", wantOpcodes, gotOpcodes)
+			}
+
+			// Every opcode, including the 12 JAM opcodes, is supported.
+			if len(gotOpcodes) != 0x100 {
+				t.Errorf("NewExtended6502Cpu() got %v opcodes, want %v", len(gotOpcodes), 0x100)
+			}
+		})
+	}
+}
+
+func TestNew2A03Cpu(t *testing.T) {
+	memory, err := processor.NewRepeatingRam(processor.SixteenBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	is, err := New2A03InstructionSet()
+	if err != nil {
+		panic(err)
+	}
+
+	want, err := processor.NewCpu(is, &memory)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name    string
+		memory  processor.Memory
+		wantErr bool
+	}{
+		{
+			name:    "Nil memory should error",
+			wantErr: true,
+		},
+		{
+			name:   "Valid memory should be fine",
+			memory: &memory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New2A03Cpu(tt.memory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New2A03Cpu() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got.State, want.State) {
+				t.Errorf("New2A03Cpu() got State = %v, want State %v", got.State, want.State)
+			}
+
+			gotMemory, _ := got.Memory()
+			if !reflect.DeepEqual(gotMemory, tt.memory) {
+				t.Errorf("New2A03Cpu() got memory = %v, want memory %v", gotMemory, tt.memory)
+			}
+
+			// We can only really compare opcode as reflect.DeepEqual does not work with function pointers.
+			wantOpcodes, _ := want.Opcodes()
+			gotOpcodes, _ := got.Opcodes()
+
+			if !reflect.DeepEqual(wantOpcodes, gotOpcodes) {
+				t.Errorf("New2A03Cpu() got opcodes = %v, want opcodes %v", wantOpcodes, gotOpcodes)
+			}
+		})
+	}
+}
+
+func TestNewExtended2A03Cpu(t *testing.T) {
+	memory, err := processor.NewRepeatingRam(processor.SixteenBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	is, err := NewExtended2A03InstructionSet(processor.DefaultMagicConstants)
+	if err != nil {
+		panic(err)
+	}
+
+	want, err := processor.NewCpu(is, &memory)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name    string
+		memory  processor.Memory
+		wantErr bool
+	}{
+		{
+			name:    "Nil memory should error",
+			wantErr: true,
+		},
+		{
+			name:   "Valid memory should be fine",
+			memory: &memory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExtended2A03Cpu(tt.memory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewExtended2A03Cpu() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got.State, want.State) {
+				t.Errorf("NewExtended2A03Cpu() got State = %v, want State %v", got.State, want.State)
+			}
+
+			gotMemory, _ := got.Memory()
+			if !reflect.DeepEqual(gotMemory, tt.memory) {
+				t.Errorf("NewExtended2A03Cpu() got memory = %v, want memory %v", gotMemory, tt.memory)
+			}
+
+			// We can only really compare opcode as reflect.DeepEqual does not work with function pointers.
+			wantOpcodes, _ := want.Opcodes()
+			gotOpcodes, _ := got.Opcodes()
+
+			if !reflect.DeepEqual(wantOpcodes, gotOpcodes) {
+				t.Errorf("NewExtended2A03Cpu() got opcodes = %v, want opcodes %v", wantOpcodes, gotOpcodes)
+			}
+
+			// Every opcode, including the 12 JAM opcodes, is supported.
+			if len(gotOpcodes) != 0x100 {
+				t.Errorf("NewExtended2A03Cpu() got %v opcodes, want %v", len(gotOpcodes), 0x100)
+			}
+		})
+	}
+}
+
+func TestNew6510Cpu(t *testing.T) {
+	tests := []struct {
+		name        string
+		newCpu      func(processor.Memory, func(uint8)) (processor.Cpu, error)
+		wantOpcodes int
+	}{
+		{name: "6510", newCpu: New6510Cpu, wantOpcodes: 151},
+		{name: "Extended 6510", newCpu: NewExtended6510Cpu, wantOpcodes: 0x100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.newCpu(nil, nil); err != processor.MemoryMustBeProvided {
+				t.Errorf("%v with nil memory error = %v, want %v", tt.name, err, processor.MemoryMustBeProvided)
+			}
+
+			ram := processor.FlatRam{}
+			copy(ram[0x0200:], []uint8{
+				0xA9, 0x2F, // LDA #$2F
+				0x85, 0x00, // STA $00
+				0xA9, 0x35, // LDA #$35
+				0x85, 0x01, // STA $01
+				0xA5, 0x01, // LDA $01
+			})
+			ram[processor.ResetVectorAddress+1] = 0x02
+
+			var output []uint8
+			cpu, err := tt.newCpu(&ram, func(pins uint8) { output = append(output, pins) })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			memory, _ := cpu.Memory()
+			if _, ok := memory.(*IOPort); !ok {
+				t.Errorf("%v got memory = %T, want *IOPort", tt.name, memory)
+			}
+			if opcodes, _ := cpu.Opcodes(); len(opcodes) != tt.wantOpcodes {
+				t.Errorf("%v got %v opcodes, want %v", tt.name, len(opcodes), tt.wantOpcodes)
+			}
+
+			if _, err := cpu.Reset(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := cpu.Execute(14); err != nil {
+				t.Fatal(err)
+			}
+
+			// The RAM banked in by LORAM and HIRAM is left alone.
+			if cpu.State.A != 0xF5 || ram[0x0000] != 0 || ram[0x0001] != 0 {
+				t.Errorf("%v got A = $%02X, RAM = $%02X $%02X", tt.name, cpu.State.A, ram[0x0000], ram[0x0001])
+			}
+			if want := []uint8{0xFF, 0xD0, 0xF5}; !reflect.DeepEqual(output, want) {
+				t.Errorf("%v got output = %X, want %X", tt.name, output, want)
+			}
+		})
+	}
+}
+
 func TestNew65C02Cpu(t *testing.T) {
 	memory, err := processor.NewRepeatingRam(processor.SixteenBytes)
 	if err != nil {
@@ -128,6 +385,107 @@ func TestNew65C02Cpu(t *testing.T) {
 			if !reflect.DeepEqual(wantOpcodes, gotOpcodes) {
 				t.Errorf("New65C02Cpu() got opcodes = %v, want opcodes %v", wantOpcodes, gotOpcodes)
 			}
+
+			// Every opcode is defined on the 65C02.
+			if len(gotOpcodes) != 0x100 {
+				t.Errorf("New65C02Cpu() got %v opcodes, want %v", len(gotOpcodes), 0x100)
+			}
+		})
+	}
+}
+
+func TestNew65C02Cpu_Tick(t *testing.T) {
+	memory := &accessRam{}
+	copy(memory.ram[0x0200:], []uint8{0xAD, 0x34, 0x12}) // LDA $1234
+	cpu, err := New65C02Cpu(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu.State.PC = 0x0200
+
+	// The bus activity of the 65C02 is not modelled, so every access is made in the first
+	// cycle and the Cpu idles for the rest.
+	want := [][]recordedAccess{
+		{{0x0200, processor.OpcodeFetchAccess}, {0x0201, processor.OperandAccess},
+			{0x0202, processor.OperandAccess}, {0x1234, processor.ReadAccess}},
+		nil, nil, nil,
+	}
+	for cycle, accesses := range want {
+		memory.accesses = nil
+		done, err := cpu.Tick()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if done != (cycle == len(want)-1) || !reflect.DeepEqual(memory.accesses, accesses) {
+			t.Errorf("cycle %v got done = %v and accesses = %v, want = %v", cycle+1, done, memory.accesses, accesses)
+		}
+	}
+}
+
+func TestNewW65C02SCpu(t *testing.T) {
+	memory, err := processor.NewRepeatingRam(processor.SixteenBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	is, err := NewW65C02SInstructionSet()
+	if err != nil {
+		panic(err)
+	}
+
+	want, err := processor.NewCpu(is, &memory)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name    string
+		memory  processor.Memory
+		wantErr bool
+	}{
+		{
+			name:    "Nil memory should error",
+			wantErr: true,
+		},
+		{
+			name:   "Valid memory should be fine",
+			memory: &memory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewW65C02SCpu(tt.memory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewW65C02SCpu() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got.State, want.State) {
+				t.Errorf("NewW65C02SCpu() got State = %v, want State %v", got.State, want.State)
+			}
+
+			gotMemory, _ := got.Memory()
+			if !reflect.DeepEqual(gotMemory, tt.memory) {
+				t.Errorf("NewW65C02SCpu() got memory = %v, want memory %v", gotMemory, tt.memory)
+			}
+
+			// We can only really compare opcode as reflect.DeepEqual does not work with function pointers.
+			wantOpcodes, _ := want.Opcodes()
+			gotOpcodes, _ := got.Opcodes()
+
+			if !reflect.DeepEqual(wantOpcodes, gotOpcodes) {
+				t.Errorf("NewW65C02SCpu() got opcodes = %v, want opcodes %v", wantOpcodes, gotOpcodes)
+			}
+
+			// Every opcode is defined on the W65C02S.
+			if len(gotOpcodes) != 0x100 {
+				t.Errorf("NewW65C02SCpu() got %v opcodes, want %v", len(gotOpcodes), 0x100)
+			}
 		})
 	}
 }
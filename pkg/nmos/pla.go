@@ -0,0 +1,182 @@
+package nmos
+
+import "go6502/pkg/processor"
+
+// The port pins of the 6510 that control the banking of the C64.
+const (
+	PlaLoram  = 0x01 // When clear, the BASIC ROM is replaced by RAM.
+	PlaHiram  = 0x02 // When clear, the KERNAL and BASIC ROMs are replaced by RAM.
+	PlaCharen = 0x04 // When clear, the character ROM replaces the I/O area.
+)
+
+// The banks visible through the Pla, as returned in the Windows.
+const (
+	PlaRam = iota
+	PlaBasic
+	PlaKernal
+	PlaCharacters
+	PlaIO
+)
+
+// plaBankNames are the names of the banks visible through the Pla.
+var plaBankNames = [...]string{
+	PlaRam:        "RAM",
+	PlaBasic:      "BASIC ROM",
+	PlaKernal:     "KERNAL ROM",
+	PlaCharacters: "Character ROM",
+	PlaIO:         "I/O",
+}
+
+// Pla is the memory map of a Commodore 64 without a cartridge, as decoded by its PLA from
+// the LORAM, HIRAM and CHAREN pins of the 6510 I/O port. It is a processor.BankSwitcher
+// that banks the BASIC ROM at $A000, the I/O area or the character ROM at $D000 and the
+// KERNAL ROM at $E000 over the 64K of RAM. Writes to a ROM go to the RAM underneath it.
+// The ROMs and the I/O area are accessed with the offset from the start of their range.
+//
+// The kind of each access made by the Cpu is passed on to the bank if it is a
+// processor.AccessMemory, and a fault reported by a bank that is a processor.FaultingMemory
+// is returned by Fault with the address used on the Pla.
+//
+// The Pla is used as the memory of the Cpu, with SetPins called by the I/O port:
+//
+//	cpu, err := nmos.New6510Cpu(pla, pla.SetPins)
+type Pla struct {
+	banks  [len(plaBankNames)]plaBank
+	pins   uint8
+	fault  processor.MemoryFaultError
+	faulty bool
+}
+
+// plaBank is a memory visible through the Pla, accessed with the offset from start.
+type plaBank struct {
+	memory   processor.Memory
+	accesses processor.AccessMemory   // The memory if it is told the kind of access, otherwise nil.
+	faults   processor.FaultingMemory // The memory if it can report faults, otherwise nil.
+	start    processor.Address
+}
+
+// NewPla returns a Pla with all the pins set, so both the BASIC and KERNAL ROMs and the I/O
+// area are visible, as they are after a reset. An error is returned if any of the memories
+// is nil.
+func NewPla(ram, basic, kernal, characters, io processor.Memory) (*Pla, error) {
+	pla := &Pla{pins: PlaLoram | PlaHiram | PlaCharen}
+	for i, bank := range [...]struct {
+		memory processor.Memory
+		start  processor.Address
+	}{
+		PlaRam:        {ram, 0x0000},
+		PlaBasic:      {basic, 0xA000},
+		PlaKernal:     {kernal, 0xE000},
+		PlaCharacters: {characters, 0xD000},
+		PlaIO:         {io, 0xD000},
+	} {
+		if bank.memory == nil {
+			return nil, processor.MemoryMustBeProvided
+		}
+		accesses, _ := bank.memory.(processor.AccessMemory)
+		faults, _ := bank.memory.(processor.FaultingMemory)
+		pla.banks[i] = plaBank{memory: bank.memory, accesses: accesses, faults: faults, start: bank.start}
+	}
+	return pla, nil
+}
+
+// SetPins sets the levels of the I/O port pins, of which only LORAM, HIRAM and CHAREN are
+// used. It has the signature of the output of an IOPort.
+func (p *Pla) SetPins(pins uint8) {
+	p.pins = pins
+}
+
+// Pins returns the levels of the LORAM, HIRAM and CHAREN pins.
+func (p *Pla) Pins() uint8 {
+	return p.pins & (PlaLoram | PlaHiram | PlaCharen)
+}
+
+// bank returns the bank visible at the address when reading.
+func (p *Pla) bank(address processor.Address) int {
+	loram, hiram := p.pins&PlaLoram != 0, p.pins&PlaHiram != 0
+	switch {
+	case address >= 0xA000 && address < 0xC000 && loram && hiram:
+		return PlaBasic
+	case address >= 0xD000 && address < 0xE000 && (loram || hiram):
+		// Either LORAM or HIRAM banks in the character ROM, which CHAREN replaces with I/O.
+		if p.pins&PlaCharen != 0 {
+			return PlaIO
+		}
+		return PlaCharacters
+	case address >= 0xE000 && hiram:
+		return PlaKernal
+	}
+	return PlaRam
+}
+
+// Read a value from the bank visible at the address.
+func (p *Pla) Read(address processor.Address) uint8 {
+	return p.AccessRead(address, processor.ReadAccess)
+}
+
+// Write a value to the I/O area if it is visible at the address, otherwise to the RAM.
+func (p *Pla) Write(address processor.Address, value uint8) {
+	p.AccessWrite(address, value, processor.WriteAccess)
+}
+
+// AccessRead is Read, passing the kind of access on to the bank. See
+// processor.AccessMemory.
+func (p *Pla) AccessRead(address processor.Address, access processor.Access) uint8 {
+	bank := &p.banks[p.bank(address)]
+	var value uint8
+	if bank.accesses != nil {
+		value = bank.accesses.AccessRead(address-bank.start, access)
+	} else {
+		value = bank.memory.Read(address - bank.start)
+	}
+	p.bankFault(bank, address)
+	return value
+}
+
+// AccessWrite is Write, passing the kind of access on to the bank. See
+// processor.AccessMemory.
+func (p *Pla) AccessWrite(address processor.Address, value uint8, access processor.Access) {
+	bank := &p.banks[PlaRam]
+	if p.bank(address) == PlaIO {
+		bank = &p.banks[PlaIO]
+	}
+	if bank.accesses != nil {
+		bank.accesses.AccessWrite(address-bank.start, value, access)
+	} else {
+		bank.memory.Write(address-bank.start, value)
+	}
+	p.bankFault(bank, address)
+}
+
+// bankFault records a fault from the bank, using the address on the Pla, unless one has
+// already been recorded.
+func (p *Pla) bankFault(bank *plaBank, address processor.Address) {
+	if bank.faults == nil {
+		return
+	}
+	if fault, ok := bank.faults.Fault(); ok && !p.faulty {
+		fault.Address = address
+		p.fault, p.faulty = fault, true
+	}
+}
+
+// Fault returns the first fault since it was last called, clearing it. See
+// processor.FaultingMemory.
+func (p *Pla) Fault() (processor.MemoryFaultError, bool) {
+	fault, faulty := p.fault, p.faulty
+	p.fault, p.faulty = processor.MemoryFaultError{}, false
+	return fault, faulty
+}
+
+// Windows returns the banks that are visible when reading. See processor.BankSwitcher.
+func (p *Pla) Windows() []processor.BankWindow {
+	ranges := [...]struct{ start, end processor.Address }{
+		{0x0000, 0x9FFF}, {0xA000, 0xBFFF}, {0xC000, 0xCFFF}, {0xD000, 0xDFFF}, {0xE000, 0xFFFF},
+	}
+	windows := make([]processor.BankWindow, len(ranges))
+	for i, r := range ranges {
+		bank := p.bank(r.start)
+		windows[i] = processor.BankWindow{Start: r.start, End: r.end, Bank: bank, Name: plaBankNames[bank]}
+	}
+	return windows
+}
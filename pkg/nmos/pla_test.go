@@ -0,0 +1,178 @@
+package nmos
+
+import (
+	"errors"
+	"go6502/pkg/processor"
+	"reflect"
+	"testing"
+)
+
+// plaMemories are the memories of a Pla created by newPla.
+type plaMemories struct {
+	ram    *processor.FlatRam
+	kernal *processor.Ram
+	io     *processor.Ram
+}
+
+// newPla returns a Pla over RAM filled with $EA, whose ROMs and I/O area are each filled
+// with a different value.
+func newPla(t *testing.T) (*Pla, plaMemories) {
+	t.Helper()
+	chip := func(size int, value uint8) *processor.Ram {
+		memory, err := processor.NewRam(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		memory.Fill(value)
+		return memory
+	}
+	m := plaMemories{ram: processor.NewFlatRam(), kernal: chip(0x2000, 0xCE), io: chip(0x1000, 0x10)}
+	m.ram.Fill(0xEA)
+	pla, err := NewPla(m.ram, chip(0x2000, 0xBA), m.kernal, chip(0x1000, 0xC4), m.io)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pla, m
+}
+
+func TestPla_Banks(t *testing.T) {
+	// The values read at $A000, $D000 and $E000 and the banks there for each configuration.
+	tests := []struct {
+		pins  uint8
+		want  [3]uint8
+		banks [3]int
+	}{
+		{pins: 7, want: [3]uint8{0xBA, 0x10, 0xCE}, banks: [3]int{PlaBasic, PlaIO, PlaKernal}},
+		{pins: 6, want: [3]uint8{0xEA, 0x10, 0xCE}, banks: [3]int{PlaRam, PlaIO, PlaKernal}},
+		{pins: 5, want: [3]uint8{0xEA, 0x10, 0xEA}, banks: [3]int{PlaRam, PlaIO, PlaRam}},
+		{pins: 4, want: [3]uint8{0xEA, 0xEA, 0xEA}, banks: [3]int{PlaRam, PlaRam, PlaRam}},
+		{pins: 3, want: [3]uint8{0xBA, 0xC4, 0xCE}, banks: [3]int{PlaBasic, PlaCharacters, PlaKernal}},
+		{pins: 2, want: [3]uint8{0xEA, 0xC4, 0xCE}, banks: [3]int{PlaRam, PlaCharacters, PlaKernal}},
+		{pins: 1, want: [3]uint8{0xEA, 0xC4, 0xEA}, banks: [3]int{PlaRam, PlaCharacters, PlaRam}},
+		{pins: 0, want: [3]uint8{0xEA, 0xEA, 0xEA}, banks: [3]int{PlaRam, PlaRam, PlaRam}},
+	}
+	for _, tt := range tests {
+		pla, _ := newPla(t)
+		pla.SetPins(tt.pins | 0xF8)
+		if pla.Pins() != tt.pins {
+			t.Errorf("Pins() got = %v, want = %v", pla.Pins(), tt.pins)
+		}
+		windows := pla.Windows()
+		windowIndex := [3]int{1, 3, 4} // The windows at $A000, $D000 and $E000.
+		for i, address := range []processor.Address{0xA000, 0xD000, 0xE000} {
+			if got := pla.Read(address); got != tt.want[i] {
+				t.Errorf("pins %v Read($%04X) got = $%02X, want = $%02X", tt.pins, address, got, tt.want[i])
+			}
+			if window := windows[windowIndex[i]]; window.Start != address || window.Bank != tt.banks[i] {
+				t.Errorf("pins %v Windows() got = %v", tt.pins, window)
+			}
+		}
+		if pla.Read(0x1234) != 0xEA || len(windows) != 5 || windows[0].Bank != PlaRam || windows[2].Bank != PlaRam {
+			t.Errorf("pins %v the RAM is not always visible at $0000 and $C000", tt.pins)
+		}
+	}
+}
+
+func TestPla_Write(t *testing.T) {
+	pla, m := newPla(t)
+	ram, io := m.ram, m.io
+
+	// Writes to the ROMs go to the RAM underneath, while the I/O area is written to.
+	pla.Write(0xA000, 0x01)
+	pla.Write(0xE000, 0x02)
+	pla.Write(0xD020, 0x03)
+	if pla.Read(0xA000) != 0xBA || ram[0xA000] != 0x01 || ram[0xE000] != 0x02 {
+		t.Errorf("writes to the ROMs did not go to the RAM")
+	}
+	if io.Read(0x0020) != 0x03 || ram[0xD020] != 0xEA {
+		t.Errorf("the write to the I/O area went to the RAM")
+	}
+
+	pla.SetPins(PlaHiram)
+	pla.Write(0xD020, 0x04)
+	if io.Read(0x0020) != 0x03 || ram[0xD020] != 0x04 {
+		t.Errorf("the write under the character ROM did not go to the RAM")
+	}
+
+	if _, err := NewPla(ram, nil, io, io, io); !errors.Is(err, processor.MemoryMustBeProvided) {
+		t.Errorf("NewPla() error got = %v, want = %v", err, processor.MemoryMustBeProvided)
+	}
+}
+
+func TestPla_6510(t *testing.T) {
+	pla, m := newPla(t)
+	copy(m.ram[0x0200:], []uint8{
+		0xA9, 0x2F, // LDA #$2F
+		0x85, 0x00, // STA $00
+		0xA9, 0x06, // LDA #$06
+		0x85, 0x01, // STA $01
+		0xAD, 0x00, 0xA0, // LDA $A000
+	})
+	// The reset vector is read from the KERNAL ROM.
+	m.kernal.Write(0x1FFC, 0x00)
+	m.kernal.Write(0x1FFD, 0x02)
+
+	cpu, err := New6510Cpu(pla, pla.SetPins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Execute(14); err != nil {
+		t.Fatal(err)
+	}
+
+	// Clearing LORAM banks out the BASIC ROM.
+	if cpu.State.A != 0xEA || pla.Pins() != PlaHiram|PlaCharen {
+		t.Errorf("LDA $A000 got = $%02X with pins = %v, want the RAM", cpu.State.A, pla.Pins())
+	}
+}
+
+func TestPla_Bus(t *testing.T) {
+	// Only the VIC-II is on the I/O bus, so the rest of the I/O area faults.
+	vic := &accessRam{}
+	io, err := processor.NewBusBuilder().
+		Map("VIC-II", 0x0000, 0x03FF, vic).
+		WithUnmappedPolicy(processor.FaultOnBus).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ram := processor.NewFlatRam()
+	copy(ram[0x0200:], []uint8{
+		0x8D, 0x20, 0xD0, // STA $D020
+		0xAD, 0x00, 0xD8, // LDA $D800
+	})
+	// The reset vector is read from the KERNAL ROM.
+	rom := processor.NewFlatRam()
+	rom.Write(0x1FFC, 0x00)
+	rom.Write(0x1FFD, 0x02)
+	pla, err := NewPla(ram, rom, rom, rom, io)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpu, err := New6510Cpu(pla, pla.SetPins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The kind of the access reaches the device at its offset in the I/O area.
+	if _, err := cpu.Step(); err != nil {
+		t.Fatal(err)
+	}
+	want := []recordedAccess{{address: 0x0020, access: processor.WriteAccess}}
+	if !reflect.DeepEqual(vic.accesses, want) {
+		t.Errorf("STA $D020 accesses got = %v, want = %v", vic.accesses, want)
+	}
+
+	// A fault in the I/O area is returned with its address on the Pla.
+	var fault processor.MemoryFaultError
+	if _, err := cpu.Step(); !errors.As(err, &fault) || fault.Address != 0xD800 || fault.Access != processor.ReadAccess {
+		t.Errorf("Step() error got = %v, want a read fault at $D800", err)
+	}
+}
@@ -0,0 +1,196 @@
+package nmos
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"go6502/pkg/processor"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The published 2A03 CPU test vectors are the nes6502 tests of the SingleStepTests
+// ProcessorTests project. There is one file per opcode, each containing 10,000 tests
+// of a single instruction starting from a random state:
+//
+//	https://github.com/SingleStepTests/ProcessorTests/tree/main/nes6502
+//
+// The files are too large to keep in the repository, so copy them into testdata/nes6502 to
+// run them, either as published or gzipped, such as 69.json.gz. The tests are skipped if the
+// directory is empty, and fail if any of the requiredVectors2A03 are missing from it.
+
+// processorTest is a single test vector.
+type processorTest struct {
+	Name    string              `json:"name"`
+	Initial processorTestState  `json:"initial"`
+	Final   processorTestState  `json:"final"`
+	Cycles  [][]json.RawMessage `json:"cycles"`
+}
+
+// processorTestState is the state of the CPU and the RAM that is used by a test vector.
+type processorTestState struct {
+	PC  uint16     `json:"pc"`
+	S   uint8      `json:"s"`
+	A   uint8      `json:"a"`
+	X   uint8      `json:"x"`
+	Y   uint8      `json:"y"`
+	P   uint8      `json:"p"`
+	Ram [][]uint16 `json:"ram"`
+}
+
+// unstableOpcodes2A03 are the opcodes whose results depend on the individual chip, or
+// that halt the CPU, and so cannot be compared with the test vectors.
+var unstableOpcodes2A03 = map[processor.Opcode]bool{
+	0x02: true, 0x12: true, 0x22: true, 0x32: true, 0x42: true, 0x52: true,
+	0x62: true, 0x72: true, 0x92: true, 0xB2: true, 0xD2: true, 0xF2: true,
+	0x8B: true, 0x93: true, 0x9B: true, 0x9C: true, 0x9E: true, 0x9F: true, 0xAB: true,
+}
+
+// requiredVectors2A03 are the opcodes whose published vectors must be in testdata/nes6502
+// when any are, as they cover the arithmetic in which the 2A03 differs from the 6502: ADC and SBC, which
+// ignore the decimal flag, and the undocumented opcodes built on them, RRA, ISC, ANC, ALR,
+// ARR and SBX.
+var requiredVectors2A03 = []processor.Opcode{
+	0x61, 0x65, 0x69, 0x6D, 0x71, 0x75, 0x79, 0x7D, // ADC
+	0xE1, 0xE5, 0xE9, 0xEB, 0xED, 0xF1, 0xF5, 0xF9, 0xFD, // SBC
+	0x63, 0x67, 0x6F, 0x73, 0x77, 0x7B, 0x7F, // RRA
+	0xE3, 0xE7, 0xEF, 0xF3, 0xF7, 0xFB, 0xFF, // ISC
+	0x0B, 0x2B, // ANC
+	0x4B, // ALR
+	0x6B, // ARR
+	0xCB, // SBX
+}
+
+// These vectors are in the same format as the published ones and check that the
+// decimal flag is ignored by ADC and SBC. They are always run.
+const decimalVectors2A03 = `[
+	{"name": "69 28 ; ADC #$28 with D set",
+	 "initial": {"pc": 1024, "s": 253, "a": 25, "x": 0, "y": 0, "p": 41, "ram": [[1024, 105], [1025, 40]]},
+	 "final":   {"pc": 1026, "s": 253, "a": 66, "x": 0, "y": 0, "p": 40, "ram": [[1024, 105], [1025, 40]]},
+	 "cycles":  [[1024, 105, "read"], [1025, 40, "read"]]},
+	{"name": "69 01 ; ADC #$01 with D set",
+	 "initial": {"pc": 1024, "s": 253, "a": 153, "x": 0, "y": 0, "p": 40, "ram": [[1024, 105], [1025, 1]]},
+	 "final":   {"pc": 1026, "s": 253, "a": 154, "x": 0, "y": 0, "p": 168, "ram": [[1024, 105], [1025, 1]]},
+	 "cycles":  [[1024, 105, "read"], [1025, 1, "read"]]},
+	{"name": "e9 01 ; SBC #$01 with D set",
+	 "initial": {"pc": 1024, "s": 253, "a": 16, "x": 0, "y": 0, "p": 41, "ram": [[1024, 233], [1025, 1]]},
+	 "final":   {"pc": 1026, "s": 253, "a": 15, "x": 0, "y": 0, "p": 41, "ram": [[1024, 233], [1025, 1]]},
+	 "cycles":  [[1024, 233, "read"], [1025, 1, "read"]]}
+]`
+
+func TestUsingProcessorTests2A03(t *testing.T) {
+
+	t.Run("Decimal flag", func(t *testing.T) {
+		var tests []processorTest
+		if err := json.Unmarshal([]byte(decimalVectors2A03), &tests); err != nil {
+			t.Fatal(err)
+		}
+		runProcessorTests(t, tests)
+	})
+
+	files, err := filepath.Glob(filepath.Join("testdata", "nes6502", "*.json*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("the nes6502 test vectors are not in testdata/nes6502")
+	}
+	found := map[string]bool{}
+	for _, file := range files {
+		found[strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), ".gz"), ".json")] = true
+	}
+	for _, opcode := range requiredVectors2A03 {
+		if name := fmt.Sprintf("%02x", uint8(opcode)); !found[name] {
+			t.Errorf("the nes6502 test vectors for opcode $%02X are not in testdata/nes6502 as %v.json.gz", uint8(opcode), name)
+		}
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			tests, err := readProcessorTests(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			runProcessorTests(t, tests)
+		})
+	}
+}
+
+// readProcessorTests returns the test vectors in the file, which is gzipped if its name ends
+// in .gz.
+func readProcessorTests(name string) ([]processorTest, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if filepath.Ext(name) == ".gz" {
+		unzipped, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer unzipped.Close()
+		reader = unzipped
+	}
+
+	var tests []processorTest
+	if err := json.NewDecoder(reader).Decode(&tests); err != nil {
+		return nil, err
+	}
+	return tests, nil
+}
+
+// runProcessorTests executes each test vector with the extended 2A03 instruction set,
+// checking the final state of the CPU and RAM along with the number of cycles.
+func runProcessorTests(t *testing.T, tests []processorTest) {
+	ram := processor.FlatRam{}
+	cpu, err := NewExtended2A03Cpu(&ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		for _, r := range tt.Initial.Ram {
+			ram[r[0]] = uint8(r[1])
+		}
+		if unstableOpcodes2A03[processor.Opcode(ram[tt.Initial.PC])] {
+			continue
+		}
+
+		cpu.State = tt.Initial.toState()
+		cycles, err := cpu.Step()
+		if err != nil {
+			t.Fatalf("%v error = %v", tt.Name, err)
+		}
+
+		if want := tt.Final.toState(); !reflect.DeepEqual(cpu.State, want) {
+			t.Errorf("%v State got = %v, want = %v", tt.Name, cpu.State, want)
+		}
+		for _, r := range tt.Final.Ram {
+			if got := ram[r[0]]; got != uint8(r[1]) {
+				t.Errorf("%v RAM $%04X got = $%02X, want = $%02X", tt.Name, r[0], got, r[1])
+			}
+		}
+		if cycles != uint(len(tt.Cycles)) {
+			t.Errorf("%v cycles got = %v, want = %v", tt.Name, cycles, len(tt.Cycles))
+		}
+	}
+}
+
+// toState returns the CPU State of the test vector.
+func (s processorTestState) toState() processor.State {
+	return processor.State{
+		PC: processor.Address(s.PC),
+		SP: s.S,
+		A:  s.A,
+		X:  s.X,
+		Y:  s.Y,
+		P:  processor.Status(s.P),
+	}
+}
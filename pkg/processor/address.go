@@ -43,6 +43,14 @@ type Addressing struct {
 	// Does the EffectiveAddress cross a page boundary.
 	PageBoundaryCrossed bool
 
+	// Set by a branch Operation when the branch is taken. This is used by the Cpu to
+	// apply the additional cycle penalties that a taken branch incurs.
+	BranchTaken bool
+
+	// Set by an Operation that changes the run state of the Cpu, such as WAI and STP.
+	// The zero value (Running) leaves the run state of the Cpu unchanged.
+	RunState RunState
+
 	// The Memory that was used when generating Addressing and where results "may"
 	// be written when using Store().
 	Memory Memory
@@ -73,7 +81,7 @@ func (as Addressing) PushByte(state State, byte uint8) (State, error) {
 
 	address := BaseStack + Address(state.SP)
 	state.SP--
-	as.Memory.Write(address, byte)
+	writeAccess(as.Memory, address, byte, StackWriteAccess)
 
 	return state, nil
 }
@@ -109,7 +117,7 @@ func (as Addressing) PullByte(state State) (State, uint8, error) {
 
 	state.SP++
 	address := BaseStack + Address(state.SP)
-	value := as.Memory.Read(address)
+	value := readAccess(as.Memory, address, StackReadAccess)
 
 	return state, value, nil
 }
@@ -153,8 +161,8 @@ func (as Addressing) PullAddress(state State) (State, Address, error) {
 // Converts the Addressing instance into a canonical string form.
 func (as Addressing) String() string {
 	return fmt.Sprintf(
-		"Acc: %v, EA: %04X, V: %02X, PC-delta: %04X, PBC: %v",
-		as.Accumulator, as.EffectiveAddress, as.Value, as.ProgramCounterChange, as.PageBoundaryCrossed)
+		"Acc: %v, EA: %04X, V: %02X, PC-delta: %04X, PBC: %v, BT: %v",
+		as.Accumulator, as.EffectiveAddress, as.Value, as.ProgramCounterChange, as.PageBoundaryCrossed, as.BranchTaken)
 }
 
 // ************************************************************
@@ -164,15 +172,22 @@ func (as Addressing) String() string {
 // AddressingFunc performs the addressing mode phase of an instructions' execution.
 // AddressingFunc is always done before Operation as it will calculate the
 // effective address (if relevant) and return the value from that address (if relevant).
+// The value should be read with a ValueAccess when the Memory is an AccessMemory, so the
+// Cpu can skip the read for instructions that do not use it.
 type AddressingFunc func(State, Memory) (Addressing, error)
 
+// readValue reads the value at the effective address with a ValueAccess.
+func readValue(memory Memory, address Address) uint8 {
+	return readAccess(memory, address, ValueAccess)
+}
+
 func AbsoluteAddressing(state State, memory Memory, offset Address) (Addressing, error) {
 	if memory == nil {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	low := memory.Read(state.PC)
-	high := memory.Read(state.PC + 1)
+	low := readAccess(memory, state.PC, OperandAccess)
+	high := readAccess(memory, state.PC+1, OperandAccess)
 	effectiveAddress := MakeAddress(low, high)
 
 	pageBoundaryCrossed := false
@@ -186,7 +201,7 @@ func AbsoluteAddressing(state State, memory Memory, offset Address) (Addressing,
 
 	result := Addressing{
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 2,
 		PageBoundaryCrossed:  pageBoundaryCrossed,
 		Memory:               memory,
@@ -208,6 +223,30 @@ func AbsoluteX(state State, memory Memory) (Addressing, error) {
 	return AbsoluteAddressing(state, memory, Address(state.X))
 }
 
+// AbsoluteXIndirect addressing (65C02 only) uses the two bytes following the Opcode as
+// a base address to which the X register is added with carry. The effective address is
+// then read from this address and the following byte. This is only used by JMP.
+func AbsoluteXIndirect(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, MemoryMustBeProvided
+	}
+
+	baseLow := readAccess(memory, state.PC, OperandAccess)
+	baseHigh := readAccess(memory, state.PC+1, OperandAccess)
+	indirectAddress := MakeAddress(baseLow, baseHigh) + Address(state.X)
+
+	low := memory.Read(indirectAddress)
+	high := memory.Read(indirectAddress + 1)
+	effectiveAddress := MakeAddress(low, high)
+
+	return Addressing{
+		EffectiveAddress:     effectiveAddress,
+		Value:                readValue(memory, effectiveAddress),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
 // AbsoluteY addressing uses the two bytes following the Opcode as a base address to which
 // the Y register is added with carry.
 func AbsoluteY(state State, memory Memory) (Addressing, error) {
@@ -231,7 +270,7 @@ func Immediate(state State, memory Memory) (Addressing, error) {
 
 	return Addressing{
 		EffectiveAddress:     state.PC,
-		Value:                memory.Read(state.PC),
+		Value:                readAccess(memory, state.PC, OperandAccess),
 		ProgramCounterChange: 1,
 		Memory:               memory,
 	}, nil
@@ -252,8 +291,8 @@ func Indirect(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	indirectAddressLow := memory.Read(state.PC)
-	indirectAddressHigh := memory.Read(state.PC + 1)
+	indirectAddressLow := readAccess(memory, state.PC, OperandAccess)
+	indirectAddressHigh := readAccess(memory, state.PC+1, OperandAccess)
 	indirectAddress := MakeAddress(indirectAddressLow, indirectAddressHigh)
 
 	// Replicate 6502 page-boundary wraparound.
@@ -265,7 +304,31 @@ func Indirect(state State, memory Memory) (Addressing, error) {
 
 	return Addressing{
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+// IndirectCmos addressing (65C02 only) is the same as Indirect addressing except that
+// the page-boundary wraparound bug has been fixed; the high byte of the effective
+// address is always read from the address following the low byte.
+func IndirectCmos(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, MemoryMustBeProvided
+	}
+
+	indirectAddressLow := readAccess(memory, state.PC, OperandAccess)
+	indirectAddressHigh := readAccess(memory, state.PC+1, OperandAccess)
+	indirectAddress := MakeAddress(indirectAddressLow, indirectAddressHigh)
+
+	low := memory.Read(indirectAddress)
+	high := memory.Read(indirectAddress + 1)
+	effectiveAddress := MakeAddress(low, high)
+
+	return Addressing{
+		EffectiveAddress:     effectiveAddress,
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 2,
 		Memory:               memory,
 	}, nil
@@ -280,14 +343,15 @@ func IndirectX(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 	// Calculate table pointer, wrapping around the zero page.
-	indirectAddress := Address(memory.Read(state.PC)+state.X) & 0x00FF
+	indirectAddress := Address(readAccess(memory, state.PC, OperandAccess)+state.X) & 0x00FF
+	indirectAddressPlusOne := (indirectAddress + 1) & 0x00FF
 	low := memory.Read(indirectAddress)
-	high := memory.Read(indirectAddress + 1)
+	high := memory.Read(indirectAddressPlusOne)
 	effectiveAddress := MakeAddress(low, high)
 
 	return Addressing{
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 1,
 		Memory:               memory,
 	}, nil
@@ -301,7 +365,7 @@ func IndirectY(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	indirectAddress := Address(memory.Read(state.PC)) & 0x00FF
+	indirectAddress := Address(readAccess(memory, state.PC, OperandAccess)) & 0x00FF
 	// Replicate 6502 page-boundary wraparound.
 	indirectAddressPlusOne := (indirectAddress + 1) & 0x00FF
 
@@ -316,7 +380,7 @@ func IndirectY(state State, memory Memory) (Addressing, error) {
 
 	return Addressing{
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 1,
 		PageBoundaryCrossed:  pageBoundaryCrossed,
 		Memory:               memory,
@@ -330,23 +394,27 @@ func Relative(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	// TODO: If the relative address crosses a page boundary then it incurs a penalty.
-
-	// TODO: Relative addressing looks faulty as the Cpu seems to always calculate the penalty.
-	//       However, it should only incur the penalty if the branch is taken.
-
 	// Convert the relative address byte to a 16-bit value that we can add to the
 	// program counter. We then need to check to see if it represents a negative
 	// address (MSB set) and adjust as required.
-	value := memory.Read(state.PC)
+	value := readAccess(memory, state.PC, OperandAccess)
 	relativeAddress := Address(value)
 	if (value & 0x80) != 0 {
 		relativeAddress |= 0xFF00
 	}
+
+	// The page boundary is crossed if the branch target is on a different page to
+	// the instruction following the branch. The penalty for this is only incurred
+	// if the branch is actually taken.
+	nextInstruction := state.PC + 1
+	effectiveAddress := nextInstruction + relativeAddress
+	pageBoundaryCrossed := (nextInstruction & 0xFF00) != (effectiveAddress & 0xFF00)
+
 	return Addressing{
-		EffectiveAddress:     state.PC + 1 + relativeAddress,
+		EffectiveAddress:     effectiveAddress,
 		Value:                value,
 		ProgramCounterChange: 1,
+		PageBoundaryCrossed:  pageBoundaryCrossed,
 		Memory:               memory,
 	}, nil
 }
@@ -358,15 +426,69 @@ func ZeroPage(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	effectiveAddress := Address(memory.Read(state.PC))
+	effectiveAddress := Address(readAccess(memory, state.PC, OperandAccess))
+	return Addressing{
+		EffectiveAddress:     effectiveAddress,
+		Value:                readValue(memory, effectiveAddress),
+		ProgramCounterChange: 1,
+		Memory:               memory,
+	}, nil
+}
+
+// ZeroPageIndirect addressing (65C02 only) uses the byte following the opcode as the
+// location of the effective address in the zero page (with wraparound). This is the same
+// as IndirectY addressing without the Y register being added.
+func ZeroPageIndirect(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, MemoryMustBeProvided
+	}
+
+	indirectAddress := Address(readAccess(memory, state.PC, OperandAccess))
+	indirectAddressPlusOne := (indirectAddress + 1) & 0x00FF
+
+	low := memory.Read(indirectAddress)
+	high := memory.Read(indirectAddressPlusOne)
+	effectiveAddress := MakeAddress(low, high)
+
 	return Addressing{
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 1,
 		Memory:               memory,
 	}, nil
 }
 
+// ZeroPageRelative addressing (Rockwell and WDC 65C02 only) is used by the BBR and BBS
+// instructions. The byte following the opcode is an address in the zero page whose value
+// is returned and the next byte is a signed 8-bit value to adjust the program counter by.
+// The effective address is the branch target rather than the zero page address.
+func ZeroPageRelative(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, MemoryMustBeProvided
+	}
+
+	value := memory.Read(Address(readAccess(memory, state.PC, OperandAccess)))
+	offset := readAccess(memory, state.PC+1, OperandAccess)
+	relativeAddress := Address(offset)
+	if (offset & 0x80) != 0 {
+		relativeAddress |= 0xFF00
+	}
+
+	// As with Relative addressing, the page boundary is crossed if the branch target is
+	// on a different page to the instruction following the branch.
+	nextInstruction := state.PC + 2
+	effectiveAddress := nextInstruction + relativeAddress
+	pageBoundaryCrossed := (nextInstruction & 0xFF00) != (effectiveAddress & 0xFF00)
+
+	return Addressing{
+		EffectiveAddress:     effectiveAddress,
+		Value:                value,
+		ProgramCounterChange: 2,
+		PageBoundaryCrossed:  pageBoundaryCrossed,
+		Memory:               memory,
+	}, nil
+}
+
 // ZeroPageX addressing uses the byte following the opcode, incremented by X (without
 // carry) as the address into the first page of memory (i.e. the high byte of the
 // address is always 0x00).
@@ -375,11 +497,11 @@ func ZeroPageX(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	effectiveAddress := Address((memory.Read(state.PC) + state.X) & 0xFF)
+	effectiveAddress := Address((readAccess(memory, state.PC, OperandAccess) + state.X) & 0xFF)
 	return Addressing{
 
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 1,
 		Memory:               memory,
 	}, nil
@@ -393,10 +515,10 @@ func ZeroPageY(state State, memory Memory) (Addressing, error) {
 		return Addressing{}, MemoryMustBeProvided
 	}
 
-	effectiveAddress := Address((memory.Read(state.PC) + state.Y) & 0xFF)
+	effectiveAddress := Address((readAccess(memory, state.PC, OperandAccess) + state.Y) & 0xFF)
 	return Addressing{
 		EffectiveAddress:     effectiveAddress,
-		Value:                memory.Read(effectiveAddress),
+		Value:                readValue(memory, effectiveAddress),
 		ProgramCounterChange: 1,
 		Memory:               memory,
 	}, nil
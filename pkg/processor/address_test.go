@@ -649,6 +649,31 @@ func TestAbsoluteX(t *testing.T) {
 	}
 }
 
+func TestAbsoluteXIndirect(t *testing.T) {
+	tests := []testAddressingConfig{
+		{
+			name: "Zero input State and memory results in effective address of 0x0000 from 0x0000",
+			want: Addressing{ProgramCounterChange: 2},
+		},
+		{
+			name: "Zero input State and non-zero memory results in effective address of 0x0302 from 0x0201 and Value of 0x03",
+			ram:  []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			want: Addressing{EffectiveAddress: 0x0302, Value: 0x03, ProgramCounterChange: 2},
+		},
+		{
+			name:  "X is added to the base address; effective address of 0x0403 from 0x0201 + 0x01 and Value of 0x04",
+			start: State{X: 0x01},
+			ram:   []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			want:  Addressing{EffectiveAddress: 0x0403, Value: 0x04, ProgramCounterChange: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAddressing(t, tt, AbsoluteXIndirect)
+		})
+	}
+}
+
 func TestAbsoluteY(t *testing.T) {
 	tests := []testAddressingConfig{
 		{
@@ -824,6 +849,54 @@ func TestIndirect(t *testing.T) {
 	})
 }
 
+func TestIndirectCmos(t *testing.T) {
+	tests := []testAddressingConfig{
+		{
+			name: "Zero input State and memory results in effective address of 0x0000 from 0x0000",
+			want: Addressing{ProgramCounterChange: 2},
+		},
+		{
+			name: "Zero input State and non-zero memory results in effective address of 0x0302 from 0x0201 and Value of 0x03",
+			ram:  []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			want: Addressing{EffectiveAddress: 0x0302, Value: 0x03, ProgramCounterChange: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAddressing(t, tt, IndirectCmos)
+		})
+	}
+
+	t.Run("Test there is no page boundary wrap around where indirect $xxFF and $xxFF+1 resolves as $xxFF and $x(x+1)00", func(t *testing.T) {
+		ram, err := NewRepeatingRam(OneKiloByte)
+		if err != nil {
+			panic(err)
+		}
+
+		data := map[Address]uint8{
+			0x0000: 0xFF,
+			0x0001: 0x01, // Indirect address = 0x01FF
+			0x0100: 0x02, // Low byte wrap = 0x0100 = 0x02
+			0x01FF: 0xAA, // Low byte      = 0x01FF = 0xAA
+			0x0200: 0x03, // Low byte + 1  = 0x0200
+			0x02AA: 0x0F,
+			0x03AA: 0x0A,
+		}
+		err = WriteDataToMemory(&ram, data)
+		if err != nil {
+			panic(err)
+		}
+
+		got, _ := IndirectCmos(State{}, &ram)
+		want := Addressing{EffectiveAddress: 0x03AA, Value: 0x0A, ProgramCounterChange: 2, Memory: &ram}
+
+		// Validate the final State.
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("TestIndirectCmos() State got = %v, want = %v", got, want)
+		}
+	})
+}
+
 func TestIndirectX(t *testing.T) {
 	tests := []testAddressingConfig{
 		{
@@ -881,6 +954,32 @@ func TestIndirectX(t *testing.T) {
 			t.Errorf("IndirectX() State got = %v, want = %v", got, want)
 		}
 	})
+
+	t.Run("Test high byte of the pointer wraps around the zero page", func(t *testing.T) {
+		ram, err := NewRepeatingRam(OneKiloByte)
+		if err != nil {
+			panic(err)
+		}
+
+		data := map[Address]uint8{
+			0x0000: 0xF0,
+			0x00FF: 0x02, // 0xF0 + 0x0F (X) = 0xFF, the low byte.
+			0x0100: 0x01, // Not wanted high byte.
+			0x0203: 0x10, // Not wanted value.
+		}
+		err = WriteDataToMemory(&ram, data)
+		if err != nil {
+			panic(err)
+		}
+
+		// The high byte is read from 0x0000 which holds the operand 0xF0.
+		got, _ := IndirectX(State{X: 0x0F}, &ram)
+		want := Addressing{EffectiveAddress: 0xF002, Value: ram.Read(0xF002), ProgramCounterChange: 1, Memory: &ram}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("IndirectX() State got = %v, want = %v", got, want)
+		}
+	})
 }
 
 func TestIndirectY(t *testing.T) {
@@ -963,6 +1062,18 @@ func TestRelative(t *testing.T) {
 			ram:   []uint8{0xF8, 0xF7, 0xFB, 0xF5, 0xF4, 0xF3, 0xF2, 0xF1},
 			want:  Addressing{EffectiveAddress: 0x001E, Value: 0xFB, ProgramCounterChange: 1},
 		},
+		{
+			name:  "Branch target on a different page to the next instruction crosses a page boundary; $00FF + 0x05 = $0104",
+			start: State{PC: 0xFE},
+			ram:   []uint8{0, 0, 0, 0, 0, 0, 0x05, 0},
+			want:  Addressing{EffectiveAddress: 0x0104, Value: 0x05, ProgramCounterChange: 1, PageBoundaryCrossed: true},
+		},
+		{
+			name:  "Backwards branch target on a different page to the next instruction crosses a page boundary; $0101 - 0x03 = $00FE",
+			start: State{PC: 0x0100},
+			ram:   []uint8{0xFD, 0, 0, 0, 0, 0, 0, 0},
+			want:  Addressing{EffectiveAddress: 0x00FE, Value: 0xFD, ProgramCounterChange: 1, PageBoundaryCrossed: true},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1001,6 +1112,62 @@ func TestZeroPage(t *testing.T) {
 	}
 }
 
+func TestZeroPageIndirect(t *testing.T) {
+	tests := []testAddressingConfig{
+		{
+			name: "Zero input State and memory results in effective address of 0x0000 from 0x00",
+			want: Addressing{ProgramCounterChange: 1},
+		},
+		{
+			name: "Zero input State and non-zero memory results in effective address of 0x0302 from 0x01 and Value of 0x03",
+			ram:  []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			want: Addressing{EffectiveAddress: 0x0302, Value: 0x03, ProgramCounterChange: 1},
+		},
+		{
+			name:  "Y is not added; effective address of 0x0504 from 0x03 and Value of 0x05",
+			start: State{PC: 0x02, Y: 0x01},
+			ram:   []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			want:  Addressing{EffectiveAddress: 0x0504, Value: 0x05, ProgramCounterChange: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAddressing(t, tt, ZeroPageIndirect)
+		})
+	}
+}
+
+func TestZeroPageRelative(t *testing.T) {
+	tests := []testAddressingConfig{
+		{
+			name: "Zero input State and memory results in effective address of 0x0002",
+			want: Addressing{EffectiveAddress: 0x0002, ProgramCounterChange: 2},
+		},
+		{
+			name: "Zero input State and non-zero memory results in effective address of 0x0000 + 2 + 0x02 and Value of 0x02 from 0x01",
+			ram:  []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			want: Addressing{EffectiveAddress: 0x0004, Value: 0x02, ProgramCounterChange: 2},
+		},
+		{
+			name:  "Branch target on a different page to the next instruction crosses a page boundary; $00FF + 0x05 = $0104",
+			start: State{PC: 0xFD},
+			ram:   []uint8{0, 0, 0, 0, 0, 0x07, 0x05, 0x80},
+			want:  Addressing{EffectiveAddress: 0x0104, Value: 0x80, ProgramCounterChange: 2, PageBoundaryCrossed: true},
+		},
+		{
+			name:  "Backwards branch target on a different page to the next instruction crosses a page boundary; $0102 - 0x04 = $00FE",
+			start: State{PC: 0x0100},
+			ram:   []uint8{0x03, 0xFC, 0, 0x11, 0, 0, 0, 0},
+			want:  Addressing{EffectiveAddress: 0x00FE, Value: 0x11, ProgramCounterChange: 2, PageBoundaryCrossed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAddressing(t, tt, ZeroPageRelative)
+		})
+	}
+}
+
 func TestZeroPageX(t *testing.T) {
 	tests := []testAddressingConfig{
 		{
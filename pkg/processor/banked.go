@@ -0,0 +1,177 @@
+package processor
+
+import "fmt"
+
+// BankWindow describes the bank of memory that is visible in a range of addresses of a
+// BankSwitcher.
+type BankWindow struct {
+	Start Address // The first address of the window within the memory.
+	End   Address // The last address of the window within the memory, inclusive.
+	Bank  int     // The index of the bank; what it refers to depends on the memory.
+	Name  string  // A description of the bank for debuggers.
+}
+
+func (w BankWindow) String() string {
+	return fmt.Sprintf("$%04X-$%04X: %s", uint16(w.Start), uint16(w.End), w.Name)
+}
+
+// BankSwitcher is a Memory that switches banks of memory in and out of its address space,
+// such as a cartridge mapper or the PLA of a C64. A debugger can use Windows to show what
+// is currently mapped.
+type BankSwitcher interface {
+	Memory
+	// Windows returns the banks that are currently visible, in address order.
+	Windows() []BankWindow
+}
+
+// SixteenKiloBytes is the size of the window of a 16K bank switched memory.
+const SixteenKiloBytes = 0x4000
+
+// Banked is a window onto one of several banks of memory of the same size, such as the
+// sideways ROMs of a BBC Micro. The bank is selected by calling Select or by writing to the
+// Register, which can itself be mapped onto a Bus. The kind of each access is passed on to
+// the selected bank if it is an AccessMemory, and faults are reported by any bank that is a
+// FaultingMemory.
+type Banked struct {
+	banks    []SizedMemory
+	accesses []AccessMemory   // The banks that are told the kind of access, otherwise nil.
+	faults   []FaultingMemory // The banks that can report faults.
+	size     int
+	selected int
+}
+
+// NewBanked returns a Banked with the first bank selected. An InvalidMemorySizeProvided
+// error is returned if there are no banks or they are not all the same size.
+func NewBanked(banks ...SizedMemory) (*Banked, error) {
+	if len(banks) == 0 {
+		return nil, InvalidMemorySizeProvided
+	}
+	for _, bank := range banks {
+		if bank == nil {
+			return nil, MemoryMustBeProvided
+		}
+		if bank.Size() != banks[0].Size() {
+			return nil, InvalidMemorySizeProvided
+		}
+	}
+	banked := &Banked{
+		banks:    append([]SizedMemory(nil), banks...),
+		accesses: make([]AccessMemory, len(banks)),
+		size:     banks[0].Size(),
+	}
+	for i, bank := range banks {
+		banked.accesses[i], _ = bank.(AccessMemory)
+		if faults, ok := bank.(FaultingMemory); ok {
+			banked.faults = append(banked.faults, faults)
+		}
+	}
+	return banked, nil
+}
+
+// NewWindow16K returns a Banked whose banks must each be 16K, the size of the switchable
+// window of many machines.
+func NewWindow16K(banks ...SizedMemory) (*Banked, error) {
+	for _, bank := range banks {
+		if bank != nil && bank.Size() != SixteenKiloBytes {
+			return nil, InvalidMemorySizeProvided
+		}
+	}
+	return NewBanked(banks...)
+}
+
+// Read a value from the selected bank.
+func (b *Banked) Read(address Address) uint8 {
+	return b.banks[b.selected].Read(address)
+}
+
+// Write a value to the selected bank.
+func (b *Banked) Write(address Address, value uint8) {
+	b.banks[b.selected].Write(address, value)
+}
+
+// AccessRead reads a value from the selected bank, passing on the kind of access. See
+// AccessMemory.
+func (b *Banked) AccessRead(address Address, access Access) uint8 {
+	if accesses := b.accesses[b.selected]; accesses != nil {
+		return accesses.AccessRead(address, access)
+	}
+	return b.banks[b.selected].Read(address)
+}
+
+// AccessWrite writes a value to the selected bank, passing on the kind of access. See
+// AccessMemory.
+func (b *Banked) AccessWrite(address Address, value uint8, access Access) {
+	if accesses := b.accesses[b.selected]; accesses != nil {
+		accesses.AccessWrite(address, value, access)
+		return
+	}
+	b.banks[b.selected].Write(address, value)
+}
+
+// Fault returns the first fault reported by the banks since it was last called, clearing
+// the faults of all of them. See FaultingMemory.
+func (b *Banked) Fault() (MemoryFaultError, bool) {
+	var first MemoryFaultError
+	var faulty bool
+	for _, faults := range b.faults {
+		if fault, ok := faults.Fault(); ok && !faulty {
+			first, faulty = fault, true
+		}
+	}
+	return first, faulty
+}
+
+// Size returns the size of the banks.
+func (b *Banked) Size() int {
+	return b.size
+}
+
+// Banks returns the number of banks.
+func (b *Banked) Banks() int {
+	return len(b.banks)
+}
+
+// Selected returns the index of the selected bank.
+func (b *Banked) Selected() int {
+	return b.selected
+}
+
+// Select selects the bank with the index. An InvalidBank error is returned if there is no
+// such bank.
+func (b *Banked) Select(bank int) error {
+	if bank < 0 || bank >= len(b.banks) {
+		return InvalidBank
+	}
+	b.selected = bank
+	return nil
+}
+
+// Windows returns the selected bank. See BankSwitcher.
+func (b *Banked) Windows() []BankWindow {
+	return []BankWindow{{
+		Start: 0,
+		End:   Address(b.size - 1),
+		Bank:  b.selected,
+		Name:  fmt.Sprintf("bank %d", b.selected),
+	}}
+}
+
+// Register returns a Memory that selects the bank written to it, wrapping around if there
+// are fewer banks than the value, and reads back the selected bank. It ignores the address,
+// so it is mirrored across whatever range it is mapped to.
+func (b *Banked) Register() Memory {
+	return bankRegister{b}
+}
+
+// bankRegister is the register of a Banked.
+type bankRegister struct {
+	banked *Banked
+}
+
+func (r bankRegister) Read(Address) uint8 {
+	return uint8(r.banked.selected)
+}
+
+func (r bankRegister) Write(_ Address, value uint8) {
+	r.banked.selected = int(value) % len(r.banked.banks)
+}
@@ -0,0 +1,175 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newBanks returns the number of 16K RAM banks, each filled with its index.
+func newBanks(t *testing.T, count int) []SizedMemory {
+	t.Helper()
+	banks := make([]SizedMemory, count)
+	for i := range banks {
+		ram, err := NewRam(SixteenKiloBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ram.Fill(uint8(i))
+		banks[i] = ram
+	}
+	return banks
+}
+
+func TestNewBanked(t *testing.T) {
+	small, err := NewRam(0x2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		banks   []SizedMemory
+		window  bool
+		wantErr error
+	}{
+		{name: "Banked", banks: []SizedMemory{small, small}},
+		{name: "Window", banks: newBanks(t, 4), window: true},
+		{name: "No banks", wantErr: InvalidMemorySizeProvided},
+		{name: "Different sizes", banks: append(newBanks(t, 1), small), wantErr: InvalidMemorySizeProvided},
+		{name: "Not 16K", banks: []SizedMemory{small}, window: true, wantErr: InvalidMemorySizeProvided},
+		{name: "Nil bank", banks: []SizedMemory{small, nil}, wantErr: MemoryMustBeProvided},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newBanked := NewBanked
+			if tt.window {
+				newBanked = NewWindow16K
+			}
+			banked, err := newBanked(tt.banks...)
+			if err != tt.wantErr {
+				t.Fatalf("error got = %v, want = %v", err, tt.wantErr)
+			}
+			if err == nil && (banked.Banks() != len(tt.banks) || banked.Size() != tt.banks[0].Size()) {
+				t.Errorf("got %v banks of %v bytes", banked.Banks(), banked.Size())
+			}
+		})
+	}
+}
+
+func TestBanked_Select(t *testing.T) {
+	banked, err := NewWindow16K(newBanks(t, 4)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := banked.Select(2); err != nil {
+		t.Fatal(err)
+	}
+	banked.Write(0x3FFF, 0x42)
+	if got := banked.Read(0x0000); got != 2 {
+		t.Errorf("Read() got = %v, want = 2", got)
+	}
+	if err := banked.Select(4); err != InvalidBank {
+		t.Errorf("Select() error got = %v, want = %v", err, InvalidBank)
+	}
+	want := []BankWindow{{Start: 0x0000, End: 0x3FFF, Bank: 2, Name: "bank 2"}}
+	if got := banked.Windows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Windows() got = %v, want = %v", got, want)
+	}
+	if err := banked.Select(0); err != nil {
+		t.Fatal(err)
+	}
+	if got := banked.Read(0x3FFF); got != 0 {
+		t.Errorf("Read() of another bank got = %v, want = 0", got)
+	}
+}
+
+func TestBanked_Register(t *testing.T) {
+	// Sideways ROMs at $8000 selected by a register at $FE30, which is mirrored to $FE33.
+	banked, err := NewWindow16K(newBanks(t, 4)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	bus, err := NewBusBuilder().
+		Map("RAM", 0x0000, 0x03FF, &ram).
+		Map("Sideways", 0x8000, 0xBFFF, banked).
+		Map("ROMSEL", 0xFE30, 0xFE33, banked.Register()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		address Address
+		value   uint8
+		want    int
+	}{
+		{name: "Select", address: 0xFE30, value: 0x03, want: 3},
+		{name: "Mirror", address: 0xFE32, value: 0x01, want: 1},
+		{name: "Wraps around", address: 0xFE33, value: 0x06, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus.Write(tt.address, tt.value)
+			if banked.Selected() != tt.want || bus.Read(0x8123) != uint8(tt.want) || bus.Read(0xFE30) != uint8(tt.want) {
+				t.Errorf("Selected() got = %v, want = %v", banked.Selected(), tt.want)
+			}
+		})
+	}
+
+	// The Bus can be searched for bank switched memories.
+	var switchers int
+	for _, m := range bus.Mappings() {
+		if _, ok := m.Device.(BankSwitcher); ok {
+			switchers++
+		}
+	}
+	if switchers != 1 {
+		t.Errorf("found %v BankSwitchers, want = 1", switchers)
+	}
+}
+
+// sizedAccessMemory is an accessMemory that is a SizedMemory, so it can be a bank.
+type sizedAccessMemory struct {
+	*accessMemory
+}
+
+func (m sizedAccessMemory) Size() int {
+	return len(m.ram)
+}
+
+func TestBanked_AccessesAndFaults(t *testing.T) {
+	recorder := &accessMemory{}
+	rom, err := NewRom(make([]uint8, 0x10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rom.SetFaultOnWrite(true)
+	banked, err := NewBanked(sizedAccessMemory{recorder}, rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The kind of access is passed on to the selected bank.
+	banked.AccessRead(0x1234, OpcodeFetchAccess)
+	banked.AccessWrite(0x4321, 0x42, StackWriteAccess)
+	want := []recordedAccess{{address: 0x1234, access: OpcodeFetchAccess}, {address: 0x4321, access: StackWriteAccess}}
+	if !reflect.DeepEqual(recorder.accesses, want) {
+		t.Errorf("accesses got = %v, want = %v", recorder.accesses, want)
+	}
+	if _, faulty := banked.Fault(); faulty {
+		t.Errorf("Fault() got a fault from the RAM bank")
+	}
+
+	// A fault from a bank is reported, and cleared, by the Banked.
+	if err := banked.Select(1); err != nil {
+		t.Fatal(err)
+	}
+	banked.AccessWrite(0x8000, 0x42, WriteAccess)
+	if fault, faulty := banked.Fault(); !faulty || fault.Address != 0x8000 {
+		t.Errorf("Fault() got = %v, %v, want a fault at $8000", fault, faulty)
+	}
+	if _, faulty := banked.Fault(); faulty {
+		t.Errorf("Fault() did not clear the fault")
+	}
+}
@@ -0,0 +1,190 @@
+package processor
+
+// InstructionSetBuilder is used to compose an InstructionSet, starting either from nothing
+// or from an existing InstructionSet. Instructions can be added, overridden and removed,
+// other sets merged in and any remaining gaps filled, before Build returns the result. The
+// methods return the builder so that calls can be chained. The InstructionSets used and
+// returned by a builder are never modified by it.
+//
+// The builder keeps the Mnemonic of each instruction added from one, so that the built
+// InstructionSet can describe its instructions (see InstructionSet.Mnemonic). Instructions
+// added directly have no Mnemonic unless one is attached with Describe.
+type InstructionSetBuilder struct {
+	instructions map[Opcode]Instruction
+	mnemonics    map[Opcode]Mnemonic
+	interrupt    Operation
+	nmi          Operation
+	reset        Operation
+}
+
+// NewInstructionSetBuilder returns an InstructionSetBuilder with no instructions.
+func NewInstructionSetBuilder() *InstructionSetBuilder {
+	return &InstructionSetBuilder{
+		instructions: make(map[Opcode]Instruction, 0x100),
+		mnemonics:    make(map[Opcode]Mnemonic, 0x100),
+	}
+}
+
+// Builder returns an InstructionSetBuilder that starts with a copy of the instructions and
+// the interrupt and reset operations of the InstructionSet.
+func (is InstructionSet) Builder() *InstructionSetBuilder {
+	b := NewInstructionSetBuilder().Merge(is)
+	b.interrupt = is.interrupt
+	b.nmi = is.nmi
+	b.reset = is.reset
+	return b
+}
+
+// Add adds the instructions, overriding any existing instructions with the same opcodes.
+// The Mnemonic of any overridden instruction is removed.
+func (b *InstructionSetBuilder) Add(instructions ...Instruction) *InstructionSetBuilder {
+	for _, instruction := range instructions {
+		b.instructions[instruction.Opcode] = instruction
+		delete(b.mnemonics, instruction.Opcode)
+	}
+	return b
+}
+
+// AddMnemonics adds an instruction for each of the mnemonics (see NewInstruction),
+// overriding any existing instructions with the same opcodes.
+func (b *InstructionSetBuilder) AddMnemonics(mnemonics ...Mnemonic) *InstructionSetBuilder {
+	for _, mnemonic := range mnemonics {
+		b.instructions[mnemonic.Opcode] = NewInstruction(mnemonic)
+		b.mnemonics[mnemonic.Opcode] = mnemonic
+	}
+	return b
+}
+
+// Describe attaches the mnemonics to the instructions with the same opcodes without changing
+// the instructions themselves. This is useful when an instruction is created from a Mnemonic
+// and then altered before being added. Mnemonics whose opcode has no instruction when Build
+// is called are dropped.
+func (b *InstructionSetBuilder) Describe(mnemonics ...Mnemonic) *InstructionSetBuilder {
+	for _, mnemonic := range mnemonics {
+		b.mnemonics[mnemonic.Opcode] = mnemonic
+	}
+	return b
+}
+
+// Remove removes the instructions with the opcodes, if they are present.
+func (b *InstructionSetBuilder) Remove(opcodes ...Opcode) *InstructionSetBuilder {
+	for _, opcode := range opcodes {
+		delete(b.instructions, opcode)
+		delete(b.mnemonics, opcode)
+	}
+	return b
+}
+
+// Merge adds all the instructions of the InstructionSet and their mnemonics, overriding any
+// existing instructions with the same opcodes. The interrupt and reset operations of the
+// InstructionSet are ignored.
+func (b *InstructionSetBuilder) Merge(is InstructionSet) *InstructionSetBuilder {
+	for _, opcode := range is.Opcodes() {
+		b.instructions[opcode] = *is.lookup(opcode)
+		if mnemonic, err := is.Mnemonic(opcode); err == nil {
+			b.mnemonics[opcode] = mnemonic
+		} else {
+			delete(b.mnemonics, opcode)
+		}
+	}
+	return b
+}
+
+// Fill adds the passed in instruction for every opcode that does not have an instruction.
+// The Opcode value of the Instruction passed in is replaced with the actual Opcode whose
+// place it takes.
+func (b *InstructionSetBuilder) Fill(instruction Instruction) *InstructionSetBuilder {
+	for opcode := range 0x100 {
+		if _, ok := b.instructions[Opcode(opcode)]; !ok {
+			instruction.Opcode = Opcode(opcode)
+			b.instructions[Opcode(opcode)] = instruction
+		}
+	}
+	return b
+}
+
+// FillWithNops adds a NOP for every opcode that does not have an instruction. Each NOP has
+// the length and addressing mode of the same opcode on the NMOS 6502, including the
+// undocumented opcodes, so a program skips the same number of bytes as it would on a 6502.
+// The NOPs perform the memory read of their addressing mode, just as the undocumented
+// multi-byte NOPs do. The JAM opcodes are filled with single byte NOPs.
+func (b *InstructionSetBuilder) FillWithNops() *InstructionSetBuilder {
+	for _, mnemonic := range nmosMnemonics() {
+		if _, ok := b.instructions[mnemonic.Opcode]; ok {
+			continue
+		}
+		b.AddMnemonics(nopMnemonic(mnemonic))
+	}
+	return b
+}
+
+// nmosMnemonics returns the mnemonics for every opcode of the NMOS 6502.
+func nmosMnemonics() []Mnemonic {
+	return append(AllOpcodes(), undocumentedOpcodes...)
+}
+
+// nopMnemonic returns a NOP with the same length as the mnemonic. Only the addressing modes
+// that read their operand are kept; the others are replaced by one of the same length.
+func nopMnemonic(mnemonic Mnemonic) Mnemonic {
+	nop := Mnemonic{Opcode: mnemonic.Opcode, Operation: Nom, Addressing: mnemonic.Addressing}
+	switch mnemonic.Addressing.Mode {
+	case ImpliedMode, AccumulatorMode:
+		nop.Operation, nop.Addressing = Nop, Imp
+	case RelativeMode:
+		nop.Addressing = Imm
+	case IndirectMode:
+		nop.Addressing = Abs
+	}
+	return nop
+}
+
+// FillWithTraps adds a trap (see Trap) for every opcode that does not have an instruction, so
+// that executing an opcode which is not in the instruction set returns a TrapError reporting
+// the PC and opcode.
+func (b *InstructionSetBuilder) FillWithTraps() *InstructionSetBuilder {
+	for opcode := range 0x100 {
+		if _, ok := b.instructions[Opcode(opcode)]; !ok {
+			b.instructions[Opcode(opcode)] = Instruction{
+				Opcode:         Opcode(opcode),
+				AddressingFunc: Implied,
+				Operation:      Trap(Opcode(opcode)),
+				Cycles:         1,
+				Mode:           ImpliedMode,
+				Type:           InternalOperation,
+			}
+		}
+	}
+	return b
+}
+
+// WithInterruptOperations sets the operations used for hardware interrupts (IRQ) and
+// non-maskable interrupts (NMI). If these are nil the NMOS 6502 operations are used.
+func (b *InstructionSetBuilder) WithInterruptOperations(interrupt, nmi Operation) *InstructionSetBuilder {
+	b.interrupt = interrupt
+	b.nmi = nmi
+	return b
+}
+
+// WithResetOperation sets the operation used for the reset sequence. If this is nil the
+// NMOS 6502 operation is used.
+func (b *InstructionSetBuilder) WithResetOperation(reset Operation) *InstructionSetBuilder {
+	b.reset = reset
+	return b
+}
+
+// Build returns a new InstructionSet containing the instructions added to the builder. The
+// builder can continue to be used afterwards without changing the InstructionSet. An error
+// is returned if there are no instructions.
+func (b *InstructionSetBuilder) Build() (InstructionSet, error) {
+	result := InstructionSet{
+		instructions: newInstructionTable(b.instructions, b.mnemonics),
+		interrupt:    b.interrupt,
+		nmi:          b.nmi,
+		reset:        b.reset,
+	}
+	if err := result.validate(); err != nil {
+		return InstructionSet{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,219 @@
+package processor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInstructionSetBuilder(t *testing.T) {
+	nop, instr1, instr2, instr3, instr4, instr5 := SixInstructions()
+	override := Instruction{Opcode: instr2.Opcode, Cycles: 9}
+
+	original, err := NewInstructionSet(Instructions{nop, instr1, instr2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewInstructionSet(Instructions{instr3, instr4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	is, err := original.Builder().
+		Add(instr5, override).
+		Remove(instr1.Opcode, 0xFF).
+		Merge(other).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Opcode{nop.Opcode, instr2.Opcode, instr3.Opcode, instr4.Opcode, instr5.Opcode}
+	if got := is.Opcodes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Opcodes() got = %v, want = %v", got, want)
+	}
+	if got, _ := is.Get(instr2.Opcode); got.Cycles != override.Cycles {
+		t.Errorf("Get() did not return the override, got = %v", got)
+	}
+
+	// The original instruction set is unchanged.
+	want = []Opcode{nop.Opcode, instr1.Opcode, instr2.Opcode}
+	if got := original.Opcodes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Opcodes() of the original got = %v, want = %v", got, want)
+	}
+	if got, _ := original.Get(instr2.Opcode); got.Cycles != instr2.Cycles {
+		t.Errorf("Get() of the original returned the override, got = %v", got)
+	}
+
+	if _, err := NewInstructionSetBuilder().Build(); err != InstructionSetEmpty {
+		t.Errorf("Build() error got = %v, want = %v", err, InstructionSetEmpty)
+	}
+	if _, err := original.Builder().Remove(is.Opcodes()...).Remove(instr1.Opcode).Build(); err != InstructionSetEmpty {
+		t.Errorf("Build() error got = %v, want = %v", err, InstructionSetEmpty)
+	}
+}
+
+func TestInstructionSetBuilder_Build(t *testing.T) {
+	nop, instr1, _, _ := FourInstructions()
+	builder := NewInstructionSetBuilder().Add(nop).WithResetOperation(ResetCmos)
+
+	is, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.Add(instr1)
+
+	if len(is.Opcodes()) != 1 {
+		t.Errorf("Build() was changed by the builder, got = %v", is.Opcodes())
+	}
+	if is.resetOperation() == nil || is.interruptOperation() == nil {
+		t.Errorf("Build() did not set the operations")
+	}
+
+	// The operations are kept by Builder.
+	cmos := is.WithInterruptOperations(InterruptCmos, NmiCmos)
+	copied, err := cmos.Builder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied.interrupt == nil || copied.nmi == nil || copied.reset == nil {
+		t.Errorf("Builder() did not keep the operations")
+	}
+}
+
+func TestInstructionSetBuilder_FillWithNops(t *testing.T) {
+	is, err := NewInstructionSetBuilder().FillWithNops().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(is.Opcodes()) != 256 {
+		t.Fatalf("FillWithNops() got = %v opcodes, want = 256", len(is.Opcodes()))
+	}
+
+	tests := []struct {
+		name       string
+		opcode     Opcode
+		wantBytes  Address
+		wantCycles uint
+	}{
+		{name: "NOP", opcode: 0xEA, wantBytes: 1, wantCycles: 2},
+		{name: "JAM", opcode: 0x02, wantBytes: 1, wantCycles: 2},
+		{name: "ASL A", opcode: 0x0A, wantBytes: 1, wantCycles: 2},
+		{name: "LDA #", opcode: 0xA9, wantBytes: 2, wantCycles: 2},
+		{name: "BNE", opcode: 0xD0, wantBytes: 2, wantCycles: 2},
+		{name: "LDA (zp),Y", opcode: 0xB1, wantBytes: 2, wantCycles: 5},
+		{name: "NOP abs", opcode: 0x0C, wantBytes: 3, wantCycles: 4},
+		{name: "JMP (ind)", opcode: 0x6C, wantBytes: 3, wantCycles: 4},
+		{name: "STA abs", opcode: 0x8D, wantBytes: 3, wantCycles: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram := NewPopulatedRam(SixteenBytes, []uint8{uint8(tt.opcode)})
+			cpu, err := NewCpu(is, &ram)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cpu.State = State{A: 0x12}
+
+			cycles, err := cpu.Step()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cpu.State != (State{PC: tt.wantBytes, A: 0x12}) {
+				t.Errorf("Step() State got = %v", cpu.State)
+			}
+			if cycles != tt.wantCycles {
+				t.Errorf("Step() cycles got = %v, want = %v", cycles, tt.wantCycles)
+			}
+		})
+	}
+}
+
+func TestInstructionSetBuilder_FillWithTraps(t *testing.T) {
+	is, err := newLegalInstructionSet().Builder().FillWithTraps().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(is.Opcodes()) != 256 {
+		t.Fatalf("FillWithTraps() got = %v opcodes, want = 256", len(is.Opcodes()))
+	}
+
+	ram := NewPopulatedRam(SixteenBytes, []uint8{0xEA, 0xEA, 0x02})
+	cpu, err := NewCpu(is, &ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu.State = State{}
+
+	cycles, err := cpu.Execute(0)
+	var trap TrapError
+	if !errors.As(err, &trap) {
+		t.Fatalf("Execute() error got = %v, want a TrapError", err)
+	}
+	if trap.PC != 0x0002 || trap.Opcode != 0x02 || trap.State.PC != 0x0003 || !errors.Is(err, OpcodeTrapped) {
+		t.Errorf("Execute() error got = %v with State = %v", trap, trap.State)
+	}
+	// Only the cycle used to read the opcode is counted for the trap.
+	if cycles != 5 {
+		t.Errorf("Execute() cycles got = %v, want = 5", cycles)
+	}
+}
+
+func TestInstructionSetBuilder_Mnemonics(t *testing.T) {
+	mnemonic := func(opcode Opcode) Mnemonic {
+		m, err := MnemonicFromOpCode(opcode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	lda, ldx, nop, brk := mnemonic(0xA9), mnemonic(0xA2), mnemonic(0xEA), mnemonic(0x00)
+	changed := NewInstruction(ldx)
+	changed.Cycles = 9
+
+	original, err := NewInstructionSetBuilder().AddMnemonics(lda, ldx, nop).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	is, err := original.Builder().
+		Add(changed).
+		Remove(nop.Opcode).
+		Describe(brk).
+		FillWithTraps().
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		is      InstructionSet
+		opcode  Opcode
+		want    Mnemonic
+		wantErr error
+	}{
+		{name: "Added from a mnemonic", is: original, opcode: lda.Opcode, want: lda},
+		{name: "Kept by Builder", is: is, opcode: lda.Opcode, want: lda},
+		{name: "Removed", is: is, opcode: nop.Opcode, wantErr: MnemonicNotInInstructionSet},
+		{name: "Overridden by Add", is: is, opcode: ldx.Opcode, wantErr: MnemonicNotInInstructionSet},
+		{name: "Described trap", is: is, opcode: 0x00, want: brk},
+		{name: "Not in the set", is: original, opcode: 0x00, wantErr: OpCodeNotInInstructionSet},
+		{name: "Empty set", is: InstructionSet{}, opcode: lda.Opcode, wantErr: InstructionSetEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.is.Mnemonic(tt.opcode)
+			if err != tt.wantErr {
+				t.Fatalf("Mnemonic() error got = %v, want = %v", err, tt.wantErr)
+			}
+			if got.Opcode != tt.want.Opcode || got.Operation.Name != tt.want.Operation.Name {
+				t.Errorf("Mnemonic() got = %+v, want = %+v", got, tt.want)
+			}
+		})
+	}
+
+	// The mnemonics are returned in opcode order.
+	if got := is.Mnemonics(); len(got) != 2 || got[0].Opcode != brk.Opcode || got[1].Opcode != lda.Opcode {
+		t.Errorf("Mnemonics() got = %v", got)
+	}
+}
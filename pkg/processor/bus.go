@@ -0,0 +1,268 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+)
+
+// UnmappedPolicy decides what happens when the Bus is accessed at an address that has
+// nothing mapped to it.
+type UnmappedPolicy uint8
+
+const (
+	OpenBus    UnmappedPolicy = iota // Reads return the last value on the data bus; writes are ignored.
+	ZeroBus                          // Reads return zero; writes are ignored.
+	FaultOnBus                       // Reads return zero, writes are ignored and a MemoryFaultError is reported.
+)
+
+func (p UnmappedPolicy) String() string {
+	switch p {
+	case OpenBus:
+		return "OpenBus"
+	case ZeroBus:
+		return "ZeroBus"
+	case FaultOnBus:
+		return "FaultOnBus"
+	}
+	return fmt.Sprintf("UnmappedPolicy(%d)", uint8(p))
+}
+
+// Mapping attaches a device to a range of addresses on a Bus. The device is accessed with
+// the offset of the address from the Start of the range, so the same device can be mapped
+// anywhere. If the Mask is not zero the offset is ANDed with it, which mirrors a device that
+// is smaller than the range across the whole range. Where mappings overlap, the one with the
+// highest Priority is used, for example to map I/O registers over RAM.
+type Mapping struct {
+	Name     string  // Used to describe the mapping in errors and by debuggers.
+	Start    Address // The first address of the range.
+	End      Address // The last address of the range, inclusive.
+	Mask     Address // If not zero then it is applied to the offset, mirroring the device.
+	Priority int
+	Device   Memory
+}
+
+// contains returns true if the address is in the range of the mapping.
+func (m Mapping) contains(address Address) bool {
+	return address >= m.Start && address <= m.End
+}
+
+// offset returns the address used to access the device.
+func (m Mapping) offset(address Address) Address {
+	offset := address - m.Start
+	if m.Mask != 0 {
+		offset &= m.Mask
+	}
+	return offset
+}
+
+// window returns the number of bytes of the device that are accessed through the mapping.
+func (m Mapping) window() int {
+	size := int(m.End-m.Start) + 1
+	if m.Mask != 0 {
+		size = min(size, int(m.Mask)+1)
+	}
+	return size
+}
+
+// BusBuilder is used to compose a Bus from mappings. The methods return the builder so that
+// calls can be chained.
+type BusBuilder struct {
+	mappings []Mapping
+	policy   UnmappedPolicy
+}
+
+// NewBusBuilder returns a BusBuilder with no mappings and the OpenBus policy.
+func NewBusBuilder() *BusBuilder {
+	return &BusBuilder{}
+}
+
+// Map adds the device to the range of addresses, with no mirroring and a priority of zero.
+func (b *BusBuilder) Map(name string, start, end Address, device Memory) *BusBuilder {
+	return b.Add(Mapping{Name: name, Start: start, End: end, Device: device})
+}
+
+// Add adds the mappings.
+func (b *BusBuilder) Add(mappings ...Mapping) *BusBuilder {
+	b.mappings = append(b.mappings, mappings...)
+	return b
+}
+
+// WithUnmappedPolicy sets what happens when an address that has nothing mapped is accessed.
+func (b *BusBuilder) WithUnmappedPolicy(policy UnmappedPolicy) *BusBuilder {
+	b.policy = policy
+	return b
+}
+
+// Build returns a Bus containing the mappings. An InvalidMapping error is returned if a
+// mapping has no device or ends before it starts, a DeviceSizeMismatch error if a device
+// that is a SizedMemory does not exactly fill its range, once mirrored, and an
+// OverlappingMappings error if two mappings with the same priority include the same address.
+func (b *BusBuilder) Build() (*Bus, error) {
+	if len(b.mappings) > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: at most %d mappings are supported", InvalidMapping, math.MaxUint8)
+	}
+	for _, m := range b.mappings {
+		if m.Device == nil || m.End < m.Start {
+			return nil, fmt.Errorf("%w: %q from $%04X to $%04X", InvalidMapping, m.Name, uint16(m.Start), uint16(m.End))
+		}
+		if sized, ok := m.Device.(SizedMemory); ok && sized.Size() != m.window() {
+			return nil, fmt.Errorf("%w: %q is $%X bytes but is mapped to $%X bytes", DeviceSizeMismatch,
+				m.Name, sized.Size(), m.window())
+		}
+	}
+
+	bus := &Bus{mappings: make([]busMapping, len(b.mappings)), policy: b.policy}
+	for i, m := range b.mappings {
+		faults, _ := m.Device.(FaultingMemory)
+		accesses, _ := m.Device.(AccessMemory)
+		bus.mappings[i] = busMapping{Mapping: m, faults: faults, accesses: accesses}
+	}
+
+	for address := range 0x10000 {
+		index, overlap := 0, 0
+		for i, m := range b.mappings {
+			if !m.contains(Address(address)) {
+				continue
+			}
+			switch {
+			case index == 0 || m.Priority > b.mappings[index-1].Priority:
+				index, overlap = i+1, 0
+			case m.Priority == b.mappings[index-1].Priority:
+				overlap = i + 1
+			}
+		}
+		if overlap != 0 {
+			return nil, fmt.Errorf("%w: %q and %q both include $%04X", OverlappingMappings,
+				b.mappings[index-1].Name, b.mappings[overlap-1].Name, address)
+		}
+		bus.index[address] = uint8(index)
+	}
+
+	return bus, nil
+}
+
+// busMapping is a Mapping along with the device if it can report faults or be told the kind
+// of each access.
+type busMapping struct {
+	Mapping
+	faults   FaultingMemory
+	accesses AccessMemory
+}
+
+// Bus is a Memory that decodes each address and passes the access on to the device that is
+// mapped to it. It is created by a BusBuilder. The Bus is a FaultingMemory, reporting the
+// accesses to unmapped addresses when the policy is FaultOnBus and any faults reported by
+// the devices, with their addresses on the Bus. The Bus is also an AccessMemory, passing the
+// kind of each access on to the devices that are AccessMemory.
+type Bus struct {
+	index    [0x10000]uint8 // The index of the mapping for each address plus one; zero if unmapped.
+	mappings []busMapping
+	policy   UnmappedPolicy
+	last     uint8 // The last value on the data bus.
+	fault    MemoryFaultError
+	faulty   bool
+}
+
+// Read reads from the device mapped to the address.
+func (b *Bus) Read(address Address) uint8 {
+	return b.AccessRead(address, ReadAccess)
+}
+
+// Write writes to the device mapped to the address.
+func (b *Bus) Write(address Address, value uint8) {
+	b.AccessWrite(address, value, WriteAccess)
+}
+
+// AccessRead reads from the device mapped to the address, passing on the kind of access. See
+// AccessMemory.
+func (b *Bus) AccessRead(address Address, access Access) uint8 {
+	i := b.index[address]
+	if i == 0 {
+		return b.unmapped(address, access)
+	}
+	m := &b.mappings[i-1]
+	if m.accesses != nil {
+		b.last = m.accesses.AccessRead(m.offset(address), access)
+	} else {
+		b.last = m.Device.Read(m.offset(address))
+	}
+	if m.faults != nil {
+		b.deviceFault(m, address)
+	}
+	return b.last
+}
+
+// AccessWrite writes to the device mapped to the address, passing on the kind of access. See
+// AccessMemory.
+func (b *Bus) AccessWrite(address Address, value uint8, access Access) {
+	b.last = value
+	i := b.index[address]
+	if i == 0 {
+		b.unmapped(address, access)
+		return
+	}
+	m := &b.mappings[i-1]
+	if m.accesses != nil {
+		m.accesses.AccessWrite(m.offset(address), value, access)
+	} else {
+		m.Device.Write(m.offset(address), value)
+	}
+	if m.faults != nil {
+		b.deviceFault(m, address)
+	}
+}
+
+// unmapped applies the policy to an access of an unmapped address, returning the value read.
+func (b *Bus) unmapped(address Address, access Access) uint8 {
+	switch b.policy {
+	case OpenBus:
+		return b.last
+	case FaultOnBus:
+		b.report(MemoryFaultError{Address: address, Access: access})
+	}
+	if !access.IsWrite() {
+		b.last = 0
+	}
+	return 0
+}
+
+// deviceFault reports a fault from the device using the address on the Bus.
+func (b *Bus) deviceFault(m *busMapping, address Address) {
+	if fault, ok := m.faults.Fault(); ok {
+		fault.Address = address
+		b.report(fault)
+	}
+}
+
+// report records the fault unless one has already been recorded.
+func (b *Bus) report(fault MemoryFaultError) {
+	if !b.faulty {
+		b.fault, b.faulty = fault, true
+	}
+}
+
+// Fault returns the first fault since it was last called, clearing it. See FaultingMemory.
+func (b *Bus) Fault() (MemoryFaultError, bool) {
+	fault, faulty := b.fault, b.faulty
+	b.fault, b.faulty = MemoryFaultError{}, false
+	return fault, faulty
+}
+
+// Lookup returns the Mapping used for the address and true, or false if the address is not
+// mapped.
+func (b *Bus) Lookup(address Address) (Mapping, bool) {
+	i := b.index[address]
+	if i == 0 {
+		return Mapping{}, false
+	}
+	return b.mappings[i-1].Mapping, true
+}
+
+// Mappings returns all the mappings of the Bus in the order they were added.
+func (b *Bus) Mappings() []Mapping {
+	mappings := make([]Mapping, len(b.mappings))
+	for i, m := range b.mappings {
+		mappings[i] = m.Mapping
+	}
+	return mappings
+}
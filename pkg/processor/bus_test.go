@@ -0,0 +1,190 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBus_ReadWrite(t *testing.T) {
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	io := NewPopulatedRam(EightBytes, nil)
+	rom := NewPopulatedRam(OneKiloByte, nil)
+	bus, err := NewBusBuilder().
+		Add(Mapping{Name: "RAM", Start: 0x0000, End: 0x1FFF, Mask: 0x03FF, Device: &ram}).
+		Add(Mapping{Name: "IO", Start: 0x0800, End: 0x08FF, Mask: 0x0007, Priority: 1, Device: &io}).
+		Map("ROM", 0xFC00, 0xFFFF, &rom).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		address Address
+		memory  *RepeatingRam
+		offset  Address
+		mapping string
+	}{
+		{name: "RAM", address: 0x0123, memory: &ram, offset: 0x0123, mapping: "RAM"},
+		{name: "RAM mirror", address: 0x1523, memory: &ram, offset: 0x0123, mapping: "RAM"},
+		{name: "IO over RAM", address: 0x0801, memory: &io, offset: 0x0001, mapping: "IO"},
+		{name: "IO mirror", address: 0x08F9, memory: &io, offset: 0x0001, mapping: "IO"},
+		{name: "RAM after IO", address: 0x0900, memory: &ram, offset: 0x0100, mapping: "RAM"},
+		{name: "ROM", address: 0xFFFC, memory: &rom, offset: 0x03FC, mapping: "ROM"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := uint8(i + 1)
+			bus.Write(tt.address, value)
+			if got := tt.memory.Read(tt.offset); got != value {
+				t.Errorf("Write() wrote %v to the device at $%04X, want = %v", got, tt.offset, value)
+			}
+			tt.memory.Write(tt.offset, value+0x80)
+			if got := bus.Read(tt.address); got != value+0x80 {
+				t.Errorf("Read() got = %v, want = %v", got, value+0x80)
+			}
+			if got, ok := bus.Lookup(tt.address); !ok || got.Name != tt.mapping {
+				t.Errorf("Lookup() got = %q, want = %q", got.Name, tt.mapping)
+			}
+		})
+	}
+
+	if _, ok := bus.Lookup(0x8000); ok {
+		t.Errorf("Lookup() of an unmapped address got = true, want = false")
+	}
+	if got := len(bus.Mappings()); got != 3 {
+		t.Errorf("Mappings() got %v mappings, want = 3", got)
+	}
+}
+
+func TestBus_UnmappedPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    UnmappedPolicy
+		want      uint8
+		wantFault bool
+	}{
+		{name: "Open bus returns the last value", policy: OpenBus, want: 0x42},
+		{name: "Zero", policy: ZeroBus, want: 0x00},
+		{name: "Fault", policy: FaultOnBus, want: 0x00, wantFault: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram := NewPopulatedRam(OneKiloByte, []uint8{0x42})
+			bus, err := NewBusBuilder().Map("RAM", 0x0000, 0x03FF, &ram).WithUnmappedPolicy(tt.policy).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bus.Read(0x0000)
+			if got := bus.Read(0x8000); got != tt.want {
+				t.Errorf("Read() got = %v, want = %v", got, tt.want)
+			}
+			fault, faulty := bus.Fault()
+			if want := (MemoryFaultError{Address: 0x8000, Access: ReadAccess}); faulty != tt.wantFault || faulty && fault != want {
+				t.Errorf("Fault() got = %v, %v, want = %v", fault, faulty, tt.wantFault)
+			}
+
+			bus.Write(0x9000, 0x01)
+			bus.Write(0xA000, 0x02)
+			fault, faulty = bus.Fault()
+			if want := (MemoryFaultError{Address: 0x9000, Access: WriteAccess}); faulty != tt.wantFault || faulty && fault != want {
+				t.Errorf("Fault() got = %v, %v, want the first write", fault, faulty)
+			}
+			if _, faulty := bus.Fault(); faulty {
+				t.Errorf("Fault() did not clear the fault")
+			}
+		})
+	}
+}
+
+func TestBusBuilder_Build(t *testing.T) {
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	tests := []struct {
+		name     string
+		mappings []Mapping
+		want     error
+	}{
+		{
+			name: "Different priorities can overlap",
+			mappings: []Mapping{
+				{Name: "RAM", Start: 0x0000, End: 0xFFFF, Device: &ram},
+				{Name: "IO", Start: 0xD000, End: 0xDFFF, Priority: 1, Device: &ram},
+			},
+		},
+		{
+			name: "Same priority cannot overlap",
+			mappings: []Mapping{
+				{Name: "RAM", Start: 0x0000, End: 0x7FFF, Device: &ram},
+				{Name: "ROM", Start: 0x7FFF, End: 0xFFFF, Device: &ram},
+			},
+			want: OverlappingMappings,
+		},
+		{
+			name: "Overlap under a higher priority",
+			mappings: []Mapping{
+				{Name: "RAM", Start: 0x0000, End: 0x7FFF, Device: &ram},
+				{Name: "IO", Start: 0x4000, End: 0x4FFF, Priority: 1, Device: &ram},
+				{Name: "ROM", Start: 0x4000, End: 0xFFFF, Device: &ram},
+			},
+			want: OverlappingMappings,
+		},
+		{
+			name:     "No device",
+			mappings: []Mapping{{Name: "RAM", Start: 0x0000, End: 0x7FFF}},
+			want:     InvalidMapping,
+		},
+		{
+			name:     "End before start",
+			mappings: []Mapping{{Name: "RAM", Start: 0x8000, End: 0x7FFF, Device: &ram}},
+			want:     InvalidMapping,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBusBuilder().Add(tt.mappings...).Build(); !errors.Is(err, tt.want) {
+				t.Errorf("Build() error got = %v, want = %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBus_Cpu(t *testing.T) {
+	// A device that faults is reported at its address on the bus.
+	device := &faultMemory{}
+	ram := NewPopulatedRam(OneKiloByte, []uint8{
+		0xAD, 0x34, 0x12, // LDA $1234
+	})
+	vectors := NewPopulatedRam(EightBytes, nil)
+	bus, err := NewBusBuilder().
+		Map("RAM", 0x0000, 0x03FF, &ram).
+		Map("Device", 0x2000, 0xBFFF, device).
+		Map("Vectors", 0xFFF8, 0xFFFF, &vectors).
+		WithUnmappedPolicy(FaultOnBus).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteResetVectorToMemory(bus, 0x0000); err != nil {
+		t.Fatal(err)
+	}
+	cpu, err := NewCpu(newLegalInstructionSet(), bus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	var fault MemoryFaultError
+	if _, err := cpu.Step(); !errors.As(err, &fault) || fault.Address != 0x1234 || fault.Access != ReadAccess {
+		t.Errorf("Step() error got = %v, want a read fault at $1234", err)
+	}
+
+	// faultMemory faults from $8000, so $A834 is offset $8834 of the device.
+	cpu.State.PC = 0x0000
+	ram.Write(0x0002, 0xA8)
+	if _, err := cpu.Step(); !errors.As(err, &fault) || fault.Address != 0xA834 {
+		t.Errorf("Step() error got = %v, want a read fault at $A834", err)
+	}
+}
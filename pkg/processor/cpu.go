@@ -3,10 +3,15 @@ package processor
 import (
 	"fmt"
 	"math"
+	"math/rand"
 )
 
+// StackPointerStart is the stack pointer after a reset of a Cpu whose stack pointer was zero.
 const StackPointerStart = 0xFD
 
+// resetCycles is the number of cycles taken by the reset sequence.
+const resetCycles = 7
+
 // State represents the entire state of the Cpu at a specific point.
 type State struct {
 	PC Address
@@ -21,16 +26,72 @@ func (s State) String() string {
 	return fmt.Sprintf("PC: 0x%04X, SP: 0x%02X, A: 0x%02X, X: 0x%02X, Y: 0x%02X, P: %v", s.PC, s.SP, s.A, s.X, s.Y, s.P.String())
 }
 
+// RunState represents whether the Cpu is executing instructions or not.
+type RunState uint8
+
+const (
+	Running RunState = iota // The Cpu is executing instructions.
+	Waiting                 // The Cpu is waiting for an interrupt (see WAI).
+	Stopped                 // The Cpu is stopped until it is reset (see STP).
+	Halted                  // The Cpu has crashed into a JAM opcode and is halted until it is reset.
+)
+
+func (r RunState) String() string {
+	switch r {
+	case Running:
+		return "Running"
+	case Waiting:
+		return "Waiting"
+	case Stopped:
+		return "Stopped"
+	case Halted:
+		return "Halted"
+	}
+	return fmt.Sprintf("RunState(%d)", uint8(r))
+}
+
+// Counters are the cumulative counts kept by a Cpu from the time it is created. They only
+// ever increase, even when the Cpu is reset, so they can be used as a time base.
+type Counters struct {
+	Cycles       uint64 // Every cycle that has elapsed, including the reset and interrupt sequences.
+	Instructions uint64 // The instructions that completed without an error.
+	Irqs         uint64 // The IRQs serviced.
+	Nmis         uint64 // The NMIs serviced, other than those that hijack a BRK.
+}
+
+// Snapshot is a consistent copy of the registers, run state and counters of a Cpu.
+type Snapshot struct {
+	State    State
+	RunState RunState
+	Counters Counters
+}
+
+// Clock is the time base shared by a Cpu and the devices attached to it, so that timers
+// and traces all measure time in the same cycles. It is implemented by Cpu.
+type Clock interface {
+	Cycles() uint64       // The total number of cycles that have elapsed.
+	Instructions() uint64 // The total number of instructions that have completed.
+}
+
 // Cpu represents the actual Cpu
 type Cpu struct {
 	State          State
 	memory         Memory
+	bus            cpuMemory      // The memory as accessed by the instructions, telling it the kind of access.
+	faults         FaultingMemory // The memory if it can report faults, otherwise nil.
 	instructionSet InstructionSet
+	tick           tickState
+	runState       RunState
+	jam            JamError // Details of the JAM opcode when Halted.
+	interrupts     interruptState
+	addressing     Addressing // Passed to every Operation so that it is not allocated.
+	counters       Counters
 }
 
 // NewCpu returns an initialised Cpu that supports the provided instruction set
-// and is connected to the supplied memory. A Reset() should be called on the
-// newly constructed CPU to set up the reset vector and stack pointer.
+// and is connected to the supplied memory. The registers are all zero; a Reset()
+// or PowerOn() should be called on the newly constructed CPU to set up the reset
+// vector and stack pointer.
 func NewCpu(is InstructionSet, memory Memory) (Cpu, error) {
 	if err := is.validate(); err != nil {
 		return Cpu{}, err
@@ -38,64 +99,297 @@ func NewCpu(is InstructionSet, memory Memory) (Cpu, error) {
 	if memory == nil {
 		return Cpu{}, MemoryMustBeProvided
 	}
-	return Cpu{memory: memory, instructionSet: is}, nil
+	faults, _ := memory.(FaultingMemory)
+	accesses, _ := memory.(AccessMemory)
+	bus := cpuMemory{memory: memory, accesses: accesses}
+	return Cpu{memory: memory, bus: bus, faults: faults, instructionSet: is}, nil
+}
+
+// cpuMemory is the memory as it is accessed by the Cpu. Every access is passed on with its
+// kind if the memory is an AccessMemory. The Cpu also uses it to redirect the IRQ vector when
+// an NMI hijacks BRK, and to skip the ValueAccess of an instruction that only writes to the
+// effective address or jumps to it.
+type cpuMemory struct {
+	memory    Memory
+	accesses  AccessMemory // The memory if it is told the kind of access, otherwise nil.
+	hijacked  bool         // Are data reads of the IRQ vector redirected to the NMI vector.
+	writeOnly bool         // Is the ValueAccess skipped.
+}
+
+// Read performs a read of data, which includes the vectors.
+func (m *cpuMemory) Read(address Address) uint8 {
+	if m.hijacked {
+		switch address {
+		case IrqVectorAddress:
+			address = NmiVectorAddress
+		case IrqVectorAddress + 1:
+			address = NmiVectorAddress + 1
+		}
+	}
+	return m.AccessRead(address, ReadAccess)
+}
+
+// Write performs a write of data.
+func (m *cpuMemory) Write(address Address, value uint8) {
+	m.AccessWrite(address, value, WriteAccess)
+}
+
+// AccessRead performs a read of the given kind. A ValueAccess is performed as a ReadAccess,
+// unless the instruction does not read the value.
+func (m *cpuMemory) AccessRead(address Address, access Access) uint8 {
+	if access == ValueAccess {
+		if m.writeOnly {
+			return 0
+		}
+		access = ReadAccess
+	}
+	if m.accesses != nil {
+		return m.accesses.AccessRead(address, access)
+	}
+	return m.memory.Read(address)
+}
+
+// AccessWrite performs a write of the given kind.
+func (m *cpuMemory) AccessWrite(address Address, value uint8, access Access) {
+	if m.accesses != nil {
+		m.accesses.AccessWrite(address, value, access)
+		return
+	}
+	m.memory.Write(address, value)
 }
 
-// Reset should be called before execution begins. It clears all flags, clears
-// registers X, Y and A, sets SP to 0xFD and sets PC to the reset vector that
-// is stored in RAM at 0xFFFC and 0xFFFD.
-func (c *Cpu) Reset() error {
+// Reset should be called before execution begins. It performs the 7 cycle reset
+// sequence of a real 6502, returning the number of cycles taken. The opcode fetch
+// and the following read are performed and ignored. The three stack pushes are
+// suppressed, being performed as reads, so SP is decremented by three. The I flag
+// is set and PC is set to the reset vector that is stored in RAM at 0xFFFC and
+// 0xFFFD. All other registers and flags are unchanged, so a newly constructed Cpu
+// has a SP of 0xFD after a reset. The instruction set may replace this behaviour,
+// for example the 65C02 also clears the decimal flag. The Cpu is left Running.
+func (c *Cpu) Reset() (uint, error) {
 	if c == nil {
-		return UninitialisedCpu
+		return 0, UninitialisedCpu
 	}
+	if c.memory == nil {
+		return 0, MemoryMustBeProvided
+	}
+
+	c.bus.AccessRead(c.State.PC, DummyReadAccess)
+	c.bus.AccessRead(c.State.PC, DummyReadAccess)
+	c.counters.Cycles += resetCycles
 
-	start, err := ReadResetVectorFromMemory(c.memory)
+	state, err := c.instructionSet.resetOperation()(c.State, c.newAddressing(Addressing{Memory: &c.bus}))
 	if err != nil {
-		return err
+		return resetCycles, c.fault(err)
 	}
 
-	c.State = State{PC: start, SP: StackPointerStart}
+	c.State = state
+	c.tick = tickState{}
+	c.runState = Running
+	c.jam = JamError{}
+	c.interrupts.nmiEdge = false
+	c.interrupts.pending = false
 
-	return nil
+	return resetCycles, c.fault(nil)
+}
+
+// PowerOnConfig describes the contents of the registers and RAM when the Cpu is
+// powered on. The registers of a real 6502 are not reliably defined at power on and
+// the reset sequence leaves most of them unchanged, so software that relies on them
+// can be tested using specific or random values.
+type PowerOnConfig struct {
+	State     State      // The registers before the reset sequence; must be zero with Random.
+	RamStart  Address    // The first address of RAM to initialise.
+	RamLength uint       // The number of bytes of RAM to initialise; zero leaves memory unchanged.
+	RamValue  uint8      // The value RAM is initialised with.
+	Random    *rand.Rand // If not nil, the registers and RAM are randomised using it.
+}
+
+// PowerOn initialises the registers and RAM as described by the config and then
+// performs the reset sequence, returning the number of cycles taken by the reset. The
+// InvalidPowerOnConfig error is returned, without changing anything, if the config has
+// both a State and a Random source, as the State would be replaced by random values.
+func (c *Cpu) PowerOn(config PowerOnConfig) (uint, error) {
+	if c == nil {
+		return 0, UninitialisedCpu
+	}
+	if c.memory == nil {
+		return 0, MemoryMustBeProvided
+	}
+	if config.Random != nil && config.State != (State{}) {
+		return 0, InvalidPowerOnConfig
+	}
+
+	state := config.State
+	if config.Random != nil {
+		state = State{
+			PC: Address(config.Random.Intn(0x10000)),
+			SP: uint8(config.Random.Intn(0x100)),
+			A:  uint8(config.Random.Intn(0x100)),
+			X:  uint8(config.Random.Intn(0x100)),
+			Y:  uint8(config.Random.Intn(0x100)),
+			P:  Status(config.Random.Intn(0x100)),
+		}
+	}
+
+	for i := range config.RamLength {
+		value := config.RamValue
+		if config.Random != nil {
+			value = uint8(config.Random.Intn(0x100))
+		}
+		c.memory.Write(config.RamStart+Address(i), value)
+	}
+
+	c.State = state
+	return c.Reset()
 }
 
 // Step a single machine instruction. This will read the opcode, advance the
 // program counter and then execute the instruction. If the opcode read is not
-// present in the CPUs instruction set then an error is returned. If there is
+// present in the CPUs instruction set then an UnknownOpcodeError is returned. If there is
 // an error executing the instruction then an error is returned and the state
 // changes relating to the instruction execution are not applied. In all cases
 // the program counter is incremented by at least 1 byte. Details of the number
 // of CPU cycles that have elapsed are returned; this will always be at least
-// 1 for a valid Cpu instance.
+// 1 for a valid Cpu instance that is not Stopped or Halted. If an instruction has been
+// partially executed using Tick() then Step completes that instruction instead.
+//
+// If the Cpu is Waiting then no instruction is executed, a single cycle elapses
+// and CpuWaiting is returned. If the Cpu is Stopped then no cycles elapse and
+// CpuStopped is returned. If a JAM opcode is executed then the Cpu is Halted and a
+// JamError is returned, as it is by every Step until the Cpu is reset.
+//
+// The interrupt lines (see SetIrqLine and SetNmiLine) are sampled during every Step. If
+// an interrupt is pending once an instruction has completed then the following Step
+// services the interrupt instead of executing an instruction, taking 7 cycles.
+//
+// The cycles, instructions and interrupts are added to the Counters of the Cpu. If the
+// memory is a FaultingMemory that reports a fault during the Step then the instruction is
+// completed with the values read and a MemoryFaultError is returned.
 func (c *Cpu) Step() (uint, error) {
 	if c == nil {
 		return 0, UninitialisedCpu
 	}
 
-	opcode := Opcode(c.memory.Read(c.State.PC))
+	if c.tick.cycle > 0 {
+		cycles := uint(0)
+		for {
+			done, err := c.Tick()
+			cycles++
+			if done || err != nil {
+				return cycles, err
+			}
+		}
+	}
+
+	cycles, err := c.step()
+	c.counters.Cycles += uint64(cycles)
+	return cycles, c.fault(err)
+}
+
+// fault returns the error, or if it is nil then any fault reported by the memory since it
+// was last checked, along with the State of the Cpu.
+func (c *Cpu) fault(err error) error {
+	if c.faults == nil {
+		return err
+	}
+	fault, ok := c.faults.Fault()
+	if !ok || err != nil {
+		return err
+	}
+	fault.State = c.State
+	return fault
+}
+
+// step executes the next instruction, or services an interrupt, for Step.
+func (c *Cpu) step() (uint, error) {
+	c.sampleInterrupts()
+	switch c.runState {
+	case Waiting:
+		if !c.wake() {
+			return 1, CpuWaiting
+		}
+	case Stopped:
+		return 0, CpuStopped
+	case Halted:
+		return 0, c.jam
+	}
+
+	if c.interrupts.pending {
+		return c.serviceInterrupt()
+	}
+
+	pc := c.State.PC
+	opcode := Opcode(c.bus.AccessRead(pc, OpcodeFetchAccess))
 	c.State.PC++
 
-	instruction, err := c.instructionSet.Get(opcode)
-	if err != nil {
-		return 1, err
+	instruction := c.instructionSet.lookup(opcode)
+	if instruction == nil {
+		return 1, UnknownOpcodeError{PC: pc, Opcode: opcode, State: c.State}
 	}
 
+	// The interrupt lines are constant for the whole instruction so they are polled
+	// as they were sampled.
+	nmi, irq := c.interrupts.nmiEdge, c.interrupts.irq
+	disabled := c.State.P&FlagInterrupt != 0
+
 	// If there is an error executing the instruction (which should not happen)
 	// then we return an error and do not apply the instruction state changes.
-	newState, cycles, err := instruction.Execute(c.State, c.memory)
+	newState, cycles, err := c.execute(instruction)
 	if err != nil {
 		return cycles + 1, err
 	}
 	c.State = newState
 
+	if err := c.changeRunState(c.addressing.RunState, pc, opcode); err != nil {
+		return cycles + 1, err
+	}
+	c.counters.Instructions++
+	c.pollInterrupts(instruction, nmi, irq, disabled)
+
 	return cycles + 1, nil
 }
 
+// newAddressing sets the Addressing passed to every Operation called by the Cpu and returns
+// it. Reusing the same Addressing avoids a heap allocation each time an Operation is called.
+func (c *Cpu) newAddressing(addressing Addressing) *Addressing {
+	c.addressing = addressing
+	return &c.addressing
+}
+
+// RunState returns whether the Cpu is Running, Waiting for an interrupt, Stopped or Halted.
+func (c *Cpu) RunState() RunState {
+	if c == nil {
+		return Stopped
+	}
+	return c.runState
+}
+
+// changeRunState applies a run state reported by the Operation of the instruction
+// whose opcode was read from pc. If the Cpu has halted then a JamError is returned.
+func (c *Cpu) changeRunState(runState RunState, pc Address, opcode Opcode) error {
+	if runState == Running {
+		return nil
+	}
+	c.runState = runState
+	if runState == Halted {
+		c.jam = JamError{PC: pc, Opcode: opcode, State: c.State}
+		return c.jam
+	}
+	return nil
+}
+
+// halted returns true if the Cpu can only be restarted by a reset.
+func (c *Cpu) halted() bool {
+	return c.runState == Stopped || c.runState == Halted
+}
+
 // Execute will execute instructions until the specified number of cycles have been
 // passed; returning the actual number of cycles that have cycled. The number of cycles
 // actually executed may be more than those specified if the last instruction executed
 // takes it over the limit. Specifying a value of zero for cycles will let the CPU run
-// continuously. If an unknown instruction is executed then Execute also stops.
+// continuously. If an unknown instruction is executed then Execute also stops, as it
+// does if the Cpu is Waiting for an interrupt, is Stopped or has Halted.
 func (c *Cpu) Execute(cycles uint) (uint, error) {
 	if c == nil {
 		return 0, UninitialisedCpu
@@ -121,17 +415,30 @@ func (c *Cpu) Execute(cycles uint) (uint, error) {
 // is pushed to the stack (high byte first, low byte second). The status register is
 // then pushed onto the stack. The Interrupt flag is set then the NMI vector stored
 // at address 0xFFFA (low byte) and 0xFFFB (high byte) is loaded into the PC ready
-// to execute. No actual instructions are executed.
+// to execute. No actual instructions are executed. The instruction set may replace
+// this behaviour, for example the 65C02 also clears the decimal flag. A Cpu that is
+// Waiting resumes but a Stopped or Halted Cpu ignores the interrupt.
+//
+// Deprecated: The NMI is serviced immediately, regardless of instruction boundaries, takes
+// no cycles and ignores the quirks of a real 6502 such as the branch that delays polling.
+// Use SetNmiLine, whose NMI is detected and serviced as it is by a real 6502.
 func (c *Cpu) Nmi() error {
 	if c == nil {
 		return UninitialisedCpu
 	}
-	addressing := Addressing{Memory: c.memory}
-	state, err := Nmi(c.State, addressing)
+
+	// A stopped or halted CPU can only be restarted by a reset.
+	if c.halted() {
+		return nil
+	}
+	c.runState = Running
+
+	state, err := c.instructionSet.nmiOperation()(c.State, c.newAddressing(Addressing{Memory: &c.bus}))
 	if err != nil {
 		return err
 	}
 	c.State = state
+	c.counters.Nmis++
 	return nil
 }
 
@@ -140,26 +447,67 @@ func (c *Cpu) Nmi() error {
 // then pushed onto the stack. The Interrupt flag is set then the IRQ vector stored
 // at address 0xFFFE (low byte) and 0xFFFF (high byte) is loaded into the PC ready
 // to execute. No actual instructions are executed. If the processor status flag has
-// the Interrupt flag set when calling this method, it does nothing.
+// the Interrupt flag set when calling this method, it does nothing other than resume
+// a Cpu that is Waiting. Neither interrupt restarts a Stopped or Halted Cpu. The instruction
+// set may replace this behaviour, for example the 65C02 also clears the decimal flag.
+//
+// Deprecated: The interrupt is serviced immediately, regardless of instruction boundaries,
+// takes no cycles and ignores the quirks of a real 6502 such as CLI, SEI and PLP delaying
+// an IRQ. Use SetIrqLine, whose IRQ is polled and serviced as it is by a real 6502.
 func (c *Cpu) Interrupt() error {
 	if c == nil {
 		return UninitialisedCpu
 	}
 
+	// A stopped or halted CPU can only be restarted by a reset.
+	if c.halted() {
+		return nil
+	}
+
+	// A waiting CPU resumes even if the interrupt disable flag is set; in which
+	// case execution continues with the instruction following the WAI.
+	c.runState = Running
+
 	// If the interrupt disable flag is set then ignore the request.
 	if c.State.P.ToFlags().Interrupt {
 		return nil
 	}
 
-	addressing := Addressing{Memory: c.memory}
-	state, err := Interrupt(c.State, addressing)
+	state, err := c.instructionSet.interruptOperation()(c.State, c.newAddressing(Addressing{Memory: &c.bus}))
 	if err != nil {
 		return err
 	}
 	c.State = state
+	c.counters.Irqs++
 	return nil
 }
 
+// Counters returns the cumulative counts of the cycles, instructions and interrupts.
+func (c *Cpu) Counters() Counters {
+	if c == nil {
+		return Counters{}
+	}
+	return c.counters
+}
+
+// Cycles returns the total number of cycles that have elapsed. See Clock.
+func (c *Cpu) Cycles() uint64 {
+	return c.Counters().Cycles
+}
+
+// Instructions returns the total number of instructions that have completed. See Clock.
+func (c *Cpu) Instructions() uint64 {
+	return c.Counters().Instructions
+}
+
+// Snapshot returns a copy of the registers, run state and counters of the Cpu.
+func (c *Cpu) Snapshot() Snapshot {
+	if c == nil {
+		return Snapshot{RunState: Stopped}
+	}
+	return Snapshot{State: c.State, RunState: c.runState, Counters: c.counters}
+}
+
 // Opcodes returns a sorted slice of all the opcodes the Cpu has in its instruction set.
 func (c *Cpu) Opcodes() ([]Opcode, error) {
 	if c == nil {
@@ -169,6 +517,16 @@ func (c *Cpu) Opcodes() ([]Opcode, error) {
 	return c.instructionSet.Opcodes(), nil
 }
 
+// Mnemonic returns the Mnemonic that describes the opcode in the instruction set of the Cpu.
+// See InstructionSet.Mnemonic.
+func (c *Cpu) Mnemonic(opcode Opcode) (Mnemonic, error) {
+	if c == nil {
+		return Mnemonic{}, UninitialisedCpu
+	}
+
+	return c.instructionSet.Mnemonic(opcode)
+}
+
 // Memory returns a reference to the memory attached to the CPU.
 func (c *Cpu) Memory() (Memory, error) {
 	if c == nil {
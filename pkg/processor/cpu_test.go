@@ -1,6 +1,8 @@
 package processor
 
 import (
+	"errors"
+	"math/rand"
 	"reflect"
 	"testing"
 )
@@ -79,7 +81,7 @@ func TestNewCpu(t *testing.T) {
 		{
 			name:    "Empty instruction set should error",
 			wantErr: true,
-			is:      InstructionSet{instructions: map[Opcode]Instruction{}},
+			is:      InstructionSet{instructions: newInstructionTable(map[Opcode]Instruction{}, nil)},
 		},
 		{
 			name:   "Valid instruction set and memory should be fine",
@@ -159,21 +161,32 @@ func testCpuMethod(t *testing.T, tt testCpuMethodConfig, callback func(cpu *Cpu)
 
 func TestCpu_Reset(t *testing.T) {
 	callback := func(cpu *Cpu) error {
-		return cpu.Reset()
+		cycles, err := cpu.Reset()
+		if cycles != 7 {
+			t.Errorf("Reset() cycles got = %v, want = 7", cycles)
+		}
+		return err
 	}
 	tests := []testCpuMethodConfig{
 		{
 			name:      "Reset with no reset vector in place",
 			startRam:  []uint8{0, 0, 0, 0, 0, 0, 0, 0},
-			wantState: State{SP: StackPointerStart},
+			wantState: State{SP: StackPointerStart, P: FlagInterrupt},
 			wantRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 		},
 		{
 			name:      "Reset with a reset vector 0x1234 in place",
 			startRam:  []uint8{0, 0, 0, 0, 0x34, 0x12, 0, 0},
-			wantState: State{PC: MakeAddress(0x34, 0x12), SP: StackPointerStart},
+			wantState: State{PC: MakeAddress(0x34, 0x12), SP: StackPointerStart, P: FlagInterrupt},
 			wantRam:   []uint8{0, 0, 0, 0, 0x34, 0x12, 0, 0},
 		},
+		{
+			name:       "Reset decrements SP and leaves the other registers unchanged",
+			startState: State{PC: 0x4321, SP: 0x01, A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative | FlagDecimal},
+			startRam:   []uint8{0, 0, 0, 0, 0x34, 0x12, 0, 0},
+			wantState:  State{PC: 0x1234, SP: 0xFE, A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative | FlagDecimal | FlagInterrupt},
+			wantRam:    []uint8{0, 0, 0, 0, 0x34, 0x12, 0, 0},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -181,10 +194,109 @@ func TestCpu_Reset(t *testing.T) {
 		})
 	}
 
+	t.Run("Reset bus activity", func(t *testing.T) {
+		memory := &traceMemory{}
+		memory.ram[0xFFFC], memory.ram[0xFFFD] = 0x34, 0x12
+		cpu, err := NewCpu(newLegalInstructionSet(), memory)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cpu.State.PC = 0x0300
+		if _, err := cpu.Reset(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []busAccess{
+			{address: 0x0300}, {address: 0x0300},
+			{address: 0x0100}, {address: 0x01FF}, {address: 0x01FE},
+			{address: 0xFFFC, value: 0x34}, {address: 0xFFFD, value: 0x12},
+		}
+		if !reflect.DeepEqual(memory.trace, want) {
+			t.Errorf("Reset() bus activity got = %v, want = %v", memory.trace, want)
+		}
+	})
+
 	// Check support for nil
-	if err := (*Cpu)(nil).Reset(); err == nil {
+	if _, err := (*Cpu)(nil).Reset(); err == nil {
 		t.Errorf("Reset() did not raise an error when called on nil")
 	}
+	if _, err := (&Cpu{}).Reset(); err != MemoryMustBeProvided {
+		t.Errorf("Reset() with no memory error = %v, want %v", err, MemoryMustBeProvided)
+	}
+}
+
+func TestCpu_PowerOn(t *testing.T) {
+	newCpu := func() (*Cpu, *traceMemory) {
+		memory := &traceMemory{}
+		memory.ram[0xFFFC], memory.ram[0xFFFD] = 0x34, 0x12
+		cpu, err := NewCpu(newLegalInstructionSet(), memory)
+		if err != nil {
+			panic(err)
+		}
+		return &cpu, memory
+	}
+
+	t.Run("Configured registers and RAM", func(t *testing.T) {
+		cpu, memory := newCpu()
+		cycles, err := cpu.PowerOn(PowerOnConfig{
+			State:     State{PC: 0xABCD, SP: 0x10, A: 0x01, X: 0x02, Y: 0x03, P: FlagCarry},
+			RamStart:  0x0200,
+			RamLength: 0x100,
+			RamValue:  0xAA,
+		})
+		if cycles != 7 || err != nil {
+			t.Fatalf("PowerOn() got cycles = %v, err = %v", cycles, err)
+		}
+
+		want := State{PC: 0x1234, SP: 0x0D, A: 0x01, X: 0x02, Y: 0x03, P: FlagCarry | FlagInterrupt}
+		if cpu.State != want {
+			t.Errorf("PowerOn() State got = %v, want = %v", cpu.State, want)
+		}
+		if memory.ram[0x01FF] != 0 || memory.ram[0x0200] != 0xAA || memory.ram[0x02FF] != 0xAA || memory.ram[0x0300] != 0 {
+			t.Errorf("PowerOn() did not initialise only $0200 to $02FF")
+		}
+	})
+
+	t.Run("Random registers and RAM are repeatable", func(t *testing.T) {
+		cpu1, memory1 := newCpu()
+		cpu2, memory2 := newCpu()
+		for _, cpu := range []*Cpu{cpu1, cpu2} {
+			if _, err := cpu.PowerOn(PowerOnConfig{RamLength: 0x8000, Random: rand.New(rand.NewSource(6502))}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if cpu1.State != cpu2.State || memory1.ram != memory2.ram {
+			t.Errorf("PowerOn() with the same seed differs")
+		}
+		if cpu1.State.PC != 0x1234 || cpu1.State.P&FlagInterrupt == 0 {
+			t.Errorf("PowerOn() did not reset, State = %v", cpu1.State)
+		}
+		if cpu1.State.A == 0 && cpu1.State.X == 0 && cpu1.State.Y == 0 {
+			t.Errorf("PowerOn() registers were not randomised, State = %v", cpu1.State)
+		}
+		if memory1.ram[0x8000] != 0 {
+			t.Errorf("PowerOn() initialised RAM beyond the length")
+		}
+	})
+
+	t.Run("A State with a Random source is an error", func(t *testing.T) {
+		cpu, memory := newCpu()
+		_, err := cpu.PowerOn(PowerOnConfig{
+			State:     State{A: 0x01},
+			RamLength: 0x100,
+			Random:    rand.New(rand.NewSource(6502)),
+		})
+		if err != InvalidPowerOnConfig {
+			t.Errorf("PowerOn() error got = %v, want = %v", err, InvalidPowerOnConfig)
+		}
+		if cpu.State != (State{}) || memory.ram[0x0000] != 0 {
+			t.Errorf("PowerOn() changed the State or RAM, State = %v", cpu.State)
+		}
+	})
+
+	if _, err := (*Cpu)(nil).PowerOn(PowerOnConfig{}); err != UninitialisedCpu {
+		t.Errorf("PowerOn() on nil error = %v, want %v", err, UninitialisedCpu)
+	}
 }
 
 func TestCpu_Step(t *testing.T) {
@@ -198,7 +310,7 @@ func TestCpu_Step(t *testing.T) {
 				name:      "Single step after a reset with single default opcode.",
 				startRam:  []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 				wantRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0},
-				wantState: State{PC: 1, SP: StackPointerStart},
+				wantState: State{PC: 1, SP: StackPointerStart, P: FlagInterrupt},
 			},
 		},
 		{
@@ -207,7 +319,7 @@ func TestCpu_Step(t *testing.T) {
 				name:      "Single step after a reset with an unknown opcode.",
 				startRam:  []uint8{0xFF, 0, 0, 0, 0, 0, 0, 0},
 				wantRam:   []uint8{0xFF, 0, 0, 0, 0, 0, 0, 0},
-				wantState: State{PC: 1, SP: StackPointerStart},
+				wantState: State{PC: 1, SP: StackPointerStart, P: FlagInterrupt},
 				wantErr:   true,
 			},
 		},
@@ -217,7 +329,7 @@ func TestCpu_Step(t *testing.T) {
 				name:      "Single step after a reset with a known opcode; no State mutation.",
 				startRam:  []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 				wantRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0},
-				wantState: State{PC: 1, SP: StackPointerStart},
+				wantState: State{PC: 1, SP: StackPointerStart, P: FlagInterrupt},
 			},
 		},
 		{
@@ -242,7 +354,7 @@ func TestCpu_Step(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			callback := func(cpu *Cpu) error {
-				if err := cpu.Reset(); err != nil {
+				if _, err := cpu.Reset(); err != nil {
 					return err
 				}
 				cycles, err := cpu.Step()
@@ -281,7 +393,7 @@ func TestCpu_Execute(t *testing.T) {
 				name:      "Execute a single cycle default instruction",
 				startRam:  []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 				wantRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0},
-				wantState: State{PC: 1, SP: StackPointerStart},
+				wantState: State{PC: 1, SP: StackPointerStart, P: FlagInterrupt},
 			},
 		},
 		{
@@ -291,7 +403,7 @@ func TestCpu_Execute(t *testing.T) {
 				name:      "Execute a two cycles with default instruction",
 				startRam:  []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 				wantRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0},
-				wantState: State{PC: 2, SP: StackPointerStart},
+				wantState: State{PC: 2, SP: StackPointerStart, P: FlagInterrupt},
 			},
 		},
 		{
@@ -301,7 +413,7 @@ func TestCpu_Execute(t *testing.T) {
 				name:      "Execute three cycles with default instruction.",
 				startRam:  []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 				wantRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0},
-				wantState: State{PC: 3, SP: StackPointerStart},
+				wantState: State{PC: 3, SP: StackPointerStart, P: FlagInterrupt},
 			},
 		},
 		{
@@ -334,7 +446,7 @@ func TestCpu_Execute(t *testing.T) {
 			}
 
 			callback := func(cpu *Cpu) error {
-				if err := cpu.Reset(); err != nil {
+				if _, err := cpu.Reset(); err != nil {
 					return err
 				}
 				cycles, err := cpu.Execute(tt.cycles)
@@ -546,6 +658,82 @@ func TestCpu_Opcodes(t *testing.T) {
 	}
 }
 
+func TestCpu_Mnemonic(t *testing.T) {
+	want, err := MnemonicFromOpCode(0xEA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is, err := NewInstructionSetBuilder().AddMnemonics(want).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	memory := NewPopulatedRam(SixteenBytes, nil)
+	cpu, err := NewCpu(is, &memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := cpu.Mnemonic(0xEA); err != nil || got.Operation.Name != want.Operation.Name {
+		t.Errorf("Mnemonic() got = %v, %v, want = %v", got, err, want)
+	}
+	if _, err := cpu.Mnemonic(0xA9); err != OpCodeNotInInstructionSet {
+		t.Errorf("Mnemonic() error got = %v, want = %v", err, OpCodeNotInInstructionSet)
+	}
+	if _, err := (*Cpu)(nil).Mnemonic(0xEA); err != UninitialisedCpu {
+		t.Errorf("Mnemonic() error got = %v, want = %v", err, UninitialisedCpu)
+	}
+}
+
+func TestCpu_Counters(t *testing.T) {
+	cpu, _ := newInterruptCpu(newLegalInstructionSet(), State{}, nil, nil)
+	var clock Clock = cpu
+
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 2 {
+		if _, err := cpu.Tick(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The interrupt disable flag is set by the reset so the IRQ is ignored.
+	if err := cpu.Interrupt(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cpu.Nmi(); err != nil {
+		t.Fatal(err)
+	}
+	cpu.State.P &^= FlagInterrupt
+	if err := cpu.Interrupt(); err != nil {
+		t.Fatal(err)
+	}
+	// The counters are not reset.
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Counters{Cycles: 7 + 3*2 + 2 + 7, Instructions: 4, Irqs: 1, Nmis: 1}
+	if got := cpu.Counters(); got != want {
+		t.Errorf("Counters() got = %+v, want = %+v", got, want)
+	}
+	if clock.Cycles() != want.Cycles || clock.Instructions() != want.Instructions {
+		t.Errorf("Clock got = %v cycles and %v instructions", clock.Cycles(), clock.Instructions())
+	}
+	if got := cpu.Snapshot(); got != (Snapshot{State: cpu.State, RunState: Running, Counters: want}) {
+		t.Errorf("Snapshot() got = %+v", got)
+	}
+
+	var nilCpu *Cpu
+	if nilCpu.Counters() != (Counters{}) || nilCpu.Cycles() != 0 || nilCpu.Snapshot().RunState != Stopped {
+		t.Errorf("Counters() did not handle nil")
+	}
+}
+
 func TestCpu_Memory(t *testing.T) {
 
 	// Create CPU with populated memory and a default test instruction set if one is not specified.
@@ -568,3 +756,397 @@ func TestCpu_Memory(t *testing.T) {
 		t.Errorf("Memory() did not raise an error when called on nil")
 	}
 }
+
+func TestCpu_RunState(t *testing.T) {
+	const (
+		wai = 0xCB
+		stp = 0xDB
+		nop = 0xEA
+	)
+
+	is := newW65C02SInstructionSet()
+
+	newCpu := func(program ...uint8) (*Cpu, *traceMemory) {
+		memory := &traceMemory{}
+		copy(memory.ram[0x0200:], program)
+		memory.ram[0xFFFA], memory.ram[0xFFFB] = 0x00, 0x30 // NMI vector $3000
+		memory.ram[0xFFFC], memory.ram[0xFFFD] = 0x00, 0x02 // Reset vector $0200
+		memory.ram[0xFFFE], memory.ram[0xFFFF] = 0x00, 0x40 // IRQ vector $4000
+		cpu, err := NewCpu(is, memory)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := cpu.Reset(); err != nil {
+			panic(err)
+		}
+		return &cpu, memory
+	}
+
+	t.Run("WAI waits until an IRQ which is serviced", func(t *testing.T) {
+		cpu, _ := newCpu(wai, nop)
+		cpu.State.P.ClearInterrupt()
+		if cycles, err := cpu.Step(); err != nil || cycles != 3 {
+			t.Fatalf("Step() WAI got cycles = %v, err = %v", cycles, err)
+		}
+		if cpu.RunState() != Waiting {
+			t.Errorf("RunState() got = %v, want = %v", cpu.RunState(), Waiting)
+		}
+		if cycles, err := cpu.Step(); err != CpuWaiting || cycles != 1 {
+			t.Errorf("Step() while waiting got cycles = %v, err = %v", cycles, err)
+		}
+		if cycles, err := cpu.Execute(100); err != CpuWaiting || cycles != 1 {
+			t.Errorf("Execute() while waiting got cycles = %v, err = %v", cycles, err)
+		}
+		if _, err := cpu.Tick(); err != CpuWaiting {
+			t.Errorf("Tick() while waiting got err = %v", err)
+		}
+
+		if err := cpu.Interrupt(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Running || cpu.State.PC != 0x4000 {
+			t.Errorf("Interrupt() did not resume and service the IRQ, RunState = %v, State = %v", cpu.RunState(), cpu.State)
+		}
+	})
+
+	t.Run("WAI resumes without servicing an IRQ when interrupts are disabled", func(t *testing.T) {
+		cpu, _ := newCpu(wai, nop)
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cpu.Interrupt(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Running || cpu.State.PC != 0x0201 {
+			t.Errorf("Interrupt() got RunState = %v, State = %v", cpu.RunState(), cpu.State)
+		}
+		if cycles, err := cpu.Step(); err != nil || cycles != 2 || cpu.State.PC != 0x0202 {
+			t.Errorf("Step() after resuming got cycles = %v, err = %v, State = %v", cycles, err, cpu.State)
+		}
+	})
+
+	t.Run("WAI waits until an NMI", func(t *testing.T) {
+		cpu, _ := newCpu(wai)
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cpu.Nmi(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Running || cpu.State.PC != 0x3000 {
+			t.Errorf("Nmi() got RunState = %v, State = %v", cpu.RunState(), cpu.State)
+		}
+	})
+
+	t.Run("STP stops until a reset", func(t *testing.T) {
+		cpu, _ := newCpu(stp, nop)
+		if cycles, err := cpu.Step(); err != nil || cycles != 3 {
+			t.Fatalf("Step() STP got cycles = %v, err = %v", cycles, err)
+		}
+		if cpu.RunState() != Stopped {
+			t.Errorf("RunState() got = %v, want = %v", cpu.RunState(), Stopped)
+		}
+		if cycles, err := cpu.Step(); err != CpuStopped || cycles != 0 {
+			t.Errorf("Step() while stopped got cycles = %v, err = %v", cycles, err)
+		}
+		if cycles, err := cpu.Execute(0); err != CpuStopped || cycles != 0 {
+			t.Errorf("Execute() while stopped got cycles = %v, err = %v", cycles, err)
+		}
+
+		// Neither interrupt restarts the CPU.
+		if err := cpu.Interrupt(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cpu.Nmi(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Stopped || cpu.State.PC != 0x0201 {
+			t.Errorf("Interrupts got RunState = %v, State = %v", cpu.RunState(), cpu.State)
+		}
+
+		if _, err := cpu.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Running || cpu.State.PC != 0x0200 {
+			t.Errorf("Reset() got RunState = %v, State = %v", cpu.RunState(), cpu.State)
+		}
+	})
+
+	t.Run("STP executed using Tick", func(t *testing.T) {
+		cpu, _ := newCpu(stp)
+		for i, want := range []bool{false, false, true} {
+			done, err := cpu.Tick()
+			if err != nil || done != want {
+				t.Fatalf("Tick() cycle %v got done = %v, err = %v", i+1, done, err)
+			}
+		}
+		if _, err := cpu.Tick(); err != CpuStopped {
+			t.Errorf("Tick() while stopped got err = %v", err)
+		}
+	})
+
+	if got := (*Cpu)(nil).RunState(); got != Stopped {
+		t.Errorf("RunState() on nil got = %v, want = %v", got, Stopped)
+	}
+}
+
+func TestRunState_String(t *testing.T) {
+	tests := []struct {
+		runState RunState
+		want     string
+	}{
+		{Running, "Running"},
+		{Waiting, "Waiting"},
+		{Stopped, "Stopped"},
+		{Halted, "Halted"},
+		{RunState(0xFF), "RunState(255)"},
+	}
+	for _, tt := range tests {
+		if got := tt.runState.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestCpu_Halted(t *testing.T) {
+	const jam = 0x22
+
+	newCpu := func() (*Cpu, *traceMemory) {
+		memory := &traceMemory{}
+		memory.ram[0x0200], memory.ram[0x0201] = 0xEA, jam  // NOP, JAM
+		memory.ram[0xFFFA], memory.ram[0xFFFB] = 0x00, 0x30 // NMI vector $3000
+		memory.ram[0xFFFC], memory.ram[0xFFFD] = 0x00, 0x02 // Reset vector $0200
+		memory.ram[0xFFFE], memory.ram[0xFFFF] = 0x00, 0x40 // IRQ vector $4000
+		cpu, err := NewCpu(newExtendedInstructionSet(), memory)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := cpu.Reset(); err != nil {
+			panic(err)
+		}
+		return &cpu, memory
+	}
+	// jammed returns true if the error is the JamError of the JAM at $0201.
+	jammed := func(err error) bool {
+		var jamErr JamError
+		return errors.As(err, &jamErr) && jamErr.PC == 0x0201 && jamErr.Opcode == jam
+	}
+
+	t.Run("JAM halts until a reset", func(t *testing.T) {
+		cpu, _ := newCpu()
+		cycles, err := cpu.Execute(0)
+		if cycles != 4 || !jammed(err) {
+			t.Fatalf("Execute() got cycles = %v, err = %v, want cycles = 4 and a JAM at $0201", cycles, err)
+		}
+		var jamErr JamError
+		if !errors.As(err, &jamErr) || !errors.Is(err, CpuHalted) || errors.Is(err, OpCodeNotInInstructionSet) {
+			t.Errorf("Execute() error = %v is not a JamError", err)
+		}
+		if jamErr.State != cpu.State {
+			t.Errorf("JamError State got = %v, want = %v", jamErr.State, cpu.State)
+		}
+		if cpu.RunState() != Halted {
+			t.Errorf("RunState() got = %v, want = %v", cpu.RunState(), Halted)
+		}
+		state := cpu.State
+
+		if cycles, err := cpu.Step(); cycles != 0 || !jammed(err) {
+			t.Errorf("Step() while halted got cycles = %v, err = %v", cycles, err)
+		}
+		if cycles, err := cpu.Execute(100); cycles != 0 || !jammed(err) {
+			t.Errorf("Execute() while halted got cycles = %v, err = %v", cycles, err)
+		}
+		if _, err := cpu.Tick(); !jammed(err) {
+			t.Errorf("Tick() while halted got err = %v", err)
+		}
+
+		// Neither interrupt is serviced.
+		if err := cpu.Interrupt(); err != nil {
+			t.Fatal(err)
+		}
+		if err := cpu.Nmi(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Halted || cpu.State != state {
+			t.Errorf("Interrupts got RunState = %v, State = %v, want State = %v", cpu.RunState(), cpu.State, state)
+		}
+
+		if _, err := cpu.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.RunState() != Running {
+			t.Errorf("Reset() got RunState = %v, want = %v", cpu.RunState(), Running)
+		}
+		if cycles, err := cpu.Step(); cycles != 2 || err != nil {
+			t.Errorf("Step() after reset got cycles = %v, err = %v", cycles, err)
+		}
+	})
+
+	t.Run("JAM halts using Tick", func(t *testing.T) {
+		cpu, memory := newCpu()
+		cpu.State.PC = 0x0201
+		cycles, _, err := tickInstruction(cpu, memory)
+		if cycles != 2 || !jammed(err) {
+			t.Errorf("Tick() got cycles = %v, err = %v, want cycles = 2 and a JAM at $0201", cycles, err)
+		}
+		if cpu.RunState() != Halted {
+			t.Errorf("RunState() got = %v, want = %v", cpu.RunState(), Halted)
+		}
+		if cycles, err := cpu.Step(); cycles != 0 || !jammed(err) {
+			t.Errorf("Step() while halted got cycles = %v, err = %v", cycles, err)
+		}
+	})
+
+	t.Run("An incomplete instruction set is not a JAM", func(t *testing.T) {
+		memory := &traceMemory{}
+		memory.ram[0x0000] = jam
+		cpu, err := NewCpu(newLegalInstructionSet(), memory)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = cpu.Step()
+		var jamErr JamError
+		if !errors.Is(err, OpCodeNotInInstructionSet) || errors.As(err, &jamErr) {
+			t.Errorf("Step() got err = %v, want = %v", err, OpCodeNotInInstructionSet)
+		}
+		if cpu.RunState() != Running {
+			t.Errorf("RunState() got = %v, want = %v", cpu.RunState(), Running)
+		}
+	})
+}
+
+// faultMemory is a traceMemory that faults when the addresses from $8000 are accessed.
+type faultMemory struct {
+	traceMemory
+	fault  MemoryFaultError
+	faulty bool
+}
+
+func (m *faultMemory) Read(address Address) uint8 {
+	m.check(address, ReadAccess)
+	return m.traceMemory.Read(address)
+}
+
+func (m *faultMemory) Write(address Address, value uint8) {
+	m.check(address, WriteAccess)
+	m.traceMemory.Write(address, value)
+}
+
+func (m *faultMemory) check(address Address, access Access) {
+	if address >= 0x8000 && !m.faulty {
+		m.fault, m.faulty = MemoryFaultError{Address: address, Access: access}, true
+	}
+}
+
+func (m *faultMemory) Fault() (MemoryFaultError, bool) {
+	fault, faulty := m.fault, m.faulty
+	m.fault, m.faulty = MemoryFaultError{}, false
+	return fault, faulty
+}
+
+func TestCpu_ExecutionErrors(t *testing.T) {
+	const (
+		jam    = 0x02
+		ldaAbs = 0xAD
+		staAbs = 0x8D
+	)
+
+	tests := []struct {
+		name    string
+		program []uint8
+		want    error
+		wantErr error
+	}{
+		{
+			name:    "Unknown opcode",
+			program: []uint8{jam},
+			want:    OpCodeNotInInstructionSet,
+			wantErr: UnknownOpcodeError{PC: 0x0200, Opcode: jam, State: State{PC: 0x0201, A: 0x12}},
+		},
+		{
+			name:    "Read fault",
+			program: []uint8{ldaAbs, 0x34, 0x92},
+			want:    MemoryFault,
+			wantErr: MemoryFaultError{Address: 0x9234, Access: ReadAccess, State: State{PC: 0x0203, P: FlagZero}},
+		},
+		{
+			name:    "Write fault",
+			program: []uint8{staAbs, 0x00, 0x80},
+			want:    MemoryFault,
+			wantErr: MemoryFaultError{Address: 0x8000, Access: WriteAccess, State: State{PC: 0x0203, A: 0x12}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, tick := range []bool{false, true} {
+				memory := &faultMemory{}
+				copy(memory.ram[0x0200:], tt.program)
+				cpu, err := NewCpu(newLegalInstructionSet(), memory)
+				if err != nil {
+					t.Fatal(err)
+				}
+				cpu.State = State{PC: 0x0200, A: 0x12}
+
+				if tick {
+					_, _, err = tickInstruction(&cpu, &memory.traceMemory)
+					for err == nil && cpu.tick.cycle != 0 {
+						_, err = cpu.Tick()
+					}
+				} else {
+					_, err = cpu.Step()
+				}
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("Tick %v error got = %v, want = %v", tick, err, tt.want)
+				}
+				if err != tt.wantErr {
+					t.Errorf("Tick %v error got = %#v, want = %#v", tick, err, tt.wantErr)
+				}
+				// The fault is only returned once.
+				if _, faulty := memory.Fault(); faulty {
+					t.Errorf("Tick %v the fault was not cleared", tick)
+				}
+			}
+		})
+	}
+
+	if got, want := (UnknownOpcodeError{PC: 0x1234, Opcode: 0x02}).Error(), "the opcode $02 at $1234 is not present in the instruction set"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+	if got, want := (MemoryFaultError{Address: 0x8000, Access: WriteAccess}).Error(), "the memory write of $8000 failed"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func TestJamError_Error(t *testing.T) {
+	got := JamError{PC: 0x1234, Opcode: 0x02}.Error()
+	want := "the CPU halted executing JAM opcode $02 at $1234"
+	if got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func TestCpu_DoesNotAllocate(t *testing.T) {
+	ram := NewPopulatedRam(OneKiloByte, []uint8{
+		0xA9, 0x01, // LDA #$01
+		0x85, 0x20, // STA $20
+		0x65, 0x20, // ADC $20
+		0x48,             // PHA
+		0x68,             // PLA
+		0x20, 0x10, 0x00, // JSR $0010
+		0x4C, 0x00, 0x00, // JMP $0000
+		0xEA, 0xEA, // NOP, NOP
+		0x60, // RTS
+	})
+	cpu, err := NewCpu(newLegalInstructionSet(), &ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu.State = State{SP: 0xFF}
+
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = cpu.Step() }); allocs != 0 {
+		t.Errorf("Step() allocations got = %v, want = 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = cpu.Tick() }); allocs != 0 {
+		t.Errorf("Tick() allocations got = %v, want = 0", allocs)
+	}
+}
@@ -0,0 +1,480 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A CPU definition describes a custom instruction set in JSON so that new CPUs can be
+// created for experiments without writing any Go. The operations and addressing modes
+// are described once and then combined by opcode, in the same way as the static Mnemonic
+// data for the 6502. The functions that implement them are looked up by name in a
+// Registry. For example:
+//
+//	{
+//	  "name": "Tiny",
+//	  "addressingModes": {
+//	    "Imm": {"name": "Immediate", "assembler": "#$%02X", "bytes": 1, "cycles": 1,
+//	            "mode": "Immediate", "func": "Immediate"},
+//	    "Imp": {"name": "Implied", "bytes": 0, "cycles": 0, "mode": "Implied", "func": "Implied"}
+//	  },
+//	  "operations": {
+//	    "LDA": {"name": "Load Accumulator", "assembler": "LDA", "flags": "NZ", "bytes": 1,
+//	            "cycles": 1, "pageBoundaryPenalty": true, "type": "Read", "func": "LoadA"},
+//	    "NOP": {"name": "No Operation", "assembler": "NOP", "bytes": 1, "cycles": 2,
+//	            "type": "Internal", "func": "NoOperation"}
+//	  },
+//	  "opcodes": [
+//	    {"opcode": "$A9", "operation": "LDA", "addressing": "Imm"},
+//	    {"opcode": "$EA", "operation": "NOP", "addressing": "Imp"}
+//	  ]
+//	}
+//
+// The bytes, cycles and penalties have the same meaning as the fields of MnemonicOperation
+// and MnemonicAddressingMode, and each opcode can have a cycleAdjust. The flags are the
+// letters of the affected flags from "NVBDIZC". The mode and type are the names of the
+// AddressingMode and OperationType constants without the Mode and Operation suffixes;
+// if either is omitted the cycle-stepped core executes the instruction in a single cycle.
+// The optional "interrupt", "nmi" and "reset" fields name the operations used for the
+// hardware interrupts and reset sequence.
+
+// Registry holds the named Operation and AddressingFunc functions that a CPU definition
+// can use.
+type Registry struct {
+	Operations      map[string]Operation
+	AddressingFuncs map[string]AddressingFunc
+}
+
+// NewRegistry returns a Registry containing every Operation and AddressingFunc in this
+// package, named as they are in Go. The operations that take a parameter are included once
+// for each bit (such as BranchOnBitReset0 to BranchOnBitReset7) or with the default magic
+// constants. Further functions can be added to the maps before loading a definition.
+func NewRegistry() Registry {
+	registry := Registry{
+		Operations: map[string]Operation{
+			"AddWithCarry":                    AddWithCarry,
+			"AddWithCarryBinary":              AddWithCarryBinary,
+			"AddWithCarryCmos":                AddWithCarryCmos,
+			"AndWithA":                        AndWithA,
+			"AndWithACopyToCarry":             AndWithACopyToCarry,
+			"AndWithARotateRight":             AndWithARotateRight,
+			"AndWithARotateRightBinary":       AndWithARotateRightBinary,
+			"AndWithAShiftRight":              AndWithAShiftRight,
+			"AndXWithMagic":                   AndXWithMagic(DefaultMagicConstants.Ane),
+			"ArithmeticShiftLeft":             ArithmeticShiftLeft,
+			"BranchAlways":                    BranchAlways,
+			"BranchOnCarryClear":              BranchOnCarryClear,
+			"BranchOnCarrySet":                BranchOnCarrySet,
+			"BranchOnEqual":                   BranchOnEqual,
+			"BranchOnMinus":                   BranchOnMinus,
+			"BranchOnNotEqual":                BranchOnNotEqual,
+			"BranchOnOverflowClear":           BranchOnOverflowClear,
+			"BranchOnOverflowSet":             BranchOnOverflowSet,
+			"BranchOnPlus":                    BranchOnPlus,
+			"Break":                           Break,
+			"BreakCmos":                       BreakCmos,
+			"ClearCarry":                      ClearCarry,
+			"ClearDecimal":                    ClearDecimal,
+			"ClearInterrupt":                  ClearInterrupt,
+			"ClearOverflow":                   ClearOverflow,
+			"CompareWithA":                    CompareWithA,
+			"CompareWithX":                    CompareWithX,
+			"CompareWithY":                    CompareWithY,
+			"Decrement":                       Decrement,
+			"DecrementAndCompare":             DecrementAndCompare,
+			"DecrementX":                      DecrementX,
+			"DecrementY":                      DecrementY,
+			"ExclusiveOrWithA":                ExclusiveOrWithA,
+			"Halt":                            Halt,
+			"Increment":                       Increment,
+			"IncrementAndSubtract":            IncrementAndSubtract,
+			"IncrementAndSubtractBinary":      IncrementAndSubtractBinary,
+			"IncrementX":                      IncrementX,
+			"IncrementY":                      IncrementY,
+			"Interrupt":                       Interrupt,
+			"InterruptCmos":                   InterruptCmos,
+			"Jump":                            Jump,
+			"JumpSubRoutine":                  JumpSubRoutine,
+			"LoadA":                           LoadA,
+			"LoadAAndX":                       LoadAAndX,
+			"LoadAAndXWithMagic":              LoadAAndXWithMagic(DefaultMagicConstants.Lxa),
+			"LoadAXAndSPWithSP":               LoadAXAndSPWithSP,
+			"LoadX":                           LoadX,
+			"LoadY":                           LoadY,
+			"LogicalShiftRight":               LogicalShiftRight,
+			"Nmi":                             Nmi,
+			"NmiCmos":                         NmiCmos,
+			"NoOperation":                     NoOperation,
+			"OrWithA":                         OrWithA,
+			"PullA":                           PullA,
+			"PullP":                           PullP,
+			"PullX":                           PullX,
+			"PullY":                           PullY,
+			"PushA":                           PushA,
+			"PushP":                           PushP,
+			"PushX":                           PushX,
+			"PushY":                           PushY,
+			"Reset":                           Reset,
+			"ResetCmos":                       ResetCmos,
+			"ReturnFromInterrupt":             ReturnFromInterrupt,
+			"ReturnFromSubroutine":            ReturnFromSubroutine,
+			"RotateLeft":                      RotateLeft,
+			"RotateLeftAndWithA":              RotateLeftAndWithA,
+			"RotateRight":                     RotateRight,
+			"RotateRightAddWithCarry":         RotateRightAddWithCarry,
+			"RotateRightAddWithCarryBinary":   RotateRightAddWithCarryBinary,
+			"SetCarry":                        SetCarry,
+			"SetDecimal":                      SetDecimal,
+			"SetInterrupt":                    SetInterrupt,
+			"ShiftLeftOrWithA":                ShiftLeftOrWithA,
+			"ShiftRightExclusiveOrWithA":      ShiftRightExclusiveOrWithA,
+			"Stop":                            Stop,
+			"StoreA":                          StoreA,
+			"StoreAAndX":                      StoreAAndX,
+			"StoreAAndXAndHigh":               StoreAAndXAndHigh,
+			"StoreX":                          StoreX,
+			"StoreXAndHigh":                   StoreXAndHigh,
+			"StoreY":                          StoreY,
+			"StoreYAndHigh":                   StoreYAndHigh,
+			"StoreZero":                       StoreZero,
+			"SubtractFromAAndX":               SubtractFromAAndX,
+			"SubtractWithCarry":               SubtractWithCarry,
+			"SubtractWithCarryBinary":         SubtractWithCarryBinary,
+			"SubtractWithCarryCmos":           SubtractWithCarryCmos,
+			"TestAndResetBits":                TestAndResetBits,
+			"TestAndSetBits":                  TestAndSetBits,
+			"TestBitsImmediate":               TestBitsImmediate,
+			"TestBitsInMemoryWithAccumulator": TestBitsInMemoryWithAccumulator,
+			"TransferAAndXToSPAndStore":       TransferAAndXToSPAndStore,
+			"TransferAtoX":                    TransferAtoX,
+			"TransferAtoY":                    TransferAtoY,
+			"TransferSPtoX":                   TransferSPtoX,
+			"TransferXtoA":                    TransferXtoA,
+			"TransferXtoSP":                   TransferXtoSP,
+			"TransferYtoA":                    TransferYtoA,
+			"WaitForInterrupt":                WaitForInterrupt,
+		},
+		AddressingFuncs: map[string]AddressingFunc{
+			"Absolute":          Absolute,
+			"AbsoluteX":         AbsoluteX,
+			"AbsoluteXIndirect": AbsoluteXIndirect,
+			"AbsoluteY":         AbsoluteY,
+			"Accumulator":       Accumulator,
+			"Immediate":         Immediate,
+			"Implied":           Implied,
+			"Indirect":          Indirect,
+			"IndirectCmos":      IndirectCmos,
+			"IndirectX":         IndirectX,
+			"IndirectY":         IndirectY,
+			"Relative":          Relative,
+			"ZeroPage":          ZeroPage,
+			"ZeroPageIndirect":  ZeroPageIndirect,
+			"ZeroPageRelative":  ZeroPageRelative,
+			"ZeroPageX":         ZeroPageX,
+			"ZeroPageY":         ZeroPageY,
+		},
+	}
+
+	for bit := range uint8(8) {
+		registry.Operations[fmt.Sprintf("BranchOnBitReset%d", bit)] = BranchOnBitReset(bit)
+		registry.Operations[fmt.Sprintf("BranchOnBitSet%d", bit)] = BranchOnBitSet(bit)
+		registry.Operations[fmt.Sprintf("ResetMemoryBit%d", bit)] = ResetMemoryBit(bit)
+		registry.Operations[fmt.Sprintf("SetMemoryBit%d", bit)] = SetMemoryBit(bit)
+	}
+
+	return registry
+}
+
+// Definition is a custom CPU loaded by LoadDefinition. The Mnemonics describe the same
+// instructions as the InstructionSet, so they can be used with NewMnemonicDisplayDetails
+// and to disassemble programs for the custom CPU. They are also attached to the
+// InstructionSet, so they remain available through InstructionSet.Mnemonic and
+// Cpu.Mnemonic.
+type Definition struct {
+	Name           string
+	Mnemonics      []Mnemonic // In opcode order, smallest to highest.
+	InstructionSet InstructionSet
+}
+
+// definitionFile is the JSON representation of a Definition.
+type definitionFile struct {
+	Name            string                          `json:"name"`
+	AddressingModes map[string]definitionAddressing `json:"addressingModes"`
+	Operations      map[string]definitionOperation  `json:"operations"`
+	Opcodes         []definitionOpcode              `json:"opcodes"`
+	Interrupt       string                          `json:"interrupt"`
+	Nmi             string                          `json:"nmi"`
+	Reset           string                          `json:"reset"`
+}
+
+type definitionAddressing struct {
+	Name                string `json:"name"`
+	Assembler           string `json:"assembler"`
+	Bytes               uint   `json:"bytes"`
+	Cycles              uint   `json:"cycles"`
+	PageBoundaryPenalty bool   `json:"pageBoundaryPenalty"`
+	Mode                string `json:"mode"`
+	Func                string `json:"func"`
+}
+
+type definitionOperation struct {
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	Assembler           string `json:"assembler"`
+	Flags               string `json:"flags"`
+	Bytes               uint   `json:"bytes"`
+	Cycles              uint   `json:"cycles"`
+	PageBoundaryPenalty bool   `json:"pageBoundaryPenalty"`
+	BranchTakenPenalty  bool   `json:"branchTakenPenalty"`
+	DecimalPenalty      bool   `json:"decimalPenalty"`
+	Type                string `json:"type"`
+	Func                string `json:"func"`
+}
+
+type definitionOpcode struct {
+	Opcode      string `json:"opcode"`
+	Operation   string `json:"operation"`
+	Addressing  string `json:"addressing"`
+	CycleAdjust int    `json:"cycleAdjust"`
+}
+
+// The names of the AddressingMode and OperationType constants used in a definition.
+var (
+	definitionModes = map[string]AddressingMode{
+		"":            UnknownMode,
+		"Absolute":    AbsoluteMode,
+		"AbsoluteX":   AbsoluteXMode,
+		"AbsoluteY":   AbsoluteYMode,
+		"Accumulator": AccumulatorMode,
+		"Immediate":   ImmediateMode,
+		"Implied":     ImpliedMode,
+		"Indirect":    IndirectMode,
+		"IndirectX":   IndirectXMode,
+		"IndirectY":   IndirectYMode,
+		"Relative":    RelativeMode,
+		"ZeroPage":    ZeroPageMode,
+		"ZeroPageX":   ZeroPageXMode,
+		"ZeroPageY":   ZeroPageYMode,
+	}
+	definitionTypes = map[string]OperationType{
+		"":                     UnknownOperation,
+		"Branch":               BranchOperation,
+		"Break":                BreakOperation,
+		"Internal":             InternalOperation,
+		"Jump":                 JumpOperation,
+		"JumpSubroutine":       JumpSubroutineOperation,
+		"Pull":                 PullOperation,
+		"Push":                 PushOperation,
+		"ReadModifyWrite":      ReadModifyWriteOperation,
+		"Read":                 ReadOperation,
+		"ReturnFromInterrupt":  ReturnFromInterruptOperation,
+		"ReturnFromSubroutine": ReturnFromSubroutineOperation,
+		"Write":                WriteOperation,
+	}
+)
+
+// LoadDefinition reads a JSON CPU definition and returns it as a validated Definition,
+// using the functions in the registry. An error wrapping InvalidDefinition is returned if
+// the definition is malformed, contains unknown fields or names, or has duplicate opcodes.
+// InstructionSetEmpty is returned if the definition has no opcodes.
+func LoadDefinition(r io.Reader, registry Registry) (Definition, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var file definitionFile
+	if err := decoder.Decode(&file); err != nil {
+		return Definition{}, fmt.Errorf("%w: %v", InvalidDefinition, err)
+	}
+
+	addressingModes := make(map[string]MnemonicAddressingMode, len(file.AddressingModes))
+	for key, a := range file.AddressingModes {
+		mode, err := a.toMnemonic(registry)
+		if err != nil {
+			return Definition{}, fmt.Errorf("%w: addressing mode %q: %v", InvalidDefinition, key, err)
+		}
+		addressingModes[key] = mode
+	}
+
+	operations := make(map[string]MnemonicOperation, len(file.Operations))
+	for key, o := range file.Operations {
+		operation, err := o.toMnemonic(registry)
+		if err != nil {
+			return Definition{}, fmt.Errorf("%w: operation %q: %v", InvalidDefinition, key, err)
+		}
+		operations[key] = operation
+	}
+
+	mnemonics := make([]Mnemonic, 0, len(file.Opcodes))
+	seen := make(map[Opcode]bool, len(file.Opcodes))
+	for _, o := range file.Opcodes {
+		mnemonic, err := o.toMnemonic(operations, addressingModes)
+		if err != nil {
+			return Definition{}, fmt.Errorf("%w: opcode %q: %w", InvalidDefinition, o.Opcode, err)
+		}
+		if seen[mnemonic.Opcode] {
+			return Definition{}, fmt.Errorf("%w: opcode %q: defined more than once", InvalidDefinition, o.Opcode)
+		}
+		seen[mnemonic.Opcode] = true
+		mnemonics = append(mnemonics, mnemonic)
+	}
+	sort.Slice(mnemonics, func(i, j int) bool { return mnemonics[i].Opcode < mnemonics[j].Opcode })
+
+	interrupt, err := lookupOptionalOperation(registry, file.Interrupt)
+	if err != nil {
+		return Definition{}, fmt.Errorf("%w: interrupt: %v", InvalidDefinition, err)
+	}
+	nmi, err := lookupOptionalOperation(registry, file.Nmi)
+	if err != nil {
+		return Definition{}, fmt.Errorf("%w: nmi: %v", InvalidDefinition, err)
+	}
+	reset, err := lookupOptionalOperation(registry, file.Reset)
+	if err != nil {
+		return Definition{}, fmt.Errorf("%w: reset: %v", InvalidDefinition, err)
+	}
+
+	is, err := NewInstructionSetBuilder().
+		AddMnemonics(mnemonics...).
+		WithInterruptOperations(interrupt, nmi).
+		WithResetOperation(reset).
+		Build()
+	if err != nil {
+		return Definition{}, err
+	}
+
+	return Definition{Name: file.Name, Mnemonics: mnemonics, InstructionSet: is}, nil
+}
+
+// toMnemonic converts the JSON representation of an addressing mode.
+func (a definitionAddressing) toMnemonic(registry Registry) (MnemonicAddressingMode, error) {
+	addressingFunc, ok := registry.AddressingFuncs[a.Func]
+	if !ok || addressingFunc == nil {
+		return MnemonicAddressingMode{}, fmt.Errorf("unknown addressing function %q", a.Func)
+	}
+	mode, ok := definitionModes[a.Mode]
+	if !ok {
+		return MnemonicAddressingMode{}, fmt.Errorf("unknown mode %q", a.Mode)
+	}
+	if verbs := strings.Count(a.Assembler, "%"); a.Bytes > 0 && verbs == 0 || a.Bytes == 0 && verbs > 0 {
+		return MnemonicAddressingMode{}, fmt.Errorf("assembler %q does not match %v bytes", a.Assembler, a.Bytes)
+	}
+
+	return MnemonicAddressingMode{
+		Name:                 a.Name,
+		AssemblyLanguageForm: a.Assembler,
+		Bytes:                a.Bytes,
+		Cycles:               a.Cycles,
+		PageBoundaryPenalty:  a.PageBoundaryPenalty,
+		Mode:                 mode,
+		AddressingFunc:       addressingFunc,
+	}, nil
+}
+
+// toMnemonic converts the JSON representation of an operation.
+func (o definitionOperation) toMnemonic(registry Registry) (MnemonicOperation, error) {
+	operation, ok := registry.Operations[o.Func]
+	if !ok || operation == nil {
+		return MnemonicOperation{}, fmt.Errorf("unknown operation function %q", o.Func)
+	}
+	operationType, ok := definitionTypes[o.Type]
+	if !ok {
+		return MnemonicOperation{}, fmt.Errorf("unknown type %q", o.Type)
+	}
+	flags, err := parseFlags(o.Flags)
+	if err != nil {
+		return MnemonicOperation{}, err
+	}
+	if o.Assembler == "" {
+		return MnemonicOperation{}, fmt.Errorf("no assembler is given")
+	}
+
+	return MnemonicOperation{
+		Name:                 o.Name,
+		Description:          o.Description,
+		AssemblyLanguageForm: o.Assembler,
+		AffectedFlags:        flags,
+		Bytes:                o.Bytes,
+		Cycles:               o.Cycles,
+		PageBoundaryPenalty:  o.PageBoundaryPenalty,
+		BranchTakenPenalty:   o.BranchTakenPenalty,
+		DecimalPenalty:       o.DecimalPenalty,
+		Type:                 operationType,
+		Operation:            operation,
+	}, nil
+}
+
+// toMnemonic combines the operation and addressing mode named by the opcode.
+func (o definitionOpcode) toMnemonic(
+	operations map[string]MnemonicOperation, addressingModes map[string]MnemonicAddressingMode) (Mnemonic, error) {
+
+	// Opcodes are always hexadecimal and can be written as $EA, 0xEA or EA.
+	digits, ok := strings.CutPrefix(o.Opcode, "$")
+	if !ok {
+		digits = strings.TrimPrefix(digits, "0x")
+	}
+	value, err := strconv.ParseUint(digits, 16, 8)
+	if err != nil {
+		return Mnemonic{}, fmt.Errorf("invalid opcode %q for %q: %w", o.Opcode, o.Operation, err)
+	}
+	operation, ok := operations[o.Operation]
+	if !ok {
+		return Mnemonic{}, fmt.Errorf("unknown operation %q", o.Operation)
+	}
+	addressing, ok := addressingModes[o.Addressing]
+	if !ok {
+		return Mnemonic{}, fmt.Errorf("unknown addressing mode %q", o.Addressing)
+	}
+
+	// This mirrors NewInstruction, which removes the cycle used to read the opcode.
+	cycles := int(operation.Cycles)
+	if cycles > 0 {
+		cycles--
+	}
+	if cycles += int(addressing.Cycles) + o.CycleAdjust; cycles < 0 {
+		return Mnemonic{}, fmt.Errorf("the cycle adjustment %v is too large", o.CycleAdjust)
+	}
+
+	return Mnemonic{Opcode: Opcode(value), Operation: operation, Addressing: addressing, CycleAdjust: o.CycleAdjust}, nil
+}
+
+// parseFlags converts the letters of the affected flags, such as "NZC", into Flags.
+func parseFlags(letters string) (Flags, error) {
+	var flags Flags
+	for _, letter := range letters {
+		switch letter {
+		case 'N':
+			flags.Negative = true
+		case 'V':
+			flags.Overflow = true
+		case 'B':
+			flags.Break = true
+		case 'D':
+			flags.Decimal = true
+		case 'I':
+			flags.Interrupt = true
+		case 'Z':
+			flags.Zero = true
+		case 'C':
+			flags.Carry = true
+		default:
+			return Flags{}, fmt.Errorf("unknown flag %q", letter)
+		}
+	}
+	return flags, nil
+}
+
+// lookupOptionalOperation returns the named operation from the registry, or nil if no name
+// is given so that the default operation is used.
+func lookupOptionalOperation(registry Registry, name string) (Operation, error) {
+	if name == "" {
+		return nil, nil
+	}
+	operation, ok := registry.Operations[name]
+	if !ok || operation == nil {
+		return nil, fmt.Errorf("unknown operation function %q", name)
+	}
+	return operation, nil
+}
@@ -0,0 +1,266 @@
+package processor
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// countdownDefinition is a tiny CPU that can count X down to zero and then stop.
+const countdownDefinition = `{
+  "name": "Countdown",
+  "addressingModes": {
+    "Abs": {"name": "Absolute", "assembler": "$%04X", "bytes": 2, "cycles": 3, "mode": "Absolute", "func": "Absolute"},
+    "Imm": {"name": "Immediate", "assembler": "#$%02X", "bytes": 1, "cycles": 1, "mode": "Immediate", "func": "Immediate"},
+    "Imp": {"name": "Implied", "bytes": 0, "cycles": 0, "mode": "Implied", "func": "Implied"},
+    "Rel": {"name": "Relative", "assembler": "$%02X", "bytes": 1, "cycles": 1, "pageBoundaryPenalty": true, "mode": "Relative", "func": "Relative"}
+  },
+  "operations": {
+    "BNE": {"name": "Branch on not equal", "assembler": "BNE", "bytes": 1, "cycles": 1,
+            "pageBoundaryPenalty": true, "branchTakenPenalty": true, "type": "Branch", "func": "BranchOnNotEqual"},
+    "DEX": {"name": "Decrement X", "assembler": "DEX", "flags": "NZ", "bytes": 1, "cycles": 2, "type": "Internal", "func": "DecrementX"},
+    "LDX": {"name": "Load X", "assembler": "LDX", "flags": "NZ", "bytes": 1, "cycles": 1,
+            "pageBoundaryPenalty": true, "type": "Read", "func": "LoadX"},
+    "STP": {"name": "Stop", "assembler": "STP", "bytes": 1, "cycles": 3, "type": "Internal", "func": "Stop"},
+    "STX": {"name": "Store X", "assembler": "STX", "bytes": 1, "cycles": 1, "type": "Write", "func": "StoreX"}
+  },
+  "opcodes": [
+    {"opcode": "$DB", "operation": "STP", "addressing": "Imp"},
+    {"opcode": "0xA2", "operation": "LDX", "addressing": "Imm"},
+    {"opcode": "$CA", "operation": "DEX", "addressing": "Imp"},
+    {"opcode": "D0", "operation": "BNE", "addressing": "Rel"},
+    {"opcode": "$8E", "operation": "STX", "addressing": "Abs"}
+  ],
+  "reset": "ResetCmos"
+}`
+
+func TestLoadDefinition(t *testing.T) {
+	definition, err := LoadDefinition(strings.NewReader(countdownDefinition), NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if definition.Name != "Countdown" {
+		t.Errorf("Name got = %v, want = Countdown", definition.Name)
+	}
+	want := []Opcode{0x8E, 0xA2, 0xCA, 0xD0, 0xDB}
+	if len(definition.Mnemonics) != len(want) {
+		t.Fatalf("Mnemonics got = %v, want = %v", len(definition.Mnemonics), len(want))
+	}
+	for i, opcode := range want {
+		if definition.Mnemonics[i].Opcode != opcode {
+			t.Errorf("Mnemonics[%v] got = $%02X, want = $%02X", i, definition.Mnemonics[i].Opcode, opcode)
+		}
+	}
+
+	// The displayed details must match those of the W65C02S for the same opcodes.
+	for _, mnemonic := range AllW65C02SOpcodes() {
+		got, err := definition.InstructionSet.Mnemonic(mnemonic.Opcode)
+		if err != nil {
+			continue
+		}
+		gotDetails := NewMnemonicDisplayDetails(got)
+		wantDetails := NewMnemonicDisplayDetails(mnemonic)
+		if gotDetails.Assembler != wantDetails.Assembler ||
+			gotDetails.AffectedFlags != wantDetails.AffectedFlags ||
+			gotDetails.Bytes != wantDetails.Bytes ||
+			gotDetails.Cycles != wantDetails.Cycles ||
+			gotDetails.PageBoundaryPenalty != wantDetails.PageBoundaryPenalty ||
+			gotDetails.BranchTakenPenalty != wantDetails.BranchTakenPenalty {
+			t.Errorf("NewMnemonicDisplayDetails($%02X) got = %+v, want = %+v", mnemonic.Opcode, gotDetails, wantDetails)
+		}
+	}
+	if _, err := definition.InstructionSet.Mnemonic(0xEA); !errors.Is(err, OpCodeNotInInstructionSet) {
+		t.Errorf("Mnemonic($EA) error got = %v, want = %v", err, OpCodeNotInInstructionSet)
+	}
+
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	if err := WriteContiguousDataToMemory(&ram, 0x0200, []uint8{
+		0xA2, 0x03, // LDX #$03
+		0xCA,       // loop: DEX
+		0xD0, 0xFD, // BNE loop
+		0x8E, 0x10, 0x00, // STX $0010
+		0xDB, // STP
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteResetVectorToMemory(&ram, 0x0200); err != nil {
+		t.Fatal(err)
+	}
+	ram.Write(0x0010, 0xFF)
+
+	cpu, err := NewCpu(definition.InstructionSet, &ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	cycles, err := cpu.Execute(100)
+	if !errors.Is(err, CpuStopped) {
+		t.Fatalf("Execute() error got = %v, want = %v", err, CpuStopped)
+	}
+	// LDX 2, DEX 2 * 3, BNE 3 * 2 + 2, STX 4 and STP 3.
+	if cycles != 23 {
+		t.Errorf("Execute() cycles got = %v, want = 23", cycles)
+	}
+	if cpu.State.X != 0 || ram.Read(0x0010) != 0 {
+		t.Errorf("State got = %v, RAM $0010 = $%02X", cpu.State, ram.Read(0x0010))
+	}
+}
+
+func TestLoadDefinition_Errors(t *testing.T) {
+	const modes = `"addressingModes": {"Imp": {"name": "Implied", "bytes": 0, "cycles": 0, "func": "Implied"}}`
+	const operations = `"operations": {"NOP": {"name": "No Operation", "assembler": "NOP", "bytes": 1, "cycles": 2, "func": "NoOperation"}}`
+
+	tests := []struct {
+		name       string
+		definition string
+		want       error
+	}{
+		{name: "Not JSON", definition: `NOP`, want: InvalidDefinition},
+		{name: "Unknown field", definition: `{"nmae": "Typo"}`, want: InvalidDefinition},
+		{name: "No opcodes", definition: `{` + modes + `,` + operations + `}`, want: InstructionSetEmpty},
+		{
+			name:       "Unknown addressing function",
+			definition: `{"addressingModes": {"Imp": {"bytes": 0, "func": "Nowhere"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Unknown mode",
+			definition: `{"addressingModes": {"Imp": {"bytes": 0, "mode": "Sideways", "func": "Implied"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Assembler does not match the bytes",
+			definition: `{"addressingModes": {"Abs": {"assembler": "$%04X", "bytes": 0, "func": "Absolute"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Unknown operation function",
+			definition: `{"operations": {"NOP": {"assembler": "NOP", "func": "Nothing"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Unknown type",
+			definition: `{"operations": {"NOP": {"assembler": "NOP", "type": "Sleep", "func": "NoOperation"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Unknown flag",
+			definition: `{"operations": {"NOP": {"assembler": "NOP", "flags": "Q", "func": "NoOperation"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "No assembler",
+			definition: `{"operations": {"NOP": {"func": "NoOperation"}}}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Invalid opcode",
+			definition: `{` + modes + `,` + operations + `, "opcodes": [{"opcode": "$100", "operation": "NOP", "addressing": "Imp"}]}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Unknown operation",
+			definition: `{` + modes + `,` + operations + `, "opcodes": [{"opcode": "$EA", "operation": "NOP2", "addressing": "Imp"}]}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Unknown addressing mode",
+			definition: `{` + modes + `,` + operations + `, "opcodes": [{"opcode": "$EA", "operation": "NOP", "addressing": "Abs"}]}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name:       "Negative cycles",
+			definition: `{` + modes + `,` + operations + `, "opcodes": [{"opcode": "$EA", "operation": "NOP", "addressing": "Imp", "cycleAdjust": -2}]}`,
+			want:       InvalidDefinition,
+		},
+		{
+			name: "Duplicate opcode",
+			definition: `{` + modes + `,` + operations + `, "opcodes": [` +
+				`{"opcode": "$EA", "operation": "NOP", "addressing": "Imp"}, {"opcode": "0xEA", "operation": "NOP", "addressing": "Imp"}]}`,
+			want: InvalidDefinition,
+		},
+		{
+			name:       "Unknown interrupt operation",
+			definition: `{` + modes + `,` + operations + `, "opcodes": [{"opcode": "$EA", "operation": "NOP", "addressing": "Imp"}], "nmi": "Never"}`,
+			want:       InvalidDefinition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadDefinition(strings.NewReader(tt.definition), NewRegistry()); !errors.Is(err, tt.want) {
+				t.Errorf("LoadDefinition() error got = %v, want = %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDefinition_Opcode(t *testing.T) {
+	modes := `"addressingModes": {"Imp": {"bytes": 0, "func": "Implied"}}`
+	operations := `"operations": {"NOP": {"assembler": "NOP", "bytes": 1, "cycles": 2, "func": "NoOperation"}}`
+	tests := []struct {
+		opcode  string
+		want    Opcode
+		wantErr error
+	}{
+		{opcode: "$EA", want: 0xEA},
+		{opcode: "0xEA", want: 0xEA},
+		{opcode: "EA", want: 0xEA},
+		{opcode: "012", want: 0x12}, // Not octal.
+		{opcode: "$100", wantErr: strconv.ErrRange},
+		{opcode: "$0xEA", wantErr: strconv.ErrSyntax},
+		{opcode: "", wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.opcode, func(t *testing.T) {
+			definition, err := LoadDefinition(strings.NewReader(`{`+modes+`,`+operations+`, "opcodes": [`+
+				`{"opcode": "`+tt.opcode+`", "operation": "NOP", "addressing": "Imp"}]}`), NewRegistry())
+			if tt.wantErr != nil {
+				if !errors.Is(err, InvalidDefinition) || !errors.Is(err, tt.wantErr) {
+					t.Fatalf("LoadDefinition() error got = %v, want = %v", err, tt.wantErr)
+				}
+				if want := `invalid opcode "` + tt.opcode + `" for "NOP"`; !strings.Contains(err.Error(), want) {
+					t.Errorf("LoadDefinition() error got = %v, want it to contain %v", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := definition.Mnemonics[0].Opcode; got != tt.want {
+				t.Errorf("opcode got = $%02X, want = $%02X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	// Every function used by the built in instruction sets must be in the registry.
+	mnemonics := append(AllW65C02SOpcodes(), AllUndocumentedOpcodes(DefaultMagicConstants)...)
+	for _, mnemonic := range append(mnemonics, All2A03Opcodes()...) {
+		if !registryHasFunc(registry.Operations, mnemonic.Operation.Operation) {
+			t.Errorf("Operation of $%02X %v is not in the registry", mnemonic.Opcode, mnemonic.Operation.AssemblyLanguageForm)
+		}
+		if !registryHasFunc(registry.AddressingFuncs, mnemonic.Addressing.AddressingFunc) {
+			t.Errorf("AddressingFunc of $%02X %v is not in the registry", mnemonic.Opcode, mnemonic.Addressing.Name)
+		}
+	}
+}
+
+// registryHasFunc returns true if the function is in the registry. The functions are compared
+// by their code pointers, so the operations that take a parameter match any of their entries.
+func registryHasFunc[F any](functions map[string]F, function F) bool {
+	want := reflect.ValueOf(function).Pointer()
+	for _, f := range functions {
+		if reflect.ValueOf(f).Pointer() == want {
+			return true
+		}
+	}
+	return false
+}
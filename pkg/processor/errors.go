@@ -1,16 +1,107 @@
 package processor
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	InstructionSetEmpty       = errors.New("the instruction set is empty")
-	OpCodeNotInInstructionSet = errors.New("the opcode is not present in the instruction set")
+	InstructionSetEmpty         = errors.New("the instruction set is empty")
+	OpCodeNotInInstructionSet   = errors.New("the opcode is not present in the instruction set")
+	MnemonicNotInInstructionSet = errors.New("the opcode has no mnemonic in the instruction set")
 
 	MemoryMustBeProvided      = errors.New("a valid memory must be provided")
+	MemoryFault               = errors.New("the memory access failed")
 	InvalidMemorySizeProvided = errors.New("invalid memory size was provided")
+	InvalidMapping            = errors.New("the mapping is invalid")
+	OverlappingMappings       = errors.New("the mappings overlap")
+	DeviceSizeMismatch        = errors.New("the size of the device does not match its mapping")
+	InvalidBank               = errors.New("the bank does not exist")
 
 	UninitialisedCpu = errors.New("the CPU has not been initialised correctly")
+	CpuWaiting       = errors.New("the CPU is waiting for an interrupt")
+	CpuStopped       = errors.New("the CPU is stopped until it is reset")
+	CpuHalted        = errors.New("the CPU is halted until it is reset")
+	OpcodeTrapped    = errors.New("the opcode is a trap")
+
+	InvalidPowerOnConfig = errors.New("the power on State cannot be given along with a Random source")
 
 	NoAddressingModeFunction = errors.New("the instruction has no addressing mode function")
 	NoOperationFunction      = errors.New("the instruction has no operation function")
+
+	InvalidDefinition = errors.New("the CPU definition is invalid")
+
+	InvalidClockRate     = errors.New("the clock rate must not be negative")
+	RunnerAlreadyStarted = errors.New("the runner has already been started")
+	RunnerNotStarted     = errors.New("the runner has not been started")
+	RunnerFinished       = errors.New("the runner has finished")
+
+	NoProcessors     = errors.New("the scheduler has no processors")
+	UnknownProcessor = errors.New("the processor is not in the scheduler")
 )
+
+// JamError is returned when the Cpu executes a JAM (aka KIL) opcode and halts. It is
+// returned by every subsequent Step, Execute or Tick until the Cpu is reset. It matches
+// CpuHalted using errors.Is.
+type JamError struct {
+	PC     Address // The address of the JAM opcode.
+	Opcode Opcode
+	State  State // The State of the Cpu once it had halted.
+}
+
+func (e JamError) Error() string {
+	return fmt.Sprintf("the CPU halted executing JAM opcode $%02X at $%04X", uint8(e.Opcode), uint16(e.PC))
+}
+
+func (e JamError) Is(target error) bool {
+	return target == CpuHalted
+}
+
+// TrapError is returned when the Cpu executes an opcode that was filled with a trap (see
+// InstructionSetBuilder.FillWithTraps), which usually means the program has gone astray.
+// It matches OpcodeTrapped using errors.Is.
+type TrapError struct {
+	PC     Address // The address of the trapped opcode.
+	Opcode Opcode
+	State  State // The State of the Cpu once the opcode had been read.
+}
+
+func (e TrapError) Error() string {
+	return fmt.Sprintf("the CPU trapped executing opcode $%02X at $%04X", uint8(e.Opcode), uint16(e.PC))
+}
+
+func (e TrapError) Is(target error) bool {
+	return target == OpcodeTrapped
+}
+
+// UnknownOpcodeError is returned when the Cpu reads an opcode that is not in its instruction
+// set. It matches OpCodeNotInInstructionSet using errors.Is.
+type UnknownOpcodeError struct {
+	PC     Address // The address of the opcode.
+	Opcode Opcode
+	State  State // The State of the Cpu once the opcode had been read.
+}
+
+func (e UnknownOpcodeError) Error() string {
+	return fmt.Sprintf("the opcode $%02X at $%04X is not present in the instruction set", uint8(e.Opcode), uint16(e.PC))
+}
+
+func (e UnknownOpcodeError) Is(target error) bool {
+	return target == OpCodeNotInInstructionSet
+}
+
+// MemoryFaultError is returned when a FaultingMemory reports that an access failed. It
+// matches MemoryFault using errors.Is.
+type MemoryFaultError struct {
+	Address Address
+	Access  Access
+	State   State // The State of the Cpu when the fault was returned; set by the Cpu.
+}
+
+func (e MemoryFaultError) Error() string {
+	return fmt.Sprintf("the memory %v of $%04X failed", e.Access, uint16(e.Address))
+}
+
+func (e MemoryFaultError) Is(target error) bool {
+	return target == MemoryFault
+}
@@ -1,12 +1,52 @@
 package processor
 
-import (
-	"fmt"
-	"sort"
-)
+import "fmt"
 
 type Opcode uint8
 
+// AddressingMode identifies the bus activity an AddressingFunc performs on a real 6502. It
+// is used by the cycle-stepped core (see Cpu.Tick) to replay the exact bus sequence of the
+// addressing mode one cycle at a time.
+type AddressingMode uint8
+
+const (
+	UnknownMode AddressingMode = iota
+	AbsoluteMode
+	AbsoluteXMode
+	AbsoluteYMode
+	AccumulatorMode
+	ImmediateMode
+	ImpliedMode
+	IndirectMode
+	IndirectXMode
+	IndirectYMode
+	RelativeMode
+	ZeroPageMode
+	ZeroPageXMode
+	ZeroPageYMode
+)
+
+// OperationType identifies the bus activity an Operation performs on a real 6502. It is
+// used by the cycle-stepped core (see Cpu.Tick) along with the AddressingMode to replay
+// the exact bus sequence of the instruction one cycle at a time.
+type OperationType uint8
+
+const (
+	UnknownOperation OperationType = iota
+	BranchOperation
+	BreakOperation
+	InternalOperation
+	JumpOperation
+	JumpSubroutineOperation
+	PullOperation
+	PushOperation
+	ReadModifyWriteOperation
+	ReadOperation
+	ReturnFromInterruptOperation
+	ReturnFromSubroutineOperation
+	WriteOperation
+)
+
 // Instruction represents a single Cpu instruction. This can be generated from the static data.
 type Instruction struct {
 	Opcode         Opcode
@@ -20,6 +60,21 @@ type Instruction struct {
 	// If true, this operation incurs an additional cycle if the addressing mode indicates a
 	// page boundary has been crossed.
 	PageBoundaryPenalty bool
+
+	// If true, this operation incurs an additional cycle if the Operation reports that a
+	// branch was taken. When taken, the PageBoundaryPenalty is only applied if the branch
+	// also crossed a page boundary.
+	BranchTakenPenalty bool
+
+	// If true, this operation incurs an additional cycle if the decimal flag was set when
+	// the instruction started. This is only used by ADC and SBC on the 65C02.
+	DecimalPenalty bool
+
+	// The bus activity of the addressing mode and operation. These are only required by the
+	// cycle-stepped core; if either is unknown then Cpu.Tick executes the instruction in a
+	// single cycle and idles for the remaining cycles.
+	Mode AddressingMode
+	Type OperationType
 }
 
 type Instructions []Instruction
@@ -35,6 +90,14 @@ func (i Instruction) String() string {
 // executing the instruction operation. also returned are the number
 // of cycles taken to execute the operation.
 func (i Instruction) Execute(state State, memory Memory) (State, uint, error) {
+	var addressing Addressing
+	return i.execute(state, memory, &addressing)
+}
+
+// execute is the same as Execute but the Addressing used by the operation is stored
+// in addressing so the Cpu can act on anything reported by the Operation. The Cpu
+// reuses the same Addressing for every instruction so that it is not allocated.
+func (i *Instruction) execute(state State, memory Memory, addressing *Addressing) (State, uint, error) {
 	if memory == nil {
 		return State{}, 0, MemoryMustBeProvided
 	}
@@ -47,33 +110,92 @@ func (i Instruction) Execute(state State, memory Memory) (State, uint, error) {
 		return State{}, 0, NoOperationFunction
 	}
 
-	addressingState, err := i.AddressingFunc(state, memory)
+	var err error
+	*addressing, err = i.AddressingFunc(state, memory)
+	if err != nil {
+		return State{}, 0, err
+	}
+
+	decimal := state.P&FlagDecimal != 0
+
+	state.PC += addressing.ProgramCounterChange
+
+	state, err = i.Operation(state, addressing)
 	if err != nil {
 		return State{}, 0, err
 	}
 
+	return state, i.cycles(addressing, decimal), nil
+}
+
+// cycles returns the number of cycles the instruction took to execute, including any
+// penalties that were incurred by the addressing mode or the operation. Branches only
+// incur the page boundary penalty when the branch is taken. The decimal flag is the one
+// in effect when the instruction started.
+func (i *Instruction) cycles(addressing *Addressing, decimal bool) uint {
 	cycles := i.Cycles
-	if i.PageBoundaryPenalty && addressingState.PageBoundaryCrossed {
+
+	if i.DecimalPenalty && decimal {
 		cycles++
 	}
-	state.PC += addressingState.ProgramCounterChange
 
-	state, err = i.Operation(state, addressingState)
-	if err != nil {
-		return State{}, 0, err
+	if i.BranchTakenPenalty {
+		if addressing.BranchTaken {
+			cycles++
+			if i.PageBoundaryPenalty && addressing.PageBoundaryCrossed {
+				cycles++
+			}
+		}
+		return cycles
 	}
 
-	return state, cycles, nil
+	if i.PageBoundaryPenalty && addressing.PageBoundaryCrossed {
+		cycles++
+	}
+	return cycles
 }
 
-// InstructionSet is a straight forward map of opcodes to Instruction instances.
+// InstructionSet is a fixed table of Instruction instances indexed by opcode. It also
+// holds the operations used by the Cpu for hardware interrupts and reset; if these are nil
+// then the NMOS 6502 Interrupt, Nmi and Reset operations are used.
 type InstructionSet struct {
-	instructions map[Opcode]Instruction
+	instructions *instructionTable
+	interrupt    Operation
+	nmi          Operation
+	reset        Operation
+}
+
+// instructionTable is the dispatch table of an InstructionSet along with the Mnemonic
+// that describes each instruction, if known. It is never changed once it has been built,
+// so it is shared by all copies of the InstructionSet.
+type instructionTable struct {
+	instructions [0x100]Instruction
+	defined      [0x100]bool
+	count        int
+	mnemonics    [0x100]Mnemonic
+	described    [0x100]bool
+}
+
+// newInstructionTable returns a dispatch table containing the instructions. Only the
+// mnemonics of opcodes that have an instruction are kept.
+func newInstructionTable(instructions map[Opcode]Instruction, mnemonics map[Opcode]Mnemonic) *instructionTable {
+	table := &instructionTable{count: len(instructions)}
+	for opcode, instruction := range instructions {
+		table.instructions[opcode] = instruction
+		table.defined[opcode] = true
+	}
+	for opcode, mnemonic := range mnemonics {
+		if table.defined[opcode] {
+			table.mnemonics[opcode] = mnemonic
+			table.described[opcode] = true
+		}
+	}
+	return table
 }
 
 // validate returns an error if the instruction set is empty.
 func (is InstructionSet) validate() error {
-	if len(is.instructions) <= 0 {
+	if is.instructions == nil || is.instructions.count <= 0 {
 		return InstructionSetEmpty
 	}
 	return nil
@@ -86,62 +208,125 @@ func (is InstructionSet) Get(opcode Opcode) (Instruction, error) {
 		return Instruction{Opcode: opcode}, err
 	}
 
-	if val, ok := is.instructions[opcode]; ok {
-		return val, nil
+	if instruction := is.lookup(opcode); instruction != nil {
+		return *instruction, nil
 	}
 
 	return Instruction{Opcode: opcode}, OpCodeNotInInstructionSet
 }
 
-// Fill ensures that every possible opcode has a valid instruction by filling any opcode
-// gaps with the passed in instruction. The Opcode value of the Instruction pass in is
-// ignored and replaced with the actual Opcode whose place it takes.
-func (is InstructionSet) Fill(instruction Instruction) error {
+// lookup returns the Instruction represented by the opcode without copying it, or nil
+// if the opcode does not exist in the InstructionSet. The Instruction must not be changed.
+func (is InstructionSet) lookup(opcode Opcode) *Instruction {
+	if is.instructions == nil || !is.instructions.defined[opcode] {
+		return nil
+	}
+	return &is.instructions.instructions[opcode]
+}
 
+// Mnemonic returns the Mnemonic that describes the instruction represented by the opcode,
+// which can be used to disassemble or document the instruction. An error is returned if the
+// opcode does not exist in the InstructionSet or if its instruction was added without a
+// Mnemonic (see InstructionSetBuilder).
+func (is InstructionSet) Mnemonic(opcode Opcode) (Mnemonic, error) {
 	if err := is.validate(); err != nil {
-		return err
+		return Mnemonic{}, err
+	}
+	if !is.instructions.defined[opcode] {
+		return Mnemonic{}, OpCodeNotInInstructionSet
+	}
+	if !is.instructions.described[opcode] {
+		return Mnemonic{}, MnemonicNotInInstructionSet
 	}
+	return is.instructions.mnemonics[opcode], nil
+}
 
-	for opcode := range 255 {
-		if _, ok := is.instructions[Opcode(opcode)]; !ok {
-			instruction.Opcode = Opcode(opcode)
-			is.instructions[Opcode(opcode)] = instruction
+// Mnemonics returns the Mnemonic of every instruction in the InstructionSet that has one,
+// in opcode order, smallest to highest.
+func (is InstructionSet) Mnemonics() []Mnemonic {
+	mnemonics := make([]Mnemonic, 0, 0x100)
+	if is.instructions == nil {
+		return mnemonics
+	}
+	for opcode, described := range is.instructions.described {
+		if described {
+			mnemonics = append(mnemonics, is.instructions.mnemonics[opcode])
 		}
 	}
-
-	return nil
+	return mnemonics
 }
 
-// Opcodes returns a sorted slice of all the opcodes in the instruction set.
-func (is InstructionSet) Opcodes() []Opcode {
+// Fill returns a copy of the InstructionSet in which every possible opcode has a valid
+// instruction, by filling any opcode gaps with the passed in instruction. The Opcode value
+// of the Instruction passed in is ignored and replaced with the actual Opcode whose place
+// it takes. See InstructionSetBuilder for other ways of filling the gaps.
+func (is InstructionSet) Fill(instruction Instruction) (InstructionSet, error) {
 
-	opcodes := make([]Opcode, 0, len(is.instructions))
-	for _, instruction := range is.instructions {
-		opcodes = append(opcodes, instruction.Opcode)
+	if err := is.validate(); err != nil {
+		return InstructionSet{}, err
 	}
 
-	sort.Slice(opcodes, func(i, j int) bool {
-		return opcodes[i] < opcodes[j]
-	})
+	return is.Builder().Fill(instruction).Build()
+}
 
-	return opcodes
+// WithInterruptOperations returns a copy of the InstructionSet that uses the passed in
+// operations for hardware interrupts (IRQ) and non-maskable interrupts (NMI).
+func (is InstructionSet) WithInterruptOperations(interrupt, nmi Operation) InstructionSet {
+	is.interrupt = interrupt
+	is.nmi = nmi
+	return is
 }
 
-// NewInstructionSet returns a correctly initialised InstructionSet based on the
-// passed in slice of Instructions.
-func NewInstructionSet(is Instructions) (InstructionSet, error) {
+// WithResetOperation returns a copy of the InstructionSet that uses the passed in
+// operation for the reset sequence.
+func (is InstructionSet) WithResetOperation(reset Operation) InstructionSet {
+	is.reset = reset
+	return is
+}
 
-	instructions := make(map[Opcode]Instruction, len(is))
-	for _, instruction := range is {
-		instructions[instruction.Opcode] = instruction
+// interruptOperation returns the operation used for a hardware interrupt.
+func (is InstructionSet) interruptOperation() Operation {
+	if is.interrupt == nil {
+		return Interrupt
 	}
+	return is.interrupt
+}
 
-	result := InstructionSet{
-		instructions: instructions,
+// nmiOperation returns the operation used for a non-maskable interrupt.
+func (is InstructionSet) nmiOperation() Operation {
+	if is.nmi == nil {
+		return Nmi
 	}
-	if err := result.validate(); err != nil {
-		return InstructionSet{}, err
+	return is.nmi
+}
+
+// resetOperation returns the operation used for the reset sequence.
+func (is InstructionSet) resetOperation() Operation {
+	if is.reset == nil {
+		return Reset
+	}
+	return is.reset
+}
+
+// Opcodes returns a sorted slice of all the opcodes in the instruction set.
+func (is InstructionSet) Opcodes() []Opcode {
+	if is.instructions == nil {
+		return []Opcode{}
 	}
 
-	return result, nil
+	opcodes := make([]Opcode, 0, is.instructions.count)
+	for opcode, defined := range is.instructions.defined {
+		if defined {
+			opcodes = append(opcodes, Opcode(opcode))
+		}
+	}
+
+	return opcodes
+}
+
+// NewInstructionSet returns a correctly initialised InstructionSet based on the
+// passed in slice of Instructions. Use InstructionSetBuilder to compose an
+// InstructionSet from several sources.
+func NewInstructionSet(is Instructions) (InstructionSet, error) {
+	return NewInstructionSetBuilder().Add(is...).Build()
 }
@@ -38,7 +38,7 @@ func NewTestInstructionSet() InstructionSet {
 		}, nil
 	}
 
-	testFunc1 := func(state State, _ Addressing) (State, error) {
+	testFunc1 := func(state State, _ *Addressing) (State, error) {
 		state.A = 0x01
 		state.X = 0x0F
 		state.Y = 0xF0
@@ -46,7 +46,7 @@ func NewTestInstructionSet() InstructionSet {
 		return state, nil
 	}
 
-	testFunc2 := func(state State, addressing Addressing) (State, error) {
+	testFunc2 := func(state State, addressing *Addressing) (State, error) {
 		state.A = 0x10
 		state.X = uint8(addressing.EffectiveAddress & 0x00FF)
 		state.Y = uint8(addressing.EffectiveAddress >> 8)
@@ -55,7 +55,7 @@ func NewTestInstructionSet() InstructionSet {
 	}
 
 	// Reads the next 2 byes and places them in X, Y and RAM 0x0 and 0x1
-	testFunc3 := func(state State, as Addressing) (State, error) {
+	testFunc3 := func(state State, as *Addressing) (State, error) {
 		state.X = as.Memory.Read(state.PC)
 		state.PC++
 		state.Y = as.Memory.Read(state.PC)
@@ -220,6 +220,15 @@ func TestInstruction_Execute(t *testing.T) {
 	noOperation := nop
 	noOperation.Operation = nil
 
+	branch := Instruction{
+		Opcode:              0xD0,
+		AddressingFunc:      Relative,
+		Operation:           BranchOnNotEqual,
+		Cycles:              1,
+		PageBoundaryPenalty: true,
+		BranchTakenPenalty:  true,
+	}
+
 	tests := []struct {
 		name        string
 		instruction Instruction
@@ -299,6 +308,51 @@ func TestInstruction_Execute(t *testing.T) {
 			wantRam:     []uint8{0, 0, 0, 0, 0, 0, 0, 0},
 			wantCycles:  5,
 		},
+		{
+			name:        "Branch not taken incurs no penalty",
+			instruction: branch,
+			state:       State{PC: 0x1, P: FlagZero},
+			startRam:    []uint8{0, 0x04, 0, 0, 0, 0, 0, 0},
+			wantState:   State{PC: 0x2, P: FlagZero},
+			wantRam:     []uint8{0, 0x04, 0, 0, 0, 0, 0, 0},
+			wantCycles:  1,
+		},
+		{
+			name:        "Branch taken incurs a single cycle penalty",
+			instruction: branch,
+			state:       State{PC: 0x1},
+			startRam:    []uint8{0, 0x04, 0, 0, 0, 0, 0, 0},
+			wantState:   State{PC: 0x6},
+			wantRam:     []uint8{0, 0x04, 0, 0, 0, 0, 0, 0},
+			wantCycles:  2,
+		},
+		{
+			name:        "Branch taken to the next instruction still incurs a single cycle penalty",
+			instruction: branch,
+			state:       State{PC: 0x1},
+			startRam:    []uint8{0, 0, 0, 0, 0, 0, 0, 0},
+			wantState:   State{PC: 0x2},
+			wantRam:     []uint8{0, 0, 0, 0, 0, 0, 0, 0},
+			wantCycles:  2,
+		},
+		{
+			name:        "Branch taken across a page boundary incurs a two cycle penalty",
+			instruction: branch,
+			state:       State{PC: 0xF9},
+			startRam:    []uint8{0, 0x10, 0, 0, 0, 0, 0, 0},
+			wantState:   State{PC: 0x010A},
+			wantRam:     []uint8{0, 0x10, 0, 0, 0, 0, 0, 0},
+			wantCycles:  3,
+		},
+		{
+			name:        "Branch not taken across a page boundary incurs no penalty",
+			instruction: branch,
+			state:       State{PC: 0xF9, P: FlagZero},
+			startRam:    []uint8{0, 0x10, 0, 0, 0, 0, 0, 0},
+			wantState:   State{PC: 0xFA, P: FlagZero},
+			wantRam:     []uint8{0, 0x10, 0, 0, 0, 0, 0, 0},
+			wantCycles:  1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -346,9 +400,9 @@ func TestInstructionSet_validate(t *testing.T) {
 		{
 			name: "Valid InstructionSet wont error",
 			is: InstructionSet{
-				instructions: map[Opcode]Instruction{
+				instructions: newInstructionTable(map[Opcode]Instruction{
 					0: {},
-				},
+				}, nil),
 			},
 		},
 	}
@@ -363,7 +417,7 @@ func TestInstructionSet_validate(t *testing.T) {
 
 func TestInstructionSet_Get(t *testing.T) {
 	instructionSet := InstructionSet{
-		instructions: map[Opcode]Instruction{
+		instructions: newInstructionTable(map[Opcode]Instruction{
 			0x00: {
 				Opcode: 0x00,
 				Cycles: 1,
@@ -378,7 +432,7 @@ func TestInstructionSet_Get(t *testing.T) {
 				Cycles:              3,
 				PageBoundaryPenalty: true,
 			},
-		},
+		}, nil),
 	}
 	tests := []struct {
 		name    string
@@ -407,13 +461,13 @@ func TestInstructionSet_Get(t *testing.T) {
 			name:   "Valid instruction code should return correct instruction 1",
 			is:     instructionSet,
 			opcode: 0x00,
-			want:   instructionSet.instructions[0x00],
+			want:   instructionSet.instructions.instructions[0x00],
 		},
 		{
 			name:   "Valid instruction code should return correct instruction 2",
 			is:     instructionSet,
 			opcode: 0xFF,
-			want:   instructionSet.instructions[0xFF],
+			want:   instructionSet.instructions.instructions[0xFF],
 		},
 	}
 	for _, tt := range tests {
@@ -433,7 +487,7 @@ func TestInstructionSet_Get(t *testing.T) {
 
 func TestInstructionSet_Fill(t *testing.T) {
 	instructionSet := InstructionSet{
-		instructions: map[Opcode]Instruction{
+		instructions: newInstructionTable(map[Opcode]Instruction{
 			0x00: {
 				Opcode: 0x00,
 				Cycles: 1,
@@ -448,7 +502,7 @@ func TestInstructionSet_Fill(t *testing.T) {
 				Cycles:              3,
 				PageBoundaryPenalty: true,
 			},
-		},
+		}, nil),
 	}
 	tests := []struct {
 		name    string
@@ -476,7 +530,7 @@ func TestInstructionSet_Fill(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.is.Fill(tt.fill)
+			got, err := tt.is.Fill(tt.fill)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Fill() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -485,20 +539,23 @@ func TestInstructionSet_Fill(t *testing.T) {
 				return
 			}
 
-			if len(tt.is.instructions) != 256 {
-				t.Errorf("Fill() did not result in a full instruction set. have = %v", len(tt.is.instructions))
+			if got.instructions.count != 256 {
+				t.Errorf("Fill() did not result in a full instruction set. have = %v", got.instructions.count)
+			}
+			if tt.is.instructions.count != 3 {
+				t.Errorf("Fill() changed the original instruction set. have = %v", tt.is.instructions.count)
 			}
 
 			// Loop through every instruction and ensure that they are as expected.
-			for opcode := range 255 {
-				instruction := tt.is.instructions[Opcode(opcode)]
+			for opcode := range 256 {
+				instruction := got.instructions.instructions[opcode]
 				if instruction.Opcode != Opcode(opcode) {
 					t.Errorf("Fill() unexpected opcode; got = %02X, want %02X", instruction.Opcode, opcode)
 				}
 
 				// No need to test the originally provided opcodes.
 				if opcode == 0x00 || opcode == 0xF0 || opcode == 0xFF {
-					return
+					continue
 				}
 
 				if instruction.Cycles != tt.fill.Cycles {
@@ -584,18 +641,18 @@ func TestNewInstructionSet(t *testing.T) {
 			name: "Single instruction is fine 1",
 			is:   Instructions{Instruction{}},
 			want: InstructionSet{
-				instructions: map[Opcode]Instruction{
+				instructions: newInstructionTable(map[Opcode]Instruction{
 					0x00: {},
-				},
+				}, nil),
 			},
 		},
 		{
 			name: "Single instruction is fine 2",
 			is:   Instructions{Instruction{Opcode: 0xF0, Cycles: 3}},
 			want: InstructionSet{
-				instructions: map[Opcode]Instruction{
+				instructions: newInstructionTable(map[Opcode]Instruction{
 					0xF0: {Opcode: 0xF0, Cycles: 3},
-				},
+				}, nil),
 			},
 		},
 		{
@@ -606,11 +663,11 @@ func TestNewInstructionSet(t *testing.T) {
 				Instruction{Opcode: 0xFF, Cycles: 1},
 			},
 			want: InstructionSet{
-				instructions: map[Opcode]Instruction{
+				instructions: newInstructionTable(map[Opcode]Instruction{
 					0x00: {Opcode: 0x00, Cycles: 3},
 					0xF0: {Opcode: 0xF0, Cycles: 7, PageBoundaryPenalty: true},
 					0xFF: {Opcode: 0xFF, Cycles: 1},
-				},
+				}, nil),
 			},
 		},
 	}
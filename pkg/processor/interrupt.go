@@ -0,0 +1,134 @@
+package processor
+
+// The IRQ and NMI inputs are modelled as lines that are shared by any number of devices.
+// As on a real 6502 the lines are sampled in every cycle; IRQ is level sensitive and NMI
+// is edge triggered. The result of the sampling is polled during each instruction and if
+// an interrupt is pending then the seven cycle interrupt sequence is performed instead of
+// the next instruction. Details from https://www.nesdev.org/wiki/CPU_interrupts
+
+// interruptCycles is the number of cycles taken to service an IRQ or NMI.
+const interruptCycles = 7
+
+// InterruptSource identifies the devices driving an interrupt line. Each device sharing
+// a line should use a different bit so that the line remains asserted until every device
+// has released it.
+type InterruptSource uint32
+
+// interruptState records the interrupt lines and the result of sampling them.
+type interruptState struct {
+	irqSources InterruptSource // The devices currently asserting the IRQ line.
+	nmiSources InterruptSource // The devices currently asserting the NMI line.
+
+	irq     bool // The IRQ line was asserted when it was last sampled.
+	nmi     bool // The NMI line was asserted when it was last sampled.
+	nmiEdge bool // An NMI edge has been detected and the NMI has not been serviced.
+	pending bool // An interrupt is serviced in place of the next instruction.
+}
+
+// SetIrqLine asserts or releases the IRQ line on behalf of the source. The line is level
+// sensitive; it is asserted while any source asserts it and an IRQ is serviced after the
+// instruction that samples it, unless the interrupt disable flag is set. A device should
+// release the line once the interrupt has been acknowledged.
+func (c *Cpu) SetIrqLine(source InterruptSource, asserted bool) error {
+	if c == nil {
+		return UninitialisedCpu
+	}
+	c.interrupts.irqSources = setInterruptSource(c.interrupts.irqSources, source, asserted)
+	return nil
+}
+
+// SetNmiLine asserts or releases the NMI line on behalf of the source. The line is edge
+// triggered; an NMI is serviced once each time the line changes from released to asserted.
+func (c *Cpu) SetNmiLine(source InterruptSource, asserted bool) error {
+	if c == nil {
+		return UninitialisedCpu
+	}
+	c.interrupts.nmiSources = setInterruptSource(c.interrupts.nmiSources, source, asserted)
+	return nil
+}
+
+// setInterruptSource returns the sources driving a line once the source has changed.
+func setInterruptSource(sources InterruptSource, source InterruptSource, asserted bool) InterruptSource {
+	if asserted {
+		return sources | source
+	}
+	return sources &^ source
+}
+
+// sampleInterrupts samples the interrupt lines. This happens once in every cycle.
+func (c *Cpu) sampleInterrupts() {
+	i := &c.interrupts
+	nmi := i.nmiSources != 0
+	if nmi && !i.nmi {
+		i.nmiEdge = true
+	}
+	i.nmi = nmi
+	i.irq = i.irqSources != 0
+}
+
+// pollInterrupts decides whether an interrupt is serviced after the instruction that has
+// just completed, given the interrupt lines as they were polled during the instruction and
+// the interrupt disable flag before the instruction. As the flag is only changed by CLI,
+// SEI and PLP after the lines are polled, their effect is delayed by an instruction. RTI
+// restores the flag before the lines are polled. Interrupts are not polled during BRK.
+func (c *Cpu) pollInterrupts(instruction *Instruction, nmi, irq, disabled bool) {
+	if c.runState != Running || instruction.Type == BreakOperation {
+		c.interrupts.pending = false
+		return
+	}
+	if instruction.Type == ReturnFromInterruptOperation {
+		disabled = c.State.P&FlagInterrupt != 0
+	}
+	c.interrupts.pending = nmi || (irq && !disabled)
+}
+
+// wake resumes a Cpu that is Waiting if the IRQ line is asserted or an NMI edge has been
+// detected. The IRQ is only serviced if the interrupt disable flag is clear, otherwise
+// execution continues with the instruction following the WAI.
+func (c *Cpu) wake() bool {
+	i := &c.interrupts
+	if !i.irq && !i.nmiEdge {
+		return false
+	}
+	c.runState = Running
+	i.pending = i.nmiEdge || c.State.P&FlagInterrupt == 0
+	return true
+}
+
+// serviceInterrupt performs the interrupt sequence in place of an instruction. An NMI
+// takes priority over an IRQ; an NMI detected after an IRQ was polled hijacks the IRQ.
+func (c *Cpu) serviceInterrupt() (uint, error) {
+	c.interrupts.pending = false
+
+	operation, count := c.instructionSet.interruptOperation(), &c.counters.Irqs
+	if c.interrupts.nmiEdge {
+		c.interrupts.nmiEdge = false
+		operation, count = c.instructionSet.nmiOperation(), &c.counters.Nmis
+	}
+
+	state, err := operation(c.State, c.newAddressing(Addressing{Memory: &c.bus}))
+	if err != nil {
+		return interruptCycles, err
+	}
+	c.State = state
+	*count++
+	return interruptCycles, nil
+}
+
+// execute executes the instruction with the addressing mode and operation called directly.
+// An NMI detected before BRK pushes the status hijacks the BRK, which then continues using
+// the NMI vector. The ValueAccess of the addressing mode is skipped for an instruction that
+// only writes to the effective address or jumps to it, as there is no such read on a real 6502.
+func (c *Cpu) execute(instruction *Instruction) (State, uint, error) {
+	if instruction.Type == BreakOperation && c.interrupts.nmiEdge {
+		c.interrupts.nmiEdge = false
+		c.bus.hijacked = true
+	}
+	switch instruction.Type {
+	case WriteOperation, JumpOperation, JumpSubroutineOperation:
+		c.bus.writeOnly = true
+	}
+	state, cycles, err := instruction.execute(c.State, &c.bus, &c.addressing)
+	c.bus.hijacked, c.bus.writeOnly = false, false
+	return state, cycles, err
+}
@@ -0,0 +1,485 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	testNmiVector Address = 0x3000
+	testIrqVector Address = 0x4000
+)
+
+// newInterruptCpu returns a Cpu using the instruction set with the program at the start
+// state's PC. All other memory is filled with NOP and the NMI and IRQ vectors point at
+// testNmiVector and testIrqVector.
+func newInterruptCpu(is InstructionSet, state State, program []uint8, ram map[Address]uint8) (*Cpu, *traceMemory) {
+	memory := &traceMemory{}
+	for i := range memory.ram {
+		memory.ram[i] = 0xEA
+	}
+	copy(memory.ram[state.PC:], program)
+	for address, value := range ram {
+		memory.ram[address] = value
+	}
+	memory.ram[0xFFFA], memory.ram[0xFFFB] = 0x00, 0x30 // testNmiVector
+	memory.ram[0xFFFE], memory.ram[0xFFFF] = 0x00, 0x40 // testIrqVector
+
+	cpu, err := NewCpu(is, memory)
+	if err != nil {
+		panic(err)
+	}
+	cpu.State = state
+	return &cpu, memory
+}
+
+func TestCpu_InterruptLines(t *testing.T) {
+	const (
+		brk = 0x00
+		cli = 0x58
+		nop = 0xEA
+		plp = 0x28
+		rti = 0x40
+		sei = 0x78
+	)
+
+	tests := []struct {
+		name       string
+		state      State
+		program    []uint8
+		ram        map[Address]uint8
+		irq        bool
+		nmi        bool
+		wantCycles []uint
+		wantPC     Address
+		wantRam    map[Address]uint8
+	}{
+		{
+			name:       "IRQ is serviced after the current instruction",
+			state:      State{PC: 0x0200, SP: 0xFD},
+			program:    []uint8{nop},
+			irq:        true,
+			wantCycles: []uint{2, 7},
+			wantPC:     testIrqVector,
+			wantRam:    map[Address]uint8{0x01FD: 0x02, 0x01FC: 0x01, 0x01FB: FlagConstant},
+		},
+		{
+			name:       "IRQ is ignored while interrupts are disabled",
+			state:      State{PC: 0x0200, SP: 0xFD, P: FlagInterrupt},
+			irq:        true,
+			wantCycles: []uint{2, 2, 2},
+			wantPC:     0x0203,
+		},
+		{
+			name:       "CLI delays the IRQ by one instruction",
+			state:      State{PC: 0x0200, SP: 0xFD, P: FlagInterrupt},
+			program:    []uint8{cli, nop},
+			irq:        true,
+			wantCycles: []uint{2, 2, 7},
+			wantPC:     testIrqVector,
+			wantRam:    map[Address]uint8{0x01FD: 0x02, 0x01FC: 0x02},
+		},
+		{
+			name:       "IRQ is serviced after SEI",
+			state:      State{PC: 0x0200, SP: 0xFD},
+			program:    []uint8{sei, nop},
+			irq:        true,
+			wantCycles: []uint{2, 7},
+			wantPC:     testIrqVector,
+			wantRam:    map[Address]uint8{0x01FD: 0x02, 0x01FC: 0x01, 0x01FB: FlagConstant | FlagInterrupt},
+		},
+		{
+			name:       "PLP delays the IRQ by one instruction",
+			state:      State{PC: 0x0200, SP: 0xFC, P: FlagInterrupt},
+			program:    []uint8{plp, nop},
+			ram:        map[Address]uint8{0x01FD: FlagConstant},
+			irq:        true,
+			wantCycles: []uint{4, 2, 7},
+			wantPC:     testIrqVector,
+		},
+		{
+			name:       "RTI does not delay the IRQ",
+			state:      State{PC: 0x0200, SP: 0xF0, P: FlagInterrupt},
+			program:    []uint8{rti},
+			ram:        map[Address]uint8{0x01F1: FlagConstant, 0x01F2: 0x00, 0x01F3: 0x05},
+			irq:        true,
+			wantCycles: []uint{6, 7},
+			wantPC:     testIrqVector,
+			wantRam:    map[Address]uint8{0x01F3: 0x05, 0x01F2: 0x00},
+		},
+		{
+			name:       "NMI is serviced while interrupts are disabled",
+			state:      State{PC: 0x0200, SP: 0xFD, P: FlagInterrupt},
+			nmi:        true,
+			wantCycles: []uint{2, 7},
+			wantPC:     testNmiVector,
+		},
+		{
+			name:       "NMI takes priority over IRQ",
+			state:      State{PC: 0x0200, SP: 0xFD},
+			irq:        true,
+			nmi:        true,
+			wantCycles: []uint{2, 7, 2},
+			wantPC:     testNmiVector + 1,
+		},
+		{
+			name:       "NMI hijacks BRK",
+			state:      State{PC: 0x0200, SP: 0xFD},
+			program:    []uint8{brk},
+			nmi:        true,
+			wantCycles: []uint{7, 2},
+			wantPC:     testNmiVector + 1,
+			wantRam:    map[Address]uint8{0x01FD: 0x02, 0x01FC: 0x02, 0x01FB: FlagConstant | FlagBreak},
+		},
+		{
+			name:       "Interrupts are not serviced after BRK",
+			state:      State{PC: 0x0200, SP: 0xFD},
+			program:    []uint8{brk},
+			irq:        true,
+			wantCycles: []uint{7, 2, 2},
+			wantPC:     testIrqVector + 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory := newInterruptCpu(newLegalInstructionSet(), tt.state, tt.program, tt.ram)
+			if err := cpu.SetIrqLine(1, tt.irq); err != nil {
+				t.Fatal(err)
+			}
+			if err := cpu.SetNmiLine(1, tt.nmi); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, want := range tt.wantCycles {
+				cycles, err := cpu.Step()
+				if err != nil {
+					t.Fatalf("Step() %v unexpected error = %v", i+1, err)
+				}
+				if cycles != want {
+					t.Errorf("Step() %v cycles got = %v, want = %v", i+1, cycles, want)
+				}
+			}
+			if cpu.State.PC != tt.wantPC {
+				t.Errorf("PC got = $%04X, want = $%04X", cpu.State.PC, tt.wantPC)
+			}
+			for address, want := range tt.wantRam {
+				if got := memory.ram[address]; got != want {
+					t.Errorf("RAM $%04X got = $%02X, want = $%02X", address, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCpu_NmiLineIsEdgeTriggered(t *testing.T) {
+	cpu, _ := newInterruptCpu(newLegalInstructionSet(), State{PC: 0x0200, SP: 0xFD}, nil, nil)
+
+	step := func(want Address) {
+		t.Helper()
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+		if cpu.State.PC != want {
+			t.Errorf("PC got = $%04X, want = $%04X", cpu.State.PC, want)
+		}
+	}
+
+	_ = cpu.SetNmiLine(1, true)
+	step(0x0201)
+	step(testNmiVector)
+
+	// The line is still asserted but there is no new edge.
+	step(testNmiVector + 1)
+	step(testNmiVector + 2)
+
+	// A second source asserting the line does not create an edge either.
+	_ = cpu.SetNmiLine(2, true)
+	_ = cpu.SetNmiLine(1, false)
+	step(testNmiVector + 3)
+	step(testNmiVector + 4)
+
+	_ = cpu.SetNmiLine(2, false)
+	step(testNmiVector + 5)
+	_ = cpu.SetNmiLine(1, true)
+	step(testNmiVector + 6)
+	step(testNmiVector)
+}
+
+func TestCpu_IrqLineSources(t *testing.T) {
+	const (
+		timer  InterruptSource = 1 << 0
+		serial InterruptSource = 1 << 1
+	)
+
+	// The handler acknowledges the interrupt with an RTI, leaving the sources asserted.
+	cpu, _ := newInterruptCpu(newLegalInstructionSet(), State{PC: 0x0200, SP: 0xFD}, nil, map[Address]uint8{testIrqVector: 0x40})
+
+	_ = cpu.SetIrqLine(timer, true)
+	_ = cpu.SetIrqLine(serial, true)
+	for range 2 {
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cpu.State.PC != testIrqVector {
+		t.Fatalf("PC got = $%04X, want = $%04X", cpu.State.PC, testIrqVector)
+	}
+
+	// Releasing one source leaves the line asserted so the IRQ is serviced again after the RTI.
+	_ = cpu.SetIrqLine(timer, false)
+	for range 2 {
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cpu.State.PC != testIrqVector {
+		t.Errorf("PC got = $%04X, want = $%04X", cpu.State.PC, testIrqVector)
+	}
+
+	// Once every source is released the program continues after the RTI.
+	_ = cpu.SetIrqLine(serial, false)
+	for range 3 {
+		if _, err := cpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cpu.State.PC != 0x0203 {
+		t.Errorf("PC got = $%04X, want = $%04X", cpu.State.PC, 0x0203)
+	}
+}
+
+func TestCpu_InterruptLinesTick(t *testing.T) {
+	const (
+		bne = 0xD0
+		brk = 0x00
+	)
+
+	tests := []struct {
+		name      string
+		state     State
+		program   []uint8
+		nmi       bool
+		assert    int // The line is asserted before this cycle.
+		wantTicks int // The cycle the interrupt sequence completes.
+		wantPC    Address
+	}{
+		{
+			name:      "IRQ asserted in the first cycle of NOP is serviced after it",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			assert:    1,
+			wantTicks: 2 + 7,
+			wantPC:    testIrqVector,
+		},
+		{
+			name:      "IRQ asserted in the last cycle of NOP is serviced after the next instruction",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			assert:    2,
+			wantTicks: 2 + 2 + 7,
+			wantPC:    testIrqVector,
+		},
+		{
+			name:      "IRQ asserted in the first cycle of a branch not taken is serviced after it",
+			state:     State{PC: 0x0200, SP: 0xFD, P: FlagZero},
+			program:   []uint8{bne, 0x02},
+			assert:    1,
+			wantTicks: 2 + 7,
+			wantPC:    testIrqVector,
+		},
+		{
+			name:      "IRQ asserted in the first cycle of a branch taken is serviced after it",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			program:   []uint8{bne, 0x02},
+			assert:    1,
+			wantTicks: 3 + 7,
+			wantPC:    testIrqVector,
+		},
+		{
+			name:      "IRQ asserted in the second cycle of a branch taken is serviced after the next instruction",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			program:   []uint8{bne, 0x02},
+			assert:    2,
+			wantTicks: 3 + 2 + 7,
+			wantPC:    testIrqVector,
+		},
+		{
+			name:      "IRQ asserted in the third cycle of a branch taken to another page is serviced after it",
+			state:     State{PC: 0x02F0, SP: 0xFD},
+			program:   []uint8{bne, 0x20},
+			assert:    3,
+			wantTicks: 4 + 7,
+			wantPC:    testIrqVector,
+		},
+		{
+			name:      "NMI asserted in the fourth cycle of BRK hijacks it",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			program:   []uint8{brk},
+			nmi:       true,
+			assert:    4,
+			wantTicks: 7,
+			wantPC:    testNmiVector,
+		},
+		{
+			name:      "NMI asserted in the fifth cycle of BRK is serviced after the first instruction of the handler",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			program:   []uint8{brk},
+			nmi:       true,
+			assert:    5,
+			wantTicks: 7 + 2 + 7,
+			wantPC:    testNmiVector,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, _ := newInterruptCpu(newLegalInstructionSet(), tt.state, tt.program, nil)
+
+			ticks := 0
+			for ticks < tt.wantTicks {
+				ticks++
+				if ticks == tt.assert {
+					if tt.nmi {
+						_ = cpu.SetNmiLine(1, true)
+					} else {
+						_ = cpu.SetIrqLine(1, true)
+					}
+				}
+				done, err := cpu.Tick()
+				if err != nil {
+					t.Fatalf("Tick() %v unexpected error = %v", ticks, err)
+				}
+				if cpu.State.PC == tt.wantPC {
+					if !done || ticks != tt.wantTicks {
+						t.Errorf("Tick() reached $%04X after %v cycles (done = %v), want %v", tt.wantPC, ticks, done, tt.wantTicks)
+					}
+					return
+				}
+			}
+			t.Errorf("Tick() did not reach $%04X after %v cycles, PC = $%04X", tt.wantPC, ticks, cpu.State.PC)
+		})
+	}
+}
+
+func TestCpu_InterruptSequenceTick(t *testing.T) {
+	read := func(address Address, value uint8) []busAccess {
+		return []busAccess{{address: address, value: value}}
+	}
+	write := func(address Address, value uint8) []busAccess {
+		return []busAccess{{write: true, address: address, value: value}}
+	}
+
+	for _, nmi := range []bool{false, true} {
+		state := State{PC: 0x1234, SP: 0xFD, P: FlagCarry}
+		cpu, memory := newInterruptCpu(newLegalInstructionSet(), state, nil, nil)
+		stepCpu, stepMemory := newInterruptCpu(newLegalInstructionSet(), state, nil, nil)
+		vector := testIrqVector
+		if nmi {
+			_ = cpu.SetNmiLine(1, true)
+			_ = stepCpu.SetNmiLine(1, true)
+			vector = testNmiVector
+		} else {
+			_ = cpu.SetIrqLine(1, true)
+			_ = stepCpu.SetIrqLine(1, true)
+		}
+
+		// Execute the NOP, the interrupt is then pending.
+		if _, _, err := tickInstruction(cpu, memory); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stepCpu.Step(); err != nil {
+			t.Fatal(err)
+		}
+
+		cycles, trace, err := tickInstruction(cpu, memory)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stepCycles, err := stepCpu.Step()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vectorAddress := Address(IrqVectorAddress)
+		if nmi {
+			vectorAddress = NmiVectorAddress
+		}
+		want := [][]busAccess{
+			read(0x1235, 0xEA),
+			read(0x1235, 0xEA),
+			write(0x01FD, 0x12),
+			write(0x01FC, 0x35),
+			write(0x01FB, FlagConstant|FlagCarry),
+			read(vectorAddress, uint8(vector)),
+			read(vectorAddress+1, uint8(vector>>8)),
+		}
+		if cycles != 7 || stepCycles != 7 {
+			t.Errorf("NMI %v cycles got Tick = %v, Step = %v, want = 7", nmi, cycles, stepCycles)
+		}
+		if !reflect.DeepEqual(trace, want) {
+			t.Errorf("NMI %v bus activity got = %v, want = %v", nmi, trace, want)
+		}
+		if cpu.State != stepCpu.State || cpu.State.PC != vector {
+			t.Errorf("NMI %v State got Tick = %v, Step = %v", nmi, cpu.State, stepCpu.State)
+		}
+		if memory.ram != stepMemory.ram {
+			t.Errorf("NMI %v memory differs", nmi)
+		}
+
+		wantCounters := Counters{Cycles: 9, Instructions: 1, Irqs: 1}
+		if nmi {
+			wantCounters = Counters{Cycles: 9, Instructions: 1, Nmis: 1}
+		}
+		if cpu.Counters() != wantCounters || stepCpu.Counters() != wantCounters {
+			t.Errorf("NMI %v Counters got Tick = %+v, Step = %+v, want = %+v", nmi, cpu.Counters(), stepCpu.Counters(), wantCounters)
+		}
+	}
+}
+
+func TestCpu_InterruptLineWakesWaitingCpu(t *testing.T) {
+	const wai = 0xCB
+
+	tests := []struct {
+		name   string
+		status Status
+		nmi    bool
+		wantPC Address
+	}{
+		{name: "IRQ is serviced", wantPC: testIrqVector},
+		{name: "IRQ resumes with the following instruction when interrupts are disabled", status: FlagInterrupt, wantPC: 0x0202},
+		{name: "NMI is serviced", status: FlagInterrupt, nmi: true, wantPC: testNmiVector},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, _ := newInterruptCpu(newW65C02SInstructionSet(), State{PC: 0x0200, SP: 0xFD, P: tt.status}, []uint8{wai}, nil)
+			if _, err := cpu.Step(); err != nil {
+				t.Fatal(err)
+			}
+			if cycles, err := cpu.Step(); err != CpuWaiting || cycles != 1 {
+				t.Fatalf("Step() got cycles = %v, err = %v", cycles, err)
+			}
+
+			if tt.nmi {
+				_ = cpu.SetNmiLine(1, true)
+			} else {
+				_ = cpu.SetIrqLine(1, true)
+			}
+			if _, err := cpu.Step(); err != nil {
+				t.Fatal(err)
+			}
+			if cpu.RunState() != Running {
+				t.Errorf("RunState() got = %v, want = %v", cpu.RunState(), Running)
+			}
+			if cpu.State.PC != tt.wantPC {
+				t.Errorf("PC got = $%04X, want = $%04X", cpu.State.PC, tt.wantPC)
+			}
+		})
+	}
+}
+
+func TestCpu_SetInterruptLineOnNil(t *testing.T) {
+	var cpu *Cpu
+	if err := cpu.SetIrqLine(1, true); err != UninitialisedCpu {
+		t.Errorf("SetIrqLine() error = %v, want %v", err, UninitialisedCpu)
+	}
+	if err := cpu.SetNmiLine(1, true); err != UninitialisedCpu {
+		t.Errorf("SetNmiLine() error = %v, want %v", err, UninitialisedCpu)
+	}
+}
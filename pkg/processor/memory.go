@@ -1,10 +1,123 @@
 package processor
 
+import "fmt"
+
 type Memory interface {
 	Read(Address) uint8
 	Write(Address, uint8)
 }
 
+// Access describes how a memory location was accessed.
+type Access uint8
+
+const (
+	ReadAccess        Access = iota // A read of data, a pointer or a vector.
+	WriteAccess                     // A write of data.
+	OpcodeFetchAccess               // The read of the opcode that starts an instruction.
+	OperandAccess                   // A read of the bytes that follow the opcode.
+	DummyReadAccess                 // A read whose value is discarded, such as before a page boundary is fixed.
+	StackReadAccess                 // A pull from the stack.
+	StackWriteAccess                // A push to the stack.
+	ValueAccess                     // A read by an addressing mode of the value at the effective address.
+)
+
+func (a Access) String() string {
+	switch a {
+	case ReadAccess:
+		return "read"
+	case WriteAccess:
+		return "write"
+	case OpcodeFetchAccess:
+		return "opcode fetch"
+	case OperandAccess:
+		return "operand read"
+	case DummyReadAccess:
+		return "dummy read"
+	case StackReadAccess:
+		return "stack read"
+	case StackWriteAccess:
+		return "stack write"
+	case ValueAccess:
+		return "value read"
+	}
+	return fmt.Sprintf("Access(%d)", uint8(a))
+}
+
+// IsWrite returns true if the access writes to memory.
+func (a Access) IsWrite() bool {
+	return a == WriteAccess || a == StackWriteAccess
+}
+
+// AccessMemory is a Memory that is told the kind of each access made by the Cpu, so that a
+// device can tell an opcode fetch from a read of data, or ignore dummy reads that would
+// otherwise have side effects. The Cpu calls AccessRead and AccessWrite instead of Read and
+// Write, which are still used by everything else.
+//
+// The addressing modes read the value at the effective address with a ValueAccess. The Cpu
+// skips that read for an instruction that only writes to the address or jumps to it, as a
+// real 6502 does, and otherwise passes it on as a ReadAccess. A Memory that wraps another
+// should pass the kind on unchanged.
+type AccessMemory interface {
+	Memory
+	AccessRead(address Address, access Access) uint8
+	AccessWrite(address Address, value uint8, access Access)
+}
+
+// readAccess reads the memory, passing the kind of access if it is an AccessMemory.
+func readAccess(memory Memory, address Address, access Access) uint8 {
+	switch m := memory.(type) {
+	case *cpuMemory:
+		// The memory of the Cpu is checked first, and its AccessRead expanded as it is not
+		// inlined, unless the value at the effective address is read.
+		if access == ValueAccess {
+			return m.AccessRead(address, access)
+		}
+		if m.accesses == nil {
+			return m.memory.Read(address)
+		}
+		return m.accesses.AccessRead(address, access)
+	case *replayMemory:
+		// The memory replayed by Tick is matched directly, as the cache the runtime fills for
+		// the AccessMemory case the first time it sees a type is allocated on the heap.
+		return m.AccessRead(address, access)
+	case AccessMemory:
+		return m.AccessRead(address, access)
+	}
+	return memory.Read(address)
+}
+
+// writeAccess writes the memory, passing the kind of access if it is an AccessMemory.
+func writeAccess(memory Memory, address Address, value uint8, access Access) {
+	switch m := memory.(type) {
+	case *cpuMemory:
+		m.AccessWrite(address, value, access)
+	case *replayMemory:
+		m.AccessWrite(address, value, access)
+	case AccessMemory:
+		m.AccessWrite(address, value, access)
+	default:
+		memory.Write(address, value)
+	}
+}
+
+// FaultingMemory is a Memory whose accesses can fail, for example because nothing is mapped
+// at the address. As Read and Write cannot return an error the Cpu asks for any fault once
+// each instruction or cycle has completed and returns it from Step, Tick or Reset.
+type FaultingMemory interface {
+	Memory
+	// Fault returns the first fault since it was last called, clearing it, and true if
+	// there was one.
+	Fault() (MemoryFaultError, bool)
+}
+
+// SizedMemory is a Memory of a fixed size, such as a RAM or ROM chip, that is addressed from
+// zero. A Bus checks that it exactly fills the range it is mapped to.
+type SizedMemory interface {
+	Memory
+	// Size returns the number of bytes in the memory.
+	Size() int
+}
+
 /*
 The 6502 CPU expects interrupt vectors in a fixed place at the end of the memory space:
 $FFFA–$FFFB: NMI vector
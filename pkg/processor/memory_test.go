@@ -5,6 +5,35 @@ import (
 	"testing"
 )
 
+// accessMemory is a full 64K AccessMemory that records the kind of every access made to it.
+type accessMemory struct {
+	ram      FlatRam
+	accesses []recordedAccess
+}
+
+type recordedAccess struct {
+	address Address
+	access  Access
+}
+
+func (m *accessMemory) Read(address Address) uint8 {
+	return m.AccessRead(address, ReadAccess)
+}
+
+func (m *accessMemory) Write(address Address, value uint8) {
+	m.AccessWrite(address, value, WriteAccess)
+}
+
+func (m *accessMemory) AccessRead(address Address, access Access) uint8 {
+	m.accesses = append(m.accesses, recordedAccess{address: address, access: access})
+	return m.ram[address]
+}
+
+func (m *accessMemory) AccessWrite(address Address, value uint8, access Access) {
+	m.accesses = append(m.accesses, recordedAccess{address: address, access: access})
+	m.ram[address] = value
+}
+
 func TestWriteVectorToMemory(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -885,3 +914,164 @@ func TestNewRepeatingRam(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessMemory(t *testing.T) {
+	var (
+		fetch   = recordedAccess{address: 0x0200, access: OpcodeFetchAccess}
+		operand = recordedAccess{address: 0x0201, access: OperandAccess}
+		high    = recordedAccess{address: 0x0202, access: OperandAccess}
+	)
+	tests := []struct {
+		name    string
+		program []uint8
+		step    []recordedAccess
+		tick    []recordedAccess // When Tick accesses memory differently to Step.
+	}{
+		{
+			name:    "STA does not read",
+			program: []uint8{0x8D, 0x34, 0x12}, // STA $1234
+			step:    []recordedAccess{fetch, operand, high, {0x1234, WriteAccess}},
+		},
+		{
+			name:    "LDA crossing a page",
+			program: []uint8{0xBD, 0xFF, 0x12}, // LDA $12FF,X
+			step:    []recordedAccess{fetch, operand, high, {0x1300, ReadAccess}},
+			tick:    []recordedAccess{fetch, operand, high, {0x1200, DummyReadAccess}, {0x1300, ReadAccess}},
+		},
+		{
+			name:    "STA indexed",
+			program: []uint8{0x9D, 0xFF, 0x12}, // STA $12FF,X
+			step:    []recordedAccess{fetch, operand, high, {0x1300, WriteAccess}},
+			tick:    []recordedAccess{fetch, operand, high, {0x1200, DummyReadAccess}, {0x1300, WriteAccess}},
+		},
+		{
+			name:    "INC",
+			program: []uint8{0xE6, 0x12}, // INC $12
+			step:    []recordedAccess{fetch, operand, {0x0012, ReadAccess}, {0x0012, WriteAccess}},
+			tick:    []recordedAccess{fetch, operand, {0x0012, ReadAccess}, {0x0012, WriteAccess}, {0x0012, WriteAccess}},
+		},
+		{
+			name:    "PHA",
+			program: []uint8{0x48}, // PHA
+			step:    []recordedAccess{fetch, {0x01FD, StackWriteAccess}},
+			tick:    []recordedAccess{fetch, {0x0201, DummyReadAccess}, {0x01FD, StackWriteAccess}},
+		},
+		{
+			name:    "PLA",
+			program: []uint8{0x68}, // PLA
+			step:    []recordedAccess{fetch, {0x01FE, StackReadAccess}},
+			tick: []recordedAccess{fetch, {0x0201, DummyReadAccess}, {0x01FD, DummyReadAccess},
+				{0x01FE, StackReadAccess}},
+		},
+		{
+			name:    "JMP does not read the target",
+			program: []uint8{0x4C, 0x34, 0x12}, // JMP $1234
+			step:    []recordedAccess{fetch, operand, high},
+		},
+		{
+			name:    "JMP indirect",
+			program: []uint8{0x6C, 0x34, 0x12}, // JMP ($1234)
+			step:    []recordedAccess{fetch, operand, high, {0x1234, ReadAccess}, {0x1235, ReadAccess}},
+		},
+		{
+			name:    "JSR",
+			program: []uint8{0x20, 0x34, 0x12}, // JSR $1234
+			step:    []recordedAccess{fetch, operand, high, {0x01FD, StackWriteAccess}, {0x01FC, StackWriteAccess}},
+			tick: []recordedAccess{fetch, operand, {0x01FD, DummyReadAccess}, {0x01FD, StackWriteAccess},
+				{0x01FC, StackWriteAccess}, high},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, tick := range []bool{false, true} {
+				memory := &accessMemory{}
+				copy(memory.ram[0x0200:], tt.program)
+				// The kind of each access is passed on by the Bus.
+				bus, err := NewBusBuilder().Map("RAM", 0x0000, 0xFFFF, memory).Build()
+				if err != nil {
+					t.Fatal(err)
+				}
+				cpu, err := NewCpu(newLegalInstructionSet(), bus)
+				if err != nil {
+					t.Fatal(err)
+				}
+				cpu.State = State{PC: 0x0200, SP: 0xFD, X: 0x01}
+
+				want := tt.step
+				if tick {
+					if tt.tick != nil {
+						want = tt.tick
+					}
+					for done := false; !done && err == nil; {
+						done, err = cpu.Tick()
+					}
+				} else {
+					_, err = cpu.Step()
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(memory.accesses, want) {
+					t.Errorf("Tick %v accesses got = %v, want = %v", tick, memory.accesses, want)
+				}
+			}
+		})
+	}
+}
+
+// valueAbsolute is absolute addressing as it would be written outside the package, reading
+// the value at the effective address with a ValueAccess.
+func valueAbsolute(state State, memory Memory) (Addressing, error) {
+	accesses := memory.(AccessMemory)
+	address := MakeAddress(accesses.AccessRead(state.PC, OperandAccess), accesses.AccessRead(state.PC+1, OperandAccess))
+	return Addressing{
+		EffectiveAddress:     address,
+		Value:                accesses.AccessRead(address, ValueAccess),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+func TestValueAccess(t *testing.T) {
+	is, err := NewInstructionSetBuilder().Add(
+		Instruction{Opcode: 0x02, AddressingFunc: valueAbsolute, Operation: LoadA, Cycles: 3, Type: ReadOperation},
+		Instruction{Opcode: 0x03, AddressingFunc: valueAbsolute, Operation: StoreA, Cycles: 3, Type: WriteOperation},
+	).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		program []uint8
+		want    recordedAccess
+	}{
+		{name: "Read", program: []uint8{0x02, 0x34, 0x12}, want: recordedAccess{0x1234, ReadAccess}},
+		{name: "Write", program: []uint8{0x03, 0x34, 0x12}, want: recordedAccess{0x1234, WriteAccess}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &accessMemory{}
+			copy(memory.ram[0x0200:], tt.program)
+			// The Cpu passes the ValueAccess on to the Bus as a ReadAccess, or skips it for a store.
+			bus, err := NewBusBuilder().Map("RAM", 0x0000, 0xFFFF, memory).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			cpu, err := NewCpu(is, bus)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cpu.State = State{PC: 0x0200}
+			if _, err := cpu.Step(); err != nil {
+				t.Fatal(err)
+			}
+
+			want := []recordedAccess{
+				{0x0200, OpcodeFetchAccess}, {0x0201, OperandAccess}, {0x0202, OperandAccess}, tt.want,
+			}
+			if !reflect.DeepEqual(memory.accesses, want) {
+				t.Errorf("accesses got = %v, want = %v", memory.accesses, want)
+			}
+		})
+	}
+}
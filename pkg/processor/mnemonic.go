@@ -24,6 +24,10 @@ func NewInstruction(mnemonic Mnemonic) Instruction {
 		Operation:           mnemonic.Operation.Operation,
 		Cycles:              uint(cycles),
 		PageBoundaryPenalty: mnemonic.Operation.PageBoundaryPenalty && mnemonic.Addressing.PageBoundaryPenalty,
+		BranchTakenPenalty:  mnemonic.Operation.BranchTakenPenalty,
+		DecimalPenalty:      mnemonic.Operation.DecimalPenalty,
+		Mode:                mnemonic.Addressing.Mode,
+		Type:                mnemonic.Operation.Type,
 	}
 
 	return result
@@ -49,24 +53,30 @@ type MnemonicOperation struct {
 	Description          string
 	AssemblyLanguageForm string
 	AffectedFlags        Flags
-	Bytes                uint // The number of bytes for the Opcode (but not addressing), usually 1.
-	Cycles               uint // The number of cycles for the Operation (but not addressing), usually 1.
-	PageBoundaryPenalty  bool // See note below
-	// TODO BranchTakenPenalty   bool // See note below
-	Operation Operation
+	Bytes                uint          // The number of bytes for the Opcode (but not addressing), usually 1.
+	Cycles               uint          // The number of cycles for the Operation (but not addressing), usually 1.
+	PageBoundaryPenalty  bool          // See note below
+	BranchTakenPenalty   bool          // See note below
+	DecimalPenalty       bool          // See note below
+	Type                 OperationType // The pattern of bus activity the Operation performs.
+	Operation            Operation
 
 	// NOTE: If PageBoundaryPenalty is true and the corresponding PageBoundaryPenalty value in the
 	//       MnemonicAddressingMode is true then the operation is susceptible to a page boundary penalty.
 	// NOTE: If BranchTakenPenalty is true and the branch was taken, then an additional 1 cycle
-	//       penalty is incurred in the CPU.
+	//       penalty is incurred in the CPU. For branches, the page boundary penalty is only
+	//       incurred if the branch is taken.
+	// NOTE: If DecimalPenalty is true and the decimal flag is set, then an additional 1 cycle
+	//       penalty is incurred in the CPU. This only applies to the 65C02.
 }
 
 type MnemonicAddressingMode struct {
 	Name                 string
 	AssemblyLanguageForm string
-	Bytes                uint // The number of bytes following the opcode required for addressing.
-	Cycles               uint // The number of cycles required for the addressing; not including any penalties.
-	PageBoundaryPenalty  bool // See note above
+	Bytes                uint           // The number of bytes following the opcode required for addressing.
+	Cycles               uint           // The number of cycles required for the addressing; not including any penalties.
+	PageBoundaryPenalty  bool           // See note above
+	Mode                 AddressingMode // The pattern of bus activity the AddressingFunc performs.
 	AddressingFunc       AddressingFunc
 }
 
@@ -76,7 +86,7 @@ type MnemonicAddressingMode struct {
 func MnemonicFromOpCode(opcode Opcode) (Mnemonic, error) {
 	mnemonic, ok := mnemonics[opcode]
 	if !ok {
-		return Mnemonic{}, fmt.Errorf("the operation code %20x cannot be found", opcode)
+		return Mnemonic{}, fmt.Errorf("the operation code $%02X cannot be found", uint8(opcode))
 	}
 	return mnemonic, nil
 }
@@ -100,7 +110,7 @@ func MnemonicsFromInstructionName(name string) ([]Mnemonic, error) {
 
 // AllOpcodes returns Mnemonic representations of all the known opcodes.
 //
-// NOTE: Currently this is only the known legal opcodes.
+// NOTE: This is only the known legal opcodes, see AllUndocumentedOpcodes() for the others.
 func AllOpcodes() []Mnemonic {
 
 	// Here we make a copy of the data to avoid it being mutated.
@@ -141,6 +151,7 @@ type MnemonicDisplayDetails struct {
 	Bytes               uint
 	Cycles              uint
 	PageBoundaryPenalty bool
+	BranchTakenPenalty  bool
 }
 
 // NewMnemonicDisplayDetails converts a Mnemonic instance into MnemonicDisplayDetails which
@@ -166,6 +177,7 @@ func NewMnemonicDisplayDetails(mnemonic Mnemonic) MnemonicDisplayDetails {
 		// uses to read the opcode but the as it is subtracted in NewInstruction().
 		Cycles:              instruction.Cycles + 1,
 		PageBoundaryPenalty: instruction.PageBoundaryPenalty,
+		BranchTakenPenalty:  instruction.BranchTakenPenalty,
 	}
 
 	return result
@@ -177,6 +189,7 @@ var (
 		AssemblyLanguageForm: "$%04X",
 		Bytes:                2,
 		Cycles:               3,
+		Mode:                 AbsoluteMode,
 		AddressingFunc:       Absolute,
 	}
 	AbsX = MnemonicAddressingMode{
@@ -185,6 +198,7 @@ var (
 		Bytes:                2,
 		Cycles:               3,
 		PageBoundaryPenalty:  true,
+		Mode:                 AbsoluteXMode,
 		AddressingFunc:       AbsoluteX,
 	}
 	AbsY = MnemonicAddressingMode{
@@ -193,6 +207,7 @@ var (
 		Bytes:                2,
 		Cycles:               3,
 		PageBoundaryPenalty:  true,
+		Mode:                 AbsoluteYMode,
 		AddressingFunc:       AbsoluteY,
 	}
 	Acc = MnemonicAddressingMode{
@@ -200,6 +215,7 @@ var (
 		AssemblyLanguageForm: "A",
 		Bytes:                0,
 		Cycles:               0,
+		Mode:                 AccumulatorMode,
 		AddressingFunc:       Accumulator,
 	}
 	Imm = MnemonicAddressingMode{
@@ -207,6 +223,7 @@ var (
 		AssemblyLanguageForm: "#$%02X",
 		Bytes:                1,
 		Cycles:               1,
+		Mode:                 ImmediateMode,
 		AddressingFunc:       Immediate,
 	}
 	Imp = MnemonicAddressingMode{
@@ -214,6 +231,7 @@ var (
 		AssemblyLanguageForm: "",
 		Bytes:                0,
 		Cycles:               0,
+		Mode:                 ImpliedMode,
 		AddressingFunc:       Implied,
 	}
 	Ind = MnemonicAddressingMode{
@@ -221,6 +239,7 @@ var (
 		AssemblyLanguageForm: "($%04X)",
 		Bytes:                2,
 		Cycles:               4,
+		Mode:                 IndirectMode,
 		AddressingFunc:       Indirect,
 	}
 	IndX = MnemonicAddressingMode{
@@ -228,6 +247,7 @@ var (
 		AssemblyLanguageForm: "($%02X,X)",
 		Bytes:                1,
 		Cycles:               5,
+		Mode:                 IndirectXMode,
 		AddressingFunc:       IndirectX,
 	}
 	IndY = MnemonicAddressingMode{
@@ -236,6 +256,7 @@ var (
 		Bytes:                1,
 		Cycles:               4,
 		PageBoundaryPenalty:  true,
+		Mode:                 IndirectYMode,
 		AddressingFunc:       IndirectY,
 	}
 	Rel = MnemonicAddressingMode{
@@ -244,6 +265,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Mode:                 RelativeMode,
 		AddressingFunc:       Relative,
 	}
 	Zpg = MnemonicAddressingMode{
@@ -251,6 +273,7 @@ var (
 		AssemblyLanguageForm: "$%02X",
 		Bytes:                1,
 		Cycles:               2,
+		Mode:                 ZeroPageMode,
 		AddressingFunc:       ZeroPage,
 	}
 	ZpgX = MnemonicAddressingMode{
@@ -258,6 +281,7 @@ var (
 		AssemblyLanguageForm: "$%02X,X",
 		Bytes:                1,
 		Cycles:               3,
+		Mode:                 ZeroPageXMode,
 		AddressingFunc:       ZeroPageX,
 	}
 	ZpgY = MnemonicAddressingMode{
@@ -265,6 +289,7 @@ var (
 		AssemblyLanguageForm: "$%02X,Y",
 		Bytes:                1,
 		Cycles:               3,
+		Mode:                 ZeroPageYMode,
 		AddressingFunc:       ZeroPageY,
 	}
 )
@@ -278,6 +303,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            AddWithCarry,
 	}
 	And = MnemonicOperation{
@@ -288,6 +314,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            AndWithA,
 	}
 	Asl = MnemonicOperation{
@@ -298,6 +325,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
 		Operation:            ArithmeticShiftLeft,
 	}
 	Bit = MnemonicOperation{
@@ -308,6 +336,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
 		Operation:            TestBitsInMemoryWithAccumulator,
 	}
 	Bcc = MnemonicOperation{
@@ -316,7 +345,9 @@ var (
 		AssemblyLanguageForm: "BCC",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnCarryClear,
 	}
 	Bcs = MnemonicOperation{
@@ -325,7 +356,9 @@ var (
 		AssemblyLanguageForm: "BCS",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnCarrySet,
 	}
 	Beq = MnemonicOperation{
@@ -334,7 +367,9 @@ var (
 		AssemblyLanguageForm: "BEQ",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnEqual,
 	}
 	Bmi = MnemonicOperation{
@@ -343,7 +378,9 @@ var (
 		AssemblyLanguageForm: "BMI",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnMinus,
 	}
 	Bne = MnemonicOperation{
@@ -352,7 +389,9 @@ var (
 		AssemblyLanguageForm: "BNE",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnNotEqual,
 	}
 	Bpl = MnemonicOperation{
@@ -361,7 +400,9 @@ var (
 		AssemblyLanguageForm: "BPL",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnPlus,
 	}
 	Brk = MnemonicOperation{
@@ -372,6 +413,7 @@ var (
 		Bytes:                1,
 		Cycles:               7,
 		PageBoundaryPenalty:  false,
+		Type:                 BreakOperation,
 		Operation:            Break,
 	}
 	Bvc = MnemonicOperation{
@@ -380,7 +422,9 @@ var (
 		AssemblyLanguageForm: "BVC",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnOverflowClear,
 	}
 	Bvs = MnemonicOperation{
@@ -389,7 +433,9 @@ var (
 		AssemblyLanguageForm: "BVS",
 		Bytes:                1,
 		Cycles:               1,
-		PageBoundaryPenalty:  true, // TODO: Needs an additional penalty cycle if branch taken
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
 		Operation:            BranchOnOverflowSet,
 	}
 	Clc = MnemonicOperation{
@@ -400,6 +446,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            ClearCarry,
 	}
 	Cld = MnemonicOperation{
@@ -410,6 +457,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            ClearDecimal,
 	}
 	Cli = MnemonicOperation{
@@ -420,6 +468,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            ClearInterrupt,
 	}
 	Clv = MnemonicOperation{
@@ -430,6 +479,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            ClearOverflow,
 	}
 	Cmp = MnemonicOperation{
@@ -442,6 +492,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            CompareWithA,
 	}
 	Cpx = MnemonicOperation{
@@ -454,6 +505,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
 		Operation:            CompareWithX,
 	}
 	Cpy = MnemonicOperation{
@@ -466,6 +518,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            CompareWithY,
 	}
 	Dec = MnemonicOperation{
@@ -476,6 +529,7 @@ var (
 		Bytes:                1,
 		Cycles:               3,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
 		Operation:            Decrement,
 	}
 	Dex = MnemonicOperation{
@@ -486,6 +540,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            DecrementX,
 	}
 	Dey = MnemonicOperation{
@@ -496,6 +551,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            DecrementY,
 	}
 	Eor = MnemonicOperation{
@@ -506,6 +562,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            ExclusiveOrWithA,
 	}
 	Inc = MnemonicOperation{
@@ -516,6 +573,7 @@ var (
 		Bytes:                1,
 		Cycles:               3,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
 		Operation:            Increment,
 	}
 	Inx = MnemonicOperation{
@@ -526,6 +584,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            IncrementX,
 	}
 	Iny = MnemonicOperation{
@@ -536,6 +595,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            IncrementY,
 	}
 	Jmp = MnemonicOperation{
@@ -545,6 +605,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  false,
+		Type:                 JumpOperation,
 		Operation:            Jump,
 	}
 	Jsr = MnemonicOperation{
@@ -554,6 +615,7 @@ var (
 		Bytes:                1,
 		Cycles:               3,
 		PageBoundaryPenalty:  false,
+		Type:                 JumpSubroutineOperation,
 		Operation:            JumpSubRoutine,
 	}
 	Lda = MnemonicOperation{
@@ -564,6 +626,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            LoadA,
 	}
 	Ldx = MnemonicOperation{
@@ -574,6 +637,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            LoadX,
 	}
 	Ldy = MnemonicOperation{
@@ -584,6 +648,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            LoadY,
 	}
 	Lsr = MnemonicOperation{
@@ -594,6 +659,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
 		Operation:            LogicalShiftRight,
 	}
 	Nop = MnemonicOperation{
@@ -603,6 +669,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            NoOperation,
 	}
 	Ora = MnemonicOperation{
@@ -613,6 +680,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            OrWithA,
 	}
 	Pha = MnemonicOperation{
@@ -622,6 +690,7 @@ var (
 		Bytes:                1,
 		Cycles:               3,
 		PageBoundaryPenalty:  false,
+		Type:                 PushOperation,
 		Operation:            PushA,
 	}
 	Php = MnemonicOperation{
@@ -631,6 +700,7 @@ var (
 		Bytes:                1,
 		Cycles:               3,
 		PageBoundaryPenalty:  false,
+		Type:                 PushOperation,
 		Operation:            PushP,
 	}
 	Pla = MnemonicOperation{
@@ -641,6 +711,7 @@ var (
 		Bytes:                1,
 		Cycles:               4,
 		PageBoundaryPenalty:  false,
+		Type:                 PullOperation,
 		Operation:            PullA,
 	}
 	Plp = MnemonicOperation{
@@ -650,6 +721,7 @@ var (
 		Bytes:                1,
 		Cycles:               4,
 		PageBoundaryPenalty:  false,
+		Type:                 PullOperation,
 		Operation:            PullP,
 	}
 	Rol = MnemonicOperation{
@@ -660,6 +732,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
 		Operation:            RotateLeft,
 	}
 	Ror = MnemonicOperation{
@@ -670,6 +743,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
 		Operation:            RotateRight,
 	}
 	Rti = MnemonicOperation{
@@ -680,6 +754,7 @@ var (
 		Bytes:                1,
 		Cycles:               6,
 		PageBoundaryPenalty:  false,
+		Type:                 ReturnFromInterruptOperation,
 		Operation:            ReturnFromInterrupt,
 	}
 	Rts = MnemonicOperation{
@@ -689,6 +764,7 @@ var (
 		Bytes:                1,
 		Cycles:               6,
 		PageBoundaryPenalty:  false,
+		Type:                 ReturnFromSubroutineOperation,
 		Operation:            ReturnFromSubroutine,
 	}
 	Sbc = MnemonicOperation{
@@ -699,6 +775,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
 		Operation:            SubtractWithCarry,
 	}
 	Sec = MnemonicOperation{
@@ -709,6 +786,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            SetCarry,
 	}
 	Sed = MnemonicOperation{
@@ -719,6 +797,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            SetDecimal,
 	}
 	Sei = MnemonicOperation{
@@ -729,6 +808,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            SetInterrupt,
 	}
 	Sta = MnemonicOperation{
@@ -738,6 +818,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
 		Operation:            StoreA,
 	}
 	Stx = MnemonicOperation{
@@ -747,6 +828,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
 		Operation:            StoreX,
 	}
 	Sty = MnemonicOperation{
@@ -756,6 +838,7 @@ var (
 		Bytes:                1,
 		Cycles:               1,
 		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
 		Operation:            StoreY,
 	}
 	Tax = MnemonicOperation{
@@ -766,6 +849,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            TransferAtoX,
 	}
 	Tay = MnemonicOperation{
@@ -776,6 +860,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            TransferAtoY,
 	}
 	Tsx = MnemonicOperation{
@@ -786,6 +871,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            TransferSPtoX,
 	}
 	Txa = MnemonicOperation{
@@ -796,6 +882,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            TransferXtoA,
 	}
 	Txs = MnemonicOperation{
@@ -805,6 +892,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            TransferXtoSP,
 	}
 	Tya = MnemonicOperation{
@@ -815,6 +903,7 @@ var (
 		Bytes:                1,
 		Cycles:               2,
 		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
 		Operation:            TransferYtoA,
 	}
 )
@@ -878,7 +967,7 @@ var opcodes = []Mnemonic{
 	{Opcode: 0x0E, Operation: Asl, Addressing: Abs, CycleAdjust: 1},
 	{Opcode: 0x16, Operation: Asl, Addressing: ZpgX, CycleAdjust: 1},
 	{Opcode: 0x1E, Operation: Asl, Addressing: AbsX, CycleAdjust: 2},
-	/* TODO: A branch not taken requires two machine cycles. Add one if the branch is taken and add one more if the branch crosses a page boundary.*/
+	/* NOTE: A branch not taken requires two machine cycles. Add one if the branch is taken and add one more if the branch crosses a page boundary.*/
 	/*
 		BCC
 		addressing  assembler  opc  bytes  cycles
@@ -1014,7 +1103,7 @@ var opcodes = []Mnemonic{
 		absolute,X  DEC $FFFF,X DE   3      7
 	*/
 	{Opcode: 0xC6, Operation: Dec, Addressing: Zpg},
-	{Opcode: 0xCE, Operation: Dec, Addressing: Abs, CycleAdjust: 1},
+	{Opcode: 0xCE, Operation: Dec, Addressing: Abs},
 	{Opcode: 0xD6, Operation: Dec, Addressing: ZpgX},
 	{Opcode: 0xDE, Operation: Dec, Addressing: AbsX, CycleAdjust: 1},
 	/*
@@ -1058,7 +1147,7 @@ var opcodes = []Mnemonic{
 		absolute,X  INC $FFFF,X FE   3      7
 	*/
 	{Opcode: 0xE6, Operation: Inc, Addressing: Zpg},
-	{Opcode: 0xEE, Operation: Inc, Addressing: Abs, CycleAdjust: 1},
+	{Opcode: 0xEE, Operation: Inc, Addressing: Abs},
 	{Opcode: 0xF6, Operation: Inc, Addressing: ZpgX},
 	{Opcode: 0xFE, Operation: Inc, Addressing: AbsX, CycleAdjust: 1},
 	/*
@@ -0,0 +1,39 @@
+package processor
+
+// All2A03Opcodes returns Mnemonic representations of the legal opcodes of the Ricoh 2A03
+// and 2A07. These are the same as AllOpcodes() except that ADC and SBC ignore the decimal
+// flag.
+//
+// NOTE: This is only the known legal opcodes, see All2A03UndocumentedOpcodes() for the others.
+func All2A03Opcodes() []Mnemonic {
+	return withoutDecimalMode(AllOpcodes())
+}
+
+// All2A03UndocumentedOpcodes returns Mnemonic representations of the undocumented opcodes
+// of the Ricoh 2A03 and 2A07. These are the same as AllUndocumentedOpcodes() except that
+// ARR, ISC, RRA and USBC ignore the decimal flag.
+func All2A03UndocumentedOpcodes(constants MagicConstants) []Mnemonic {
+	return withoutDecimalMode(AllUndocumentedOpcodes(constants))
+}
+
+// binaryOperations maps the assembly language form of each operation affected by the
+// decimal flag to the equivalent operation that ignores it.
+var binaryOperations = map[string]Operation{
+	"ADC":  AddWithCarryBinary,
+	"ARR":  AndWithARotateRightBinary,
+	"ISC":  IncrementAndSubtractBinary,
+	"RRA":  RotateRightAddWithCarryBinary,
+	"SBC":  SubtractWithCarryBinary,
+	"USBC": SubtractWithCarryBinary,
+}
+
+// withoutDecimalMode replaces the operations of the mnemonics that are affected by the
+// decimal flag. The mnemonics must already be a copy as they are modified in place.
+func withoutDecimalMode(mnemonics []Mnemonic) []Mnemonic {
+	for i, mnemonic := range mnemonics {
+		if operation, ok := binaryOperations[mnemonic.Operation.AssemblyLanguageForm]; ok {
+			mnemonics[i].Operation.Operation = operation
+		}
+	}
+	return mnemonics
+}
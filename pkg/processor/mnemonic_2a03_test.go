@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"testing"
+)
+
+func TestAll2A03Opcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		ricoh    []Mnemonic
+		nmos     []Mnemonic
+		affected int
+	}{
+		{
+			name:     "All2A03Opcodes()",
+			ricoh:    All2A03Opcodes(),
+			nmos:     AllOpcodes(),
+			affected: 16,
+		},
+		{
+			name:     "All2A03UndocumentedOpcodes()",
+			ricoh:    All2A03UndocumentedOpcodes(DefaultMagicConstants),
+			nmos:     AllUndocumentedOpcodes(DefaultMagicConstants),
+			affected: 16,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.ricoh) != len(tt.nmos) {
+				t.Fatalf("%v got %v opcodes, want %v", tt.name, len(tt.ricoh), len(tt.nmos))
+			}
+
+			affected := 0
+			for i, mnemonic := range tt.ricoh {
+				got := NewMnemonicDisplayDetails(mnemonic)
+				want := NewMnemonicDisplayDetails(tt.nmos[i])
+				if got != want {
+					t.Errorf("%v opcode $%02X got = %+v, want = %+v", tt.name, mnemonic.Opcode, got, want)
+				}
+
+				if _, ok := binaryOperations[mnemonic.Operation.AssemblyLanguageForm]; !ok {
+					continue
+				}
+				affected++
+
+				// The result in decimal mode must be the same as in binary mode.
+				binaryRam := NewPopulatedRam(EightBytes, []uint8{0x29, 0, 0, 0, 0, 0, 0, 0})
+				binaryState, err := mnemonic.Operation.Operation(State{A: 0x19, P: FlagCarry}, &Addressing{Value: 0x29, Memory: &binaryRam})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				decimalRam := NewPopulatedRam(EightBytes, []uint8{0x29, 0, 0, 0, 0, 0, 0, 0})
+				decimalState, err := mnemonic.Operation.Operation(State{A: 0x19, P: FlagCarry | FlagDecimal}, &Addressing{Value: 0x29, Memory: &decimalRam})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				binaryState.P.SetDecimal()
+				if decimalState != binaryState || decimalRam.ram[0] != binaryRam.ram[0] {
+					t.Errorf("%v opcode $%02X decimal got = %v, want = %v", tt.name, mnemonic.Opcode, decimalState, binaryState)
+				}
+			}
+
+			if affected != tt.affected {
+				t.Errorf("%v got %v opcodes affected by decimal mode, want %v", tt.name, affected, tt.affected)
+			}
+		})
+	}
+}
@@ -0,0 +1,595 @@
+package processor
+
+import "fmt"
+
+// All65C02Opcodes returns Mnemonic representations of all 256 opcodes of the 65C02. This
+// is the legal NMOS 6502 opcodes (some with changed behaviour or timing), the new 65C02
+// opcodes, the Rockwell bit manipulation and bit branch opcodes and the remaining
+// undefined opcodes which all behave as NOPs of various lengths.
+//
+// NOTE: The WDC WAI and STP opcodes are not included; $CB and $DB are NOPs. See
+// AllW65C02SOpcodes() for those.
+func All65C02Opcodes() []Mnemonic {
+
+	replaced := make(map[Opcode]bool, len(cmosOpcodes))
+	result := make([]Mnemonic, 0, 0x100)
+	for _, opcode := range cmosOpcodes {
+		replaced[opcode.Opcode] = true
+		result = append(result, opcode)
+	}
+
+	// Here we make a copy of the data to avoid it being mutated.
+	for _, opcode := range opcodes {
+		if !replaced[opcode.Opcode] {
+			result = append(result, opcode)
+		}
+	}
+
+	return result
+}
+
+// AllW65C02SOpcodes returns Mnemonic representations of all 256 opcodes of the WDC
+// W65C02S. This is the same as All65C02Opcodes() with the addition of WAI ($CB) and
+// STP ($DB).
+func AllW65C02SOpcodes() []Mnemonic {
+
+	result := All65C02Opcodes()
+	for i, opcode := range result {
+		switch opcode.Opcode {
+		case waiOpcode:
+			result[i] = Mnemonic{Opcode: waiOpcode, Operation: Wai, Addressing: Imp}
+		case stpOpcode:
+			result[i] = Mnemonic{Opcode: stpOpcode, Operation: Stp, Addressing: Imp}
+		}
+	}
+
+	return result
+}
+
+const (
+	waiOpcode Opcode = 0xCB
+	stpOpcode Opcode = 0xDB
+)
+
+// bbr returns the MnemonicOperation for BBRn.
+func bbr(bit uint8) MnemonicOperation {
+	return MnemonicOperation{
+		Name:                 fmt.Sprintf("Branch on bit %v reset", bit),
+		Description:          fmt.Sprintf("Branch relative to current program counter if bit %v of the value in the zero page is clear.", bit),
+		AssemblyLanguageForm: fmt.Sprintf("BBR%v", bit),
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
+		Operation:            BranchOnBitReset(bit),
+	}
+}
+
+// bbs returns the MnemonicOperation for BBSn.
+func bbs(bit uint8) MnemonicOperation {
+	return MnemonicOperation{
+		Name:                 fmt.Sprintf("Branch on bit %v set", bit),
+		Description:          fmt.Sprintf("Branch relative to current program counter if bit %v of the value in the zero page is set.", bit),
+		AssemblyLanguageForm: fmt.Sprintf("BBS%v", bit),
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
+		Operation:            BranchOnBitSet(bit),
+	}
+}
+
+// rmb returns the MnemonicOperation for RMBn.
+func rmb(bit uint8) MnemonicOperation {
+	return MnemonicOperation{
+		Name:                 fmt.Sprintf("Reset memory bit %v", bit),
+		Description:          fmt.Sprintf("Clear bit %v of the value in the zero page.", bit),
+		AssemblyLanguageForm: fmt.Sprintf("RMB%v", bit),
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            ResetMemoryBit(bit),
+	}
+}
+
+// smb returns the MnemonicOperation for SMBn.
+func smb(bit uint8) MnemonicOperation {
+	return MnemonicOperation{
+		Name:                 fmt.Sprintf("Set memory bit %v", bit),
+		Description:          fmt.Sprintf("Set bit %v of the value in the zero page.", bit),
+		AssemblyLanguageForm: fmt.Sprintf("SMB%v", bit),
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            SetMemoryBit(bit),
+	}
+}
+
+// The 65C02 addressing modes have no bus information as the cycle-stepped core only
+// models the NMOS 6502.
+var (
+	AbsXInd = MnemonicAddressingMode{
+		Name:                 "X-indexed, Absolute Indirect",
+		AssemblyLanguageForm: "($%04X,X)",
+		Bytes:                2,
+		Cycles:               5,
+		AddressingFunc:       AbsoluteXIndirect,
+	}
+	IndCmos = MnemonicAddressingMode{
+		Name:                 "Indirect",
+		AssemblyLanguageForm: "($%04X)",
+		Bytes:                2,
+		Cycles:               5,
+		AddressingFunc:       IndirectCmos,
+	}
+	ZpgInd = MnemonicAddressingMode{
+		Name:                 "Zero Page Indirect",
+		AssemblyLanguageForm: "($%02X)",
+		Bytes:                1,
+		Cycles:               4,
+		AddressingFunc:       ZeroPageIndirect,
+	}
+	ZpgRel = MnemonicAddressingMode{
+		Name:                 "Zero Page, Relative",
+		AssemblyLanguageForm: "$%02X,$%02X",
+		Bytes:                2,
+		Cycles:               4,
+		PageBoundaryPenalty:  true,
+		AddressingFunc:       ZeroPageRelative,
+	}
+)
+
+var (
+	AdcCmos = MnemonicOperation{
+		Name:                 "Add with carry",
+		Description:          "Add memory to accumulator with carry. Results are dependant on the setting of the decimal flag. In decimal mode, addition is carried out on the assumption that the values involved are packed BCD (Binary Coded Decimal) and the negative and zero flags are valid. Decimal mode takes an additional cycle.",
+		AssemblyLanguageForm: "ADC",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		DecimalPenalty:       true,
+		Type:                 ReadOperation,
+		Operation:            AddWithCarryCmos,
+	}
+	AslCmos = MnemonicOperation{
+		Name:                 "Arithmetic shift left",
+		Description:          "ASL shifts all bits left one position. 0 is shifted into bit 0 and the original bit 7 is shifted into the Carry.",
+		AssemblyLanguageForm: "ASL",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               2,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            ArithmeticShiftLeft,
+	}
+	BitCmos = MnemonicOperation{
+		Name:                 "Test bits",
+		Description:          "BIT sets the Z flag as though the value in the address tested were ANDed with the accumulator. The N and V flags are set to match bits 7 and 6 respectively in the value stored at the tested address.",
+		AssemblyLanguageForm: "BIT",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
+		Operation:            TestBitsInMemoryWithAccumulator,
+	}
+	BitImm = MnemonicOperation{
+		Name:                 "Test bits",
+		Description:          "BIT immediate sets the Z flag as though the value were ANDed with the accumulator. Unlike the other addressing modes, the N and V flags are not affected.",
+		AssemblyLanguageForm: "BIT",
+		AffectedFlags:        Flags{Zero: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            TestBitsImmediate,
+	}
+	Bra = MnemonicOperation{
+		Name:                 "Branch always",
+		Description:          "Branch relative to current program counter unconditionally.",
+		AssemblyLanguageForm: "BRA",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		BranchTakenPenalty:   true,
+		Type:                 BranchOperation,
+		Operation:            BranchAlways,
+	}
+	BrkCmos = MnemonicOperation{
+		Name:                 "Break",
+		Description:          "BRK causes a non-maskable interrupt and increments the program counter by one. Therefore an RTI will go to the address of the BRK +2 so that BRK may be used to replace a two-byte instruction for debugging and the subsequent RTI will be correct. The decimal flag is cleared.",
+		AssemblyLanguageForm: "BRK",
+		AffectedFlags:        Flags{Interrupt: true, Break: true, Decimal: true},
+		Bytes:                1,
+		Cycles:               7,
+		PageBoundaryPenalty:  false,
+		Type:                 BreakOperation,
+		Operation:            BreakCmos,
+	}
+	LsrCmos = MnemonicOperation{
+		Name:                 "Logical shift right",
+		Description:          "Shifts all bits right one position. Zero is shifted into bit 7 and bit 0 is shifted into the carry flag.",
+		AssemblyLanguageForm: "LSR",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               2,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            LogicalShiftRight,
+	}
+	Phx = MnemonicOperation{
+		Name:                 "Push X on stack",
+		Description:          "Push X onto the top of the stack.",
+		AssemblyLanguageForm: "PHX",
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 PushOperation,
+		Operation:            PushX,
+	}
+	Phy = MnemonicOperation{
+		Name:                 "Push Y on stack",
+		Description:          "Push Y onto the top of the stack.",
+		AssemblyLanguageForm: "PHY",
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 PushOperation,
+		Operation:            PushY,
+	}
+	Plx = MnemonicOperation{
+		Name:                 "Pull X from stack",
+		Description:          "Pull X from the top of the stack.",
+		AssemblyLanguageForm: "PLX",
+		AffectedFlags:        Flags{Negative: true, Zero: true},
+		Bytes:                1,
+		Cycles:               4,
+		PageBoundaryPenalty:  false,
+		Type:                 PullOperation,
+		Operation:            PullX,
+	}
+	Ply = MnemonicOperation{
+		Name:                 "Pull Y from stack",
+		Description:          "Pull Y from the top of the stack.",
+		AssemblyLanguageForm: "PLY",
+		AffectedFlags:        Flags{Negative: true, Zero: true},
+		Bytes:                1,
+		Cycles:               4,
+		PageBoundaryPenalty:  false,
+		Type:                 PullOperation,
+		Operation:            PullY,
+	}
+	RolCmos = MnemonicOperation{
+		Name:                 "Rotate left",
+		Description:          "Rotates all bits left one position. The carry flag is shifted into bit 0 and bit 7 is shifted into the carry flag.",
+		AssemblyLanguageForm: "ROL",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               2,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            RotateLeft,
+	}
+	RorCmos = MnemonicOperation{
+		Name:                 "Rotate right",
+		Description:          "Rotates all bits right one position. The carry flag is shifted into bit 7 and bit 0 is shifted into the carry flag.",
+		AssemblyLanguageForm: "ROR",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               2,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            RotateRight,
+	}
+	SbcCmos = MnemonicOperation{
+		Name:                 "Subtract with borrow",
+		Description:          "Subtract memory from accumulator with borrow. Results are dependant on the setting of the decimal flag. In decimal mode, subtraction is carried out on the assumption that the values involved are packed BCD (Binary Coded Decimal) and the negative and zero flags are valid. Decimal mode takes an additional cycle.",
+		AssemblyLanguageForm: "SBC",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		DecimalPenalty:       true,
+		Type:                 ReadOperation,
+		Operation:            SubtractWithCarryCmos,
+	}
+	Stp = MnemonicOperation{
+		Name:                 "Stop",
+		Description:          "Stop the processor until it is reset. WDC 65C02 only.",
+		AssemblyLanguageForm: "STP",
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
+		Operation:            Stop,
+	}
+	Stz = MnemonicOperation{
+		Name:                 "Store zero",
+		Description:          "Store zero to memory.",
+		AssemblyLanguageForm: "STZ",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
+		Operation:            StoreZero,
+	}
+	Trb = MnemonicOperation{
+		Name:                 "Test and reset bits",
+		Description:          "TRB sets the Z flag as though the value in memory were ANDed with the accumulator. The bits set in the accumulator are then cleared in memory.",
+		AssemblyLanguageForm: "TRB",
+		AffectedFlags:        Flags{Zero: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            TestAndResetBits,
+	}
+	Tsb = MnemonicOperation{
+		Name:                 "Test and set bits",
+		Description:          "TSB sets the Z flag as though the value in memory were ANDed with the accumulator. The bits set in the accumulator are then set in memory.",
+		AssemblyLanguageForm: "TSB",
+		AffectedFlags:        Flags{Zero: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            TestAndSetBits,
+	}
+	Wai = MnemonicOperation{
+		Name:                 "Wait for interrupt",
+		Description:          "Wait until an IRQ or NMI occurs. If the interrupt disable flag is set, an IRQ resumes execution with the following instruction rather than being serviced. WDC 65C02 only.",
+		AssemblyLanguageForm: "WAI",
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
+		Operation:            WaitForInterrupt,
+	}
+)
+
+// Data from the following sources:
+//   - http://6502.org/tutorials/65c02opcodes.html
+//   - https://www.westerndesigncenter.com/wdc/documentation/w65c02s.pdf
+//
+// The opcodes below either replace the NMOS 6502 opcode of the same value or fill an
+// undefined NMOS 6502 opcode.
+var cmosOpcodes = []Mnemonic{
+	/*
+		ADC and SBC (decimal mode takes an additional cycle)
+		addressing    assembler      opc  bytes  cycles
+		(indirect,X)  ADC ($FF,X)    61    2      6
+		zeropage      ADC $FF        65    2      3
+		immediate     ADC #$FF       69    2      2
+		absolute      ADC $FFFF      6D    3      4
+		(indirect),Y  ADC ($FF),Y    71    2      5*
+		(indirect)    ADC ($FF)      72    2      5
+		zeropage,X    ADC $FF,X      75    2      4
+		absolute,Y    ADC $FFFF,Y    79    3      4*
+		absolute,X    ADC $FFFF,X    7D    3      4*
+	*/
+	{Opcode: 0x61, Operation: AdcCmos, Addressing: IndX},
+	{Opcode: 0x65, Operation: AdcCmos, Addressing: Zpg},
+	{Opcode: 0x69, Operation: AdcCmos, Addressing: Imm},
+	{Opcode: 0x6D, Operation: AdcCmos, Addressing: Abs},
+	{Opcode: 0x71, Operation: AdcCmos, Addressing: IndY},
+	{Opcode: 0x72, Operation: AdcCmos, Addressing: ZpgInd},
+	{Opcode: 0x75, Operation: AdcCmos, Addressing: ZpgX},
+	{Opcode: 0x79, Operation: AdcCmos, Addressing: AbsY},
+	{Opcode: 0x7D, Operation: AdcCmos, Addressing: AbsX},
+	{Opcode: 0xE1, Operation: SbcCmos, Addressing: IndX},
+	{Opcode: 0xE5, Operation: SbcCmos, Addressing: Zpg},
+	{Opcode: 0xE9, Operation: SbcCmos, Addressing: Imm},
+	{Opcode: 0xED, Operation: SbcCmos, Addressing: Abs},
+	{Opcode: 0xF1, Operation: SbcCmos, Addressing: IndY},
+	{Opcode: 0xF2, Operation: SbcCmos, Addressing: ZpgInd},
+	{Opcode: 0xF5, Operation: SbcCmos, Addressing: ZpgX},
+	{Opcode: 0xF9, Operation: SbcCmos, Addressing: AbsY},
+	{Opcode: 0xFD, Operation: SbcCmos, Addressing: AbsX},
+	/*
+		(indirect) forms of AND, CMP, EOR, LDA, ORA and STA
+		addressing  assembler    opc  bytes  cycles
+		(indirect)  AND ($FF)    32   2      5
+		(indirect)  CMP ($FF)    D2   2      5
+		(indirect)  EOR ($FF)    52   2      5
+		(indirect)  LDA ($FF)    B2   2      5
+		(indirect)  ORA ($FF)    12   2      5
+		(indirect)  STA ($FF)    92   2      5
+	*/
+	{Opcode: 0x32, Operation: And, Addressing: ZpgInd},
+	{Opcode: 0xD2, Operation: Cmp, Addressing: ZpgInd},
+	{Opcode: 0x52, Operation: Eor, Addressing: ZpgInd},
+	{Opcode: 0xB2, Operation: Lda, Addressing: ZpgInd},
+	{Opcode: 0x12, Operation: Ora, Addressing: ZpgInd},
+	{Opcode: 0x92, Operation: Sta, Addressing: ZpgInd},
+	/*
+		ASL, LSR, ROL and ROR
+		addressing  assembler    opc  bytes  cycles
+		absolute,X  ASL $FFFF,X  1E   3      6*
+		absolute,X  LSR $FFFF,X  5E   3      6*
+		absolute,X  ROL $FFFF,X  3E   3      6*
+		absolute,X  ROR $FFFF,X  7E   3      6*
+	*/
+	{Opcode: 0x1E, Operation: AslCmos, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x5E, Operation: LsrCmos, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x3E, Operation: RolCmos, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x7E, Operation: RorCmos, Addressing: AbsX, CycleAdjust: 1},
+	/*
+		BBR and BBS
+		addressing         assembler       opc                      bytes  cycles
+		zeropage,relative  BBR0 $FF,$FF    0F,1F,2F,3F,4F,5F,6F,7F  3      5**
+		zeropage,relative  BBS0 $FF,$FF    8F,9F,AF,BF,CF,DF,EF,FF  3      5**
+	*/
+	{Opcode: 0x0F, Operation: bbr(0), Addressing: ZpgRel},
+	{Opcode: 0x1F, Operation: bbr(1), Addressing: ZpgRel},
+	{Opcode: 0x2F, Operation: bbr(2), Addressing: ZpgRel},
+	{Opcode: 0x3F, Operation: bbr(3), Addressing: ZpgRel},
+	{Opcode: 0x4F, Operation: bbr(4), Addressing: ZpgRel},
+	{Opcode: 0x5F, Operation: bbr(5), Addressing: ZpgRel},
+	{Opcode: 0x6F, Operation: bbr(6), Addressing: ZpgRel},
+	{Opcode: 0x7F, Operation: bbr(7), Addressing: ZpgRel},
+	{Opcode: 0x8F, Operation: bbs(0), Addressing: ZpgRel},
+	{Opcode: 0x9F, Operation: bbs(1), Addressing: ZpgRel},
+	{Opcode: 0xAF, Operation: bbs(2), Addressing: ZpgRel},
+	{Opcode: 0xBF, Operation: bbs(3), Addressing: ZpgRel},
+	{Opcode: 0xCF, Operation: bbs(4), Addressing: ZpgRel},
+	{Opcode: 0xDF, Operation: bbs(5), Addressing: ZpgRel},
+	{Opcode: 0xEF, Operation: bbs(6), Addressing: ZpgRel},
+	{Opcode: 0xFF, Operation: bbs(7), Addressing: ZpgRel},
+	/*
+		BIT
+		addressing  assembler    opc  bytes  cycles
+		immediate   BIT #$FF     89   2      2
+		zeropage,X  BIT $FF,X    34   2      4
+		absolute,X  BIT $FFFF,X  3C   3      4*
+	*/
+	{Opcode: 0x89, Operation: BitImm, Addressing: Imm},
+	{Opcode: 0x34, Operation: BitCmos, Addressing: ZpgX},
+	{Opcode: 0x3C, Operation: BitCmos, Addressing: AbsX},
+	/*
+		BRA
+		addressing  assembler  opc  bytes  cycles
+		relative    BRA $FF    80   2      3**
+	*/
+	{Opcode: 0x80, Operation: Bra, Addressing: Rel},
+	/*
+		BRK (the decimal flag is cleared)
+		addressing  assembler  opc  bytes  cycles
+		implied     BRK        00   1      7
+	*/
+	{Opcode: 0x00, Operation: BrkCmos, Addressing: Imp},
+	/*
+		DEC and INC
+		addressing   assembler  opc  bytes  cycles
+		accumulator  DEC A      3A   1      2
+		accumulator  INC A      1A   1      2
+	*/
+	{Opcode: 0x3A, Operation: Dec, Addressing: Acc, CycleAdjust: -1},
+	{Opcode: 0x1A, Operation: Inc, Addressing: Acc, CycleAdjust: -1},
+	/*
+		JMP (the indirect page boundary bug is fixed)
+		addressing             assembler      opc  bytes  cycles
+		indirect               JMP ($FFFF)    6C   3      6
+		(absolute,X)           JMP ($FFFF,X)  7C   3      6
+	*/
+	{Opcode: 0x6C, Operation: Jmp, Addressing: IndCmos},
+	{Opcode: 0x7C, Operation: Jmp, Addressing: AbsXInd},
+	/*
+		PHX, PHY, PLX and PLY
+		addressing  assembler  opc  bytes  cycles
+		implied     PHX        DA   1      3
+		implied     PHY        5A   1      3
+		implied     PLX        FA   1      4
+		implied     PLY        7A   1      4
+	*/
+	{Opcode: 0xDA, Operation: Phx, Addressing: Imp},
+	{Opcode: 0x5A, Operation: Phy, Addressing: Imp},
+	{Opcode: 0xFA, Operation: Plx, Addressing: Imp},
+	{Opcode: 0x7A, Operation: Ply, Addressing: Imp},
+	/*
+		RMB and SMB
+		addressing  assembler  opc                      bytes  cycles
+		zeropage    RMB0 $FF   07,17,27,37,47,57,67,77  2      5
+		zeropage    SMB0 $FF   87,97,A7,B7,C7,D7,E7,F7  2      5
+	*/
+	{Opcode: 0x07, Operation: rmb(0), Addressing: Zpg},
+	{Opcode: 0x17, Operation: rmb(1), Addressing: Zpg},
+	{Opcode: 0x27, Operation: rmb(2), Addressing: Zpg},
+	{Opcode: 0x37, Operation: rmb(3), Addressing: Zpg},
+	{Opcode: 0x47, Operation: rmb(4), Addressing: Zpg},
+	{Opcode: 0x57, Operation: rmb(5), Addressing: Zpg},
+	{Opcode: 0x67, Operation: rmb(6), Addressing: Zpg},
+	{Opcode: 0x77, Operation: rmb(7), Addressing: Zpg},
+	{Opcode: 0x87, Operation: smb(0), Addressing: Zpg},
+	{Opcode: 0x97, Operation: smb(1), Addressing: Zpg},
+	{Opcode: 0xA7, Operation: smb(2), Addressing: Zpg},
+	{Opcode: 0xB7, Operation: smb(3), Addressing: Zpg},
+	{Opcode: 0xC7, Operation: smb(4), Addressing: Zpg},
+	{Opcode: 0xD7, Operation: smb(5), Addressing: Zpg},
+	{Opcode: 0xE7, Operation: smb(6), Addressing: Zpg},
+	{Opcode: 0xF7, Operation: smb(7), Addressing: Zpg},
+	/*
+		STZ
+		addressing  assembler    opc  bytes  cycles
+		zeropage    STZ $FF      64   2      3
+		zeropage,X  STZ $FF,X    74   2      4
+		absolute    STZ $FFFF    9C   3      4
+		absolute,X  STZ $FFFF,X  9E   3      5
+	*/
+	{Opcode: 0x64, Operation: Stz, Addressing: Zpg},
+	{Opcode: 0x74, Operation: Stz, Addressing: ZpgX},
+	{Opcode: 0x9C, Operation: Stz, Addressing: Abs},
+	{Opcode: 0x9E, Operation: Stz, Addressing: AbsX, CycleAdjust: 1},
+	/*
+		TRB and TSB
+		addressing  assembler  opc  bytes  cycles
+		zeropage    TRB $FF    14   2      5
+		absolute    TRB $FFFF  1C   3      6
+		zeropage    TSB $FF    04   2      5
+		absolute    TSB $FFFF  0C   3      6
+	*/
+	{Opcode: 0x14, Operation: Trb, Addressing: Zpg},
+	{Opcode: 0x1C, Operation: Trb, Addressing: Abs},
+	{Opcode: 0x04, Operation: Tsb, Addressing: Zpg},
+	{Opcode: 0x0C, Operation: Tsb, Addressing: Abs},
+	/*
+		NOP (undefined opcodes)
+		addressing  assembler  opc                   bytes  cycles
+		implied     NOP        x3,xB                 1      1
+		immediate   NOP #$FF   02,22,42,62,82,C2,E2  2      2
+		zeropage    NOP $FF    44                    2      3
+		zeropage,X  NOP $FF,X  54,D4,F4              2      4
+		absolute    NOP $FFFF  DC,FC                 3      4
+		absolute    NOP $FFFF  5C                    3      8
+	*/
+	{Opcode: 0x03, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x13, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x23, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x33, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x43, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x53, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x63, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x73, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x83, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x93, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xA3, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xB3, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xC3, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xD3, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xE3, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xF3, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x0B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x1B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x2B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x3B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x4B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x5B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x6B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x7B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x8B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x9B, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xAB, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xBB, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xCB, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xDB, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xEB, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0xFB, Operation: Nop, Addressing: Imp, CycleAdjust: -1},
+	{Opcode: 0x02, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x22, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x42, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x62, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x82, Operation: Nom, Addressing: Imm},
+	{Opcode: 0xC2, Operation: Nom, Addressing: Imm},
+	{Opcode: 0xE2, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x44, Operation: Nom, Addressing: Zpg},
+	{Opcode: 0x54, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0xD4, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0xF4, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0xDC, Operation: Nom, Addressing: Abs},
+	{Opcode: 0xFC, Operation: Nom, Addressing: Abs},
+	{Opcode: 0x5C, Operation: Nom, Addressing: Abs, CycleAdjust: 4},
+}
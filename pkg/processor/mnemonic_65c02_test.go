@@ -0,0 +1,127 @@
+package processor
+
+import (
+	"testing"
+)
+
+func TestAll65C02Opcodes(t *testing.T) {
+	type expected struct {
+		bytes   uint
+		cycles  uint
+		penalty bool
+	}
+
+	// Timings from http://6502.org/tutorials/65c02opcodes.html for the opcodes that are new
+	// or changed on the 65C02, all others must match the NMOS 6502.
+	want := map[Opcode]expected{
+		// ADC and SBC (indirect)
+		0x72: {2, 5, false}, 0xF2: {2, 5, false},
+		// AND, CMP, EOR, LDA, ORA and STA (indirect)
+		0x32: {2, 5, false}, 0xD2: {2, 5, false}, 0x52: {2, 5, false}, 0xB2: {2, 5, false},
+		0x12: {2, 5, false}, 0x92: {2, 5, false},
+		// ASL, LSR, ROL and ROR absolute,X
+		0x1E: {3, 6, true}, 0x5E: {3, 6, true}, 0x3E: {3, 6, true}, 0x7E: {3, 6, true},
+		// BBR and BBS
+		0x0F: {3, 5, true}, 0x1F: {3, 5, true}, 0x2F: {3, 5, true}, 0x3F: {3, 5, true},
+		0x4F: {3, 5, true}, 0x5F: {3, 5, true}, 0x6F: {3, 5, true}, 0x7F: {3, 5, true},
+		0x8F: {3, 5, true}, 0x9F: {3, 5, true}, 0xAF: {3, 5, true}, 0xBF: {3, 5, true},
+		0xCF: {3, 5, true}, 0xDF: {3, 5, true}, 0xEF: {3, 5, true}, 0xFF: {3, 5, true},
+		// BIT
+		0x89: {2, 2, false}, 0x34: {2, 4, false}, 0x3C: {3, 4, true},
+		// BRA
+		0x80: {2, 2, true},
+		// DEC and INC accumulator
+		0x3A: {1, 2, false}, 0x1A: {1, 2, false},
+		// JMP
+		0x6C: {3, 6, false}, 0x7C: {3, 6, false},
+		// PHX, PHY, PLX and PLY
+		0xDA: {1, 3, false}, 0x5A: {1, 3, false}, 0xFA: {1, 4, false}, 0x7A: {1, 4, false},
+		// RMB and SMB
+		0x07: {2, 5, false}, 0x17: {2, 5, false}, 0x27: {2, 5, false}, 0x37: {2, 5, false},
+		0x47: {2, 5, false}, 0x57: {2, 5, false}, 0x67: {2, 5, false}, 0x77: {2, 5, false},
+		0x87: {2, 5, false}, 0x97: {2, 5, false}, 0xA7: {2, 5, false}, 0xB7: {2, 5, false},
+		0xC7: {2, 5, false}, 0xD7: {2, 5, false}, 0xE7: {2, 5, false}, 0xF7: {2, 5, false},
+		// STZ
+		0x64: {2, 3, false}, 0x74: {2, 4, false}, 0x9C: {3, 4, false}, 0x9E: {3, 5, false},
+		// TRB and TSB
+		0x14: {2, 5, false}, 0x1C: {3, 6, false}, 0x04: {2, 5, false}, 0x0C: {3, 6, false},
+		// NOP (all of $x3 and $xB are also single cycle NOPs, see below)
+		0x02: {2, 2, false}, 0x22: {2, 2, false}, 0x42: {2, 2, false}, 0x62: {2, 2, false},
+		0x82: {2, 2, false}, 0xC2: {2, 2, false}, 0xE2: {2, 2, false}, 0x44: {2, 3, false},
+		0x54: {2, 4, false}, 0xD4: {2, 4, false}, 0xF4: {2, 4, false}, 0xDC: {3, 4, false},
+		0xFC: {3, 4, false}, 0x5C: {3, 8, false},
+	}
+
+	mnemonics := All65C02Opcodes()
+	if len(mnemonics) != 0x100 {
+		t.Errorf("All65C02Opcodes() got %v opcodes, want %v", len(mnemonics), 0x100)
+	}
+
+	for opcode := range 0x100 {
+		if opcode&0x07 == 0x03 {
+			want[Opcode(opcode)] = expected{1, 1, false}
+		}
+	}
+
+	seen := make(map[Opcode]bool, len(mnemonics))
+	for _, mnemonic := range mnemonics {
+		if seen[mnemonic.Opcode] {
+			t.Errorf("All65C02Opcodes() duplicate opcode $%02X", mnemonic.Opcode)
+		}
+		seen[mnemonic.Opcode] = true
+
+		detail := NewMnemonicDisplayDetails(mnemonic)
+		got := expected{detail.Bytes, detail.Cycles, detail.PageBoundaryPenalty}
+
+		w, ok := want[mnemonic.Opcode]
+		if !ok {
+			legal, err := MnemonicFromOpCode(mnemonic.Opcode)
+			if err != nil {
+				t.Errorf("All65C02Opcodes() unexpected opcode $%02X", mnemonic.Opcode)
+				continue
+			}
+			legalDetail := NewMnemonicDisplayDetails(legal)
+			w = expected{legalDetail.Bytes, legalDetail.Cycles, legalDetail.PageBoundaryPenalty}
+		}
+
+		if got != w {
+			t.Errorf("All65C02Opcodes() opcode $%02X (%v) got = %+v, want = %+v", mnemonic.Opcode, detail.Assembler, got, w)
+		}
+	}
+}
+
+func TestAll65C02OpcodesDecimalPenalty(t *testing.T) {
+	for _, mnemonic := range All65C02Opcodes() {
+		instruction := NewInstruction(mnemonic)
+		want := mnemonic.Operation.AssemblyLanguageForm == "ADC" || mnemonic.Operation.AssemblyLanguageForm == "SBC"
+		if instruction.DecimalPenalty != want {
+			t.Errorf("All65C02Opcodes() opcode $%02X DecimalPenalty got = %v, want = %v", mnemonic.Opcode, instruction.DecimalPenalty, want)
+		}
+	}
+}
+
+func TestAllW65C02SOpcodes(t *testing.T) {
+	want := make(map[Opcode]MnemonicDisplayDetails, 0x100)
+	for _, mnemonic := range All65C02Opcodes() {
+		want[mnemonic.Opcode] = NewMnemonicDisplayDetails(mnemonic)
+	}
+
+	mnemonics := AllW65C02SOpcodes()
+	if len(mnemonics) != 0x100 {
+		t.Errorf("AllW65C02SOpcodes() got %v opcodes, want %v", len(mnemonics), 0x100)
+	}
+
+	for _, mnemonic := range mnemonics {
+		detail := NewMnemonicDisplayDetails(mnemonic)
+		switch mnemonic.Opcode {
+		case waiOpcode, stpOpcode:
+			if detail.Bytes != 1 || detail.Cycles != 3 {
+				t.Errorf("AllW65C02SOpcodes() opcode $%02X (%v) got bytes = %v, cycles = %v", mnemonic.Opcode, detail.Assembler, detail.Bytes, detail.Cycles)
+			}
+		default:
+			if detail != want[mnemonic.Opcode] {
+				t.Errorf("AllW65C02SOpcodes() opcode $%02X got = %+v, want = %+v", mnemonic.Opcode, detail, want[mnemonic.Opcode])
+			}
+		}
+	}
+}
@@ -164,6 +164,9 @@ func TestMnemonicFromOpCode(t *testing.T) {
 			}
 
 			if tt.wantErr {
+				if want := fmt.Sprintf("the operation code $%02X cannot be found", uint8(tt.opcode)); err.Error() != want {
+					t.Errorf("MnemonicFromOpCode() error = %v, want %v", err, want)
+				}
 				return
 			}
 
@@ -417,7 +420,7 @@ func TestMnemonicsDetails(t *testing.T) {
 			wantFlags:   Flags{Negative: true, Zero: true},
 			want: []expected{
 				{opcode: 0xC6, bytes: 2, cycles: 5, assembler: "DEC $FF"},
-				{opcode: 0xCE, bytes: 3, cycles: 7, assembler: "DEC $FFFF"},
+				{opcode: 0xCE, bytes: 3, cycles: 6, assembler: "DEC $FFFF"},
 				{opcode: 0xD6, bytes: 2, cycles: 6, assembler: "DEC $FF,X"},
 				{opcode: 0xDE, bytes: 3, cycles: 7, assembler: "DEC $FFFF,X"},
 			},
@@ -454,7 +457,7 @@ func TestMnemonicsDetails(t *testing.T) {
 			wantFlags:   Flags{Negative: true, Zero: true},
 			want: []expected{
 				{opcode: 0xE6, bytes: 2, cycles: 5, assembler: "INC $FF"},
-				{opcode: 0xEE, bytes: 3, cycles: 7, assembler: "INC $FFFF"},
+				{opcode: 0xEE, bytes: 3, cycles: 6, assembler: "INC $FFFF"},
 				{opcode: 0xF6, bytes: 2, cycles: 6, assembler: "INC $FF,X"},
 				{opcode: 0xFE, bytes: 3, cycles: 7, assembler: "INC $FFFF,X"},
 			},
@@ -0,0 +1,547 @@
+package processor
+
+// AllUndocumentedOpcodes returns Mnemonic representations of the undocumented (aka illegal)
+// NMOS 6502 opcodes, both stable and unstable. The unstable ANE and LXA operations use the
+// magic constants provided. The JAM opcodes halt the processor until it is reset.
+func AllUndocumentedOpcodes(constants MagicConstants) []Mnemonic {
+
+	// Here we make a copy of the data to avoid it being mutated.
+	result := make([]Mnemonic, len(undocumentedOpcodes))
+	for i, opcode := range undocumentedOpcodes {
+		switch opcode.Opcode {
+		case aneOpcode:
+			opcode.Operation.Operation = AndXWithMagic(constants.Ane)
+		case lxaOpcode:
+			opcode.Operation.Operation = LoadAAndXWithMagic(constants.Lxa)
+		}
+		result[i] = opcode
+	}
+
+	return result
+}
+
+const (
+	aneOpcode Opcode = 0x8B
+	lxaOpcode Opcode = 0xAB
+)
+
+var (
+	Alr = MnemonicOperation{
+		Name:                 "AND then logical shift right",
+		Description:          "Bitwise AND the accumulator with a value in memory and then shift the accumulator right one bit. Also known as ASR.",
+		AssemblyLanguageForm: "ALR",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            AndWithAShiftRight,
+	}
+	Anc = MnemonicOperation{
+		Name:                 "AND then copy negative to carry",
+		Description:          "Bitwise AND the accumulator with a value in memory and then copy the negative flag into the carry flag.",
+		AssemblyLanguageForm: "ANC",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            AndWithACopyToCarry,
+	}
+	Ane = MnemonicOperation{
+		Name:                 "OR magic, AND X, AND value",
+		Description:          "The accumulator is ORed with a chip dependent magic constant, then ANDed with X and a value in memory. Highly unstable. Also known as XAA.",
+		AssemblyLanguageForm: "ANE",
+		AffectedFlags:        Flags{Negative: true, Zero: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            AndXWithMagic(DefaultMagicConstants.Ane),
+	}
+	Arr = MnemonicOperation{
+		Name:                 "AND then rotate right",
+		Description:          "Bitwise AND the accumulator with a value in memory and then rotate the accumulator right one bit. The carry is set from bit 6 and the overflow from bit 6 XOR bit 5 of the result. Decimal mode affects the result.",
+		AssemblyLanguageForm: "ARR",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            AndWithARotateRight,
+	}
+	Dcp = MnemonicOperation{
+		Name:                 "Decrement then compare",
+		Description:          "Decrement a value in memory by 1 and then compare the result with the accumulator. Also known as DCM.",
+		AssemblyLanguageForm: "DCP",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            DecrementAndCompare,
+	}
+	Isc = MnemonicOperation{
+		Name:                 "Increment then subtract with carry",
+		Description:          "Increment a value in memory by 1 and then subtract the result from the accumulator with borrow. Also known as ISB and INS.",
+		AssemblyLanguageForm: "ISC",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            IncrementAndSubtract,
+	}
+	Jam = MnemonicOperation{
+		Name:                 "Jam",
+		Description:          "Halt the processor; the bus is locked up until the processor is reset. Also known as KIL and HLT.",
+		AssemblyLanguageForm: "JAM",
+		Bytes:                1,
+		Cycles:               2,
+		PageBoundaryPenalty:  false,
+		Type:                 InternalOperation,
+		Operation:            Halt,
+	}
+	Las = MnemonicOperation{
+		Name:                 "AND with SP then load A, X and SP",
+		Description:          "Bitwise AND a value in memory with the stack pointer and transfer the result to the accumulator, X and the stack pointer. Also known as LAR.",
+		AssemblyLanguageForm: "LAS",
+		AffectedFlags:        Flags{Negative: true, Zero: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
+		Operation:            LoadAXAndSPWithSP,
+	}
+	Lax = MnemonicOperation{
+		Name:                 "Load A and X",
+		Description:          "Load both the accumulator and X with a value from memory.",
+		AssemblyLanguageForm: "LAX",
+		AffectedFlags:        Flags{Negative: true, Zero: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
+		Operation:            LoadAAndX,
+	}
+	Lxa = MnemonicOperation{
+		Name:                 "OR magic, AND value then load A and X",
+		Description:          "The accumulator is ORed with a chip dependent magic constant and then ANDed with a value in memory. The result is loaded into both the accumulator and X. Highly unstable. Also known as LAX immediate.",
+		AssemblyLanguageForm: "LXA",
+		AffectedFlags:        Flags{Negative: true, Zero: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            LoadAAndXWithMagic(DefaultMagicConstants.Lxa),
+	}
+	Nom = MnemonicOperation{
+		Name:                 "No Operation (reading memory)",
+		Description:          "Multi-byte NOP that performs the memory read of its addressing mode but otherwise does nothing. Also known as DOP, TOP and SKB/SKW.",
+		AssemblyLanguageForm: "NOP",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  true,
+		Type:                 ReadOperation,
+		Operation:            NoOperation,
+	}
+	Rla = MnemonicOperation{
+		Name:                 "Rotate left then AND",
+		Description:          "Rotate a value in memory left one bit and then bitwise AND the result with the accumulator.",
+		AssemblyLanguageForm: "RLA",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            RotateLeftAndWithA,
+	}
+	Rra = MnemonicOperation{
+		Name:                 "Rotate right then add with carry",
+		Description:          "Rotate a value in memory right one bit and then add the result to the accumulator with the carry rotated out of memory. Decimal mode affects the result.",
+		AssemblyLanguageForm: "RRA",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            RotateRightAddWithCarry,
+	}
+	Sax = MnemonicOperation{
+		Name:                 "Store A AND X",
+		Description:          "Store the bitwise AND of the accumulator and X in memory. Also known as AXS and AAX.",
+		AssemblyLanguageForm: "SAX",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
+		Operation:            StoreAAndX,
+	}
+	Sbx = MnemonicOperation{
+		Name:                 "Subtract from A AND X",
+		Description:          "X is set to the bitwise AND of the accumulator and X minus a value in memory without borrow. The flags are set as CMP would set them. Also known as AXS and SAX.",
+		AssemblyLanguageForm: "SBX",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            SubtractFromAAndX,
+	}
+	Sha = MnemonicOperation{
+		Name:                 "Store A AND X AND high address",
+		Description:          "Store the bitwise AND of the accumulator, X and the high byte of the address plus 1 in memory. Unstable. Also known as AHX and AXA.",
+		AssemblyLanguageForm: "SHA",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
+		Operation:            StoreAAndXAndHigh,
+	}
+	Shx = MnemonicOperation{
+		Name:                 "Store X AND high address",
+		Description:          "Store the bitwise AND of X and the high byte of the address plus 1 in memory. Unstable. Also known as SXA and XAS.",
+		AssemblyLanguageForm: "SHX",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
+		Operation:            StoreXAndHigh,
+	}
+	Shy = MnemonicOperation{
+		Name:                 "Store Y AND high address",
+		Description:          "Store the bitwise AND of Y and the high byte of the address plus 1 in memory. Unstable. Also known as SYA and SAY.",
+		AssemblyLanguageForm: "SHY",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
+		Operation:            StoreYAndHigh,
+	}
+	Slo = MnemonicOperation{
+		Name:                 "Shift left then OR",
+		Description:          "Shift a value in memory left one bit and then bitwise OR the result with the accumulator. Also known as ASO.",
+		AssemblyLanguageForm: "SLO",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            ShiftLeftOrWithA,
+	}
+	Sre = MnemonicOperation{
+		Name:                 "Shift right then exclusive OR",
+		Description:          "Shift a value in memory right one bit and then bitwise exclusive OR the result with the accumulator. Also known as LSE.",
+		AssemblyLanguageForm: "SRE",
+		AffectedFlags:        Flags{Negative: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               3,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadModifyWriteOperation,
+		Operation:            ShiftRightExclusiveOrWithA,
+	}
+	Tas = MnemonicOperation{
+		Name:                 "Transfer A AND X to SP then store",
+		Description:          "Transfer the bitwise AND of the accumulator and X to the stack pointer and then store the bitwise AND of the stack pointer and the high byte of the address plus 1 in memory. Unstable. Also known as SHS and XAS.",
+		AssemblyLanguageForm: "TAS",
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 WriteOperation,
+		Operation:            TransferAAndXToSPAndStore,
+	}
+	Usbc = MnemonicOperation{
+		Name:                 "Subtract with carry",
+		Description:          "Identical to the documented immediate SBC.",
+		AssemblyLanguageForm: "USBC",
+		AffectedFlags:        Flags{Negative: true, Overflow: true, Zero: true, Carry: true},
+		Bytes:                1,
+		Cycles:               1,
+		PageBoundaryPenalty:  false,
+		Type:                 ReadOperation,
+		Operation:            SubtractWithCarry,
+	}
+)
+
+// Data from the following sources:
+//   - https://www.masswerk.at/6502/6502_instruction_set.html#illegals
+//   - https://www.masswerk.at/nowgobang/2021/6502-illegal-opcodes
+var undocumentedOpcodes = []Mnemonic{
+	/*
+		ALR (ASR)
+		addressing  assembler  opc  bytes  cycles
+		immediate   ALR #$FF   4B   2      2
+	*/
+	{Opcode: 0x4B, Operation: Alr, Addressing: Imm},
+	/*
+		ANC
+		addressing  assembler  opc  bytes  cycles
+		immediate   ANC #$FF   0B   2      2
+		immediate   ANC #$FF   2B   2      2
+	*/
+	{Opcode: 0x0B, Operation: Anc, Addressing: Imm},
+	{Opcode: 0x2B, Operation: Anc, Addressing: Imm},
+	/*
+		ANE (XAA)
+		addressing  assembler  opc  bytes  cycles
+		immediate   ANE #$FF   8B   2      2
+	*/
+	{Opcode: aneOpcode, Operation: Ane, Addressing: Imm},
+	/*
+		ARR
+		addressing  assembler  opc  bytes  cycles
+		immediate   ARR #$FF   6B   2      2
+	*/
+	{Opcode: 0x6B, Operation: Arr, Addressing: Imm},
+	/*
+		DCP (DCM)
+		addressing    assembler    opc  bytes  cycles
+		zeropage      DCP $FF      C7   2      5
+		zeropage,X    DCP $FF,X    D7   2      6
+		absolute      DCP $FFFF    CF   3      6
+		absolute,X    DCP $FFFF,X  DF   3      7
+		absolute,Y    DCP $FFFF,Y  DB   3      7
+		(indirect,X)  DCP ($FF,X)  C3   2      8
+		(indirect),Y  DCP ($FF),Y  D3   2      8
+	*/
+	{Opcode: 0xC7, Operation: Dcp, Addressing: Zpg},
+	{Opcode: 0xD7, Operation: Dcp, Addressing: ZpgX},
+	{Opcode: 0xCF, Operation: Dcp, Addressing: Abs},
+	{Opcode: 0xDF, Operation: Dcp, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0xDB, Operation: Dcp, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0xC3, Operation: Dcp, Addressing: IndX},
+	{Opcode: 0xD3, Operation: Dcp, Addressing: IndY, CycleAdjust: 1},
+	/*
+		ISC (ISB, INS)
+		addressing    assembler    opc  bytes  cycles
+		zeropage      ISC $FF      E7   2      5
+		zeropage,X    ISC $FF,X    F7   2      6
+		absolute      ISC $FFFF    EF   3      6
+		absolute,X    ISC $FFFF,X  FF   3      7
+		absolute,Y    ISC $FFFF,Y  FB   3      7
+		(indirect,X)  ISC ($FF,X)  E3   2      8
+		(indirect),Y  ISC ($FF),Y  F3   2      8
+	*/
+	{Opcode: 0xE7, Operation: Isc, Addressing: Zpg},
+	{Opcode: 0xF7, Operation: Isc, Addressing: ZpgX},
+	{Opcode: 0xEF, Operation: Isc, Addressing: Abs},
+	{Opcode: 0xFF, Operation: Isc, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0xFB, Operation: Isc, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0xE3, Operation: Isc, Addressing: IndX},
+	{Opcode: 0xF3, Operation: Isc, Addressing: IndY, CycleAdjust: 1},
+	/*
+		JAM (KIL, HLT)
+		addressing  assembler  opc  bytes  cycles
+		implied     JAM        02   1      -
+		implied     JAM        12   1      -
+		implied     JAM        22   1      -
+		implied     JAM        32   1      -
+		implied     JAM        42   1      -
+		implied     JAM        52   1      -
+		implied     JAM        62   1      -
+		implied     JAM        72   1      -
+		implied     JAM        92   1      -
+		implied     JAM        B2   1      -
+		implied     JAM        D2   1      -
+		implied     JAM        F2   1      -
+	*/
+	{Opcode: 0x02, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x12, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x22, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x32, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x42, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x52, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x62, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x72, Operation: Jam, Addressing: Imp},
+	{Opcode: 0x92, Operation: Jam, Addressing: Imp},
+	{Opcode: 0xB2, Operation: Jam, Addressing: Imp},
+	{Opcode: 0xD2, Operation: Jam, Addressing: Imp},
+	{Opcode: 0xF2, Operation: Jam, Addressing: Imp},
+	/*
+		LAS (LAR)
+		addressing  assembler    opc  bytes  cycles
+		absolute,Y  LAS $FFFF,Y  BB   3      4*
+	*/
+	{Opcode: 0xBB, Operation: Las, Addressing: AbsY},
+	/*
+		LAX
+		addressing    assembler    opc  bytes  cycles
+		zeropage      LAX $FF      A7   2      3
+		zeropage,Y    LAX $FF,Y    B7   2      4
+		absolute      LAX $FFFF    AF   3      4
+		absolute,Y    LAX $FFFF,Y  BF   3      4*
+		(indirect,X)  LAX ($FF,X)  A3   2      6
+		(indirect),Y  LAX ($FF),Y  B3   2      5*
+	*/
+	{Opcode: 0xA7, Operation: Lax, Addressing: Zpg},
+	{Opcode: 0xB7, Operation: Lax, Addressing: ZpgY},
+	{Opcode: 0xAF, Operation: Lax, Addressing: Abs},
+	{Opcode: 0xBF, Operation: Lax, Addressing: AbsY},
+	{Opcode: 0xA3, Operation: Lax, Addressing: IndX},
+	{Opcode: 0xB3, Operation: Lax, Addressing: IndY},
+	/*
+		LXA (LAX immediate)
+		addressing  assembler  opc  bytes  cycles
+		immediate   LXA #$FF   AB   2      2
+	*/
+	{Opcode: lxaOpcode, Operation: Lxa, Addressing: Imm},
+	/*
+		NOP (DOP, TOP)
+		addressing  assembler    opc                bytes  cycles
+		implied     NOP          1A,3A,5A,7A,DA,FA  1      2
+		immediate   NOP #$FF     80,82,89,C2,E2     2      2
+		zeropage    NOP $FF      04,44,64           2      3
+		zeropage,X  NOP $FF,X    14,34,54,74,D4,F4  2      4
+		absolute    NOP $FFFF    0C                 3      4
+		absolute,X  NOP $FFFF,X  1C,3C,5C,7C,DC,FC  3      4*
+	*/
+	{Opcode: 0x1A, Operation: Nop, Addressing: Imp},
+	{Opcode: 0x3A, Operation: Nop, Addressing: Imp},
+	{Opcode: 0x5A, Operation: Nop, Addressing: Imp},
+	{Opcode: 0x7A, Operation: Nop, Addressing: Imp},
+	{Opcode: 0xDA, Operation: Nop, Addressing: Imp},
+	{Opcode: 0xFA, Operation: Nop, Addressing: Imp},
+	{Opcode: 0x80, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x82, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x89, Operation: Nom, Addressing: Imm},
+	{Opcode: 0xC2, Operation: Nom, Addressing: Imm},
+	{Opcode: 0xE2, Operation: Nom, Addressing: Imm},
+	{Opcode: 0x04, Operation: Nom, Addressing: Zpg},
+	{Opcode: 0x44, Operation: Nom, Addressing: Zpg},
+	{Opcode: 0x64, Operation: Nom, Addressing: Zpg},
+	{Opcode: 0x14, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0x34, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0x54, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0x74, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0xD4, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0xF4, Operation: Nom, Addressing: ZpgX},
+	{Opcode: 0x0C, Operation: Nom, Addressing: Abs},
+	{Opcode: 0x1C, Operation: Nom, Addressing: AbsX},
+	{Opcode: 0x3C, Operation: Nom, Addressing: AbsX},
+	{Opcode: 0x5C, Operation: Nom, Addressing: AbsX},
+	{Opcode: 0x7C, Operation: Nom, Addressing: AbsX},
+	{Opcode: 0xDC, Operation: Nom, Addressing: AbsX},
+	{Opcode: 0xFC, Operation: Nom, Addressing: AbsX},
+	/*
+		RLA
+		addressing    assembler    opc  bytes  cycles
+		zeropage      RLA $FF      27   2      5
+		zeropage,X    RLA $FF,X    37   2      6
+		absolute      RLA $FFFF    2F   3      6
+		absolute,X    RLA $FFFF,X  3F   3      7
+		absolute,Y    RLA $FFFF,Y  3B   3      7
+		(indirect,X)  RLA ($FF,X)  23   2      8
+		(indirect),Y  RLA ($FF),Y  33   2      8
+	*/
+	{Opcode: 0x27, Operation: Rla, Addressing: Zpg},
+	{Opcode: 0x37, Operation: Rla, Addressing: ZpgX},
+	{Opcode: 0x2F, Operation: Rla, Addressing: Abs},
+	{Opcode: 0x3F, Operation: Rla, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x3B, Operation: Rla, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0x23, Operation: Rla, Addressing: IndX},
+	{Opcode: 0x33, Operation: Rla, Addressing: IndY, CycleAdjust: 1},
+	/*
+		RRA
+		addressing    assembler    opc  bytes  cycles
+		zeropage      RRA $FF      67   2      5
+		zeropage,X    RRA $FF,X    77   2      6
+		absolute      RRA $FFFF    6F   3      6
+		absolute,X    RRA $FFFF,X  7F   3      7
+		absolute,Y    RRA $FFFF,Y  7B   3      7
+		(indirect,X)  RRA ($FF,X)  63   2      8
+		(indirect),Y  RRA ($FF),Y  73   2      8
+	*/
+	{Opcode: 0x67, Operation: Rra, Addressing: Zpg},
+	{Opcode: 0x77, Operation: Rra, Addressing: ZpgX},
+	{Opcode: 0x6F, Operation: Rra, Addressing: Abs},
+	{Opcode: 0x7F, Operation: Rra, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x7B, Operation: Rra, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0x63, Operation: Rra, Addressing: IndX},
+	{Opcode: 0x73, Operation: Rra, Addressing: IndY, CycleAdjust: 1},
+	/*
+		SAX (AXS, AAX)
+		addressing    assembler    opc  bytes  cycles
+		zeropage      SAX $FF      87   2      3
+		zeropage,Y    SAX $FF,Y    97   2      4
+		absolute      SAX $FFFF    8F   3      4
+		(indirect,X)  SAX ($FF,X)  83   2      6
+	*/
+	{Opcode: 0x87, Operation: Sax, Addressing: Zpg},
+	{Opcode: 0x97, Operation: Sax, Addressing: ZpgY},
+	{Opcode: 0x8F, Operation: Sax, Addressing: Abs},
+	{Opcode: 0x83, Operation: Sax, Addressing: IndX},
+	/*
+		SBX (AXS, SAX)
+		addressing  assembler  opc  bytes  cycles
+		immediate   SBX #$FF   CB   2      2
+	*/
+	{Opcode: 0xCB, Operation: Sbx, Addressing: Imm},
+	/*
+		SHA (AHX, AXA)
+		addressing    assembler    opc  bytes  cycles
+		absolute,Y    SHA $FFFF,Y  9F   3      5
+		(indirect),Y  SHA ($FF),Y  93   2      6
+	*/
+	{Opcode: 0x9F, Operation: Sha, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0x93, Operation: Sha, Addressing: IndY, CycleAdjust: 1},
+	/*
+		SHX (SXA, XAS)
+		addressing  assembler    opc  bytes  cycles
+		absolute,Y  SHX $FFFF,Y  9E   3      5
+	*/
+	{Opcode: 0x9E, Operation: Shx, Addressing: AbsY, CycleAdjust: 1},
+	/*
+		SHY (SYA, SAY)
+		addressing  assembler    opc  bytes  cycles
+		absolute,X  SHY $FFFF,X  9C   3      5
+	*/
+	{Opcode: 0x9C, Operation: Shy, Addressing: AbsX, CycleAdjust: 1},
+	/*
+		SLO (ASO)
+		addressing    assembler    opc  bytes  cycles
+		zeropage      SLO $FF      07   2      5
+		zeropage,X    SLO $FF,X    17   2      6
+		absolute      SLO $FFFF    0F   3      6
+		absolute,X    SLO $FFFF,X  1F   3      7
+		absolute,Y    SLO $FFFF,Y  1B   3      7
+		(indirect,X)  SLO ($FF,X)  03   2      8
+		(indirect),Y  SLO ($FF),Y  13   2      8
+	*/
+	{Opcode: 0x07, Operation: Slo, Addressing: Zpg},
+	{Opcode: 0x17, Operation: Slo, Addressing: ZpgX},
+	{Opcode: 0x0F, Operation: Slo, Addressing: Abs},
+	{Opcode: 0x1F, Operation: Slo, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x1B, Operation: Slo, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0x03, Operation: Slo, Addressing: IndX},
+	{Opcode: 0x13, Operation: Slo, Addressing: IndY, CycleAdjust: 1},
+	/*
+		SRE (LSE)
+		addressing    assembler    opc  bytes  cycles
+		zeropage      SRE $FF      47   2      5
+		zeropage,X    SRE $FF,X    57   2      6
+		absolute      SRE $FFFF    4F   3      6
+		absolute,X    SRE $FFFF,X  5F   3      7
+		absolute,Y    SRE $FFFF,Y  5B   3      7
+		(indirect,X)  SRE ($FF,X)  43   2      8
+		(indirect),Y  SRE ($FF),Y  53   2      8
+	*/
+	{Opcode: 0x47, Operation: Sre, Addressing: Zpg},
+	{Opcode: 0x57, Operation: Sre, Addressing: ZpgX},
+	{Opcode: 0x4F, Operation: Sre, Addressing: Abs},
+	{Opcode: 0x5F, Operation: Sre, Addressing: AbsX, CycleAdjust: 1},
+	{Opcode: 0x5B, Operation: Sre, Addressing: AbsY, CycleAdjust: 1},
+	{Opcode: 0x43, Operation: Sre, Addressing: IndX},
+	{Opcode: 0x53, Operation: Sre, Addressing: IndY, CycleAdjust: 1},
+	/*
+		TAS (SHS, XAS)
+		addressing  assembler    opc  bytes  cycles
+		absolute,Y  TAS $FFFF,Y  9B   3      5
+	*/
+	{Opcode: 0x9B, Operation: Tas, Addressing: AbsY, CycleAdjust: 1},
+	/*
+		USBC (SBC)
+		addressing  assembler   opc  bytes  cycles
+		immediate   USBC #$FF   EB   2      2
+	*/
+	{Opcode: 0xEB, Operation: Usbc, Addressing: Imm},
+}
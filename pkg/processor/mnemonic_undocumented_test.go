@@ -0,0 +1,143 @@
+package processor
+
+import (
+	"testing"
+)
+
+func TestAllUndocumentedOpcodes(t *testing.T) {
+	type expected struct {
+		bytes   uint
+		cycles  uint
+		penalty bool
+	}
+
+	// Timings from https://www.masswerk.at/6502/6502_instruction_set.html#illegals
+	want := map[Opcode]expected{
+		// ALR, ANC, ANE, ARR, LXA, SBX and USBC.
+		0x4B: {2, 2, false}, 0x0B: {2, 2, false}, 0x2B: {2, 2, false}, 0x8B: {2, 2, false},
+		0x6B: {2, 2, false}, 0xAB: {2, 2, false}, 0xCB: {2, 2, false}, 0xEB: {2, 2, false},
+		// DCP
+		0xC7: {2, 5, false}, 0xD7: {2, 6, false}, 0xCF: {3, 6, false}, 0xDF: {3, 7, false},
+		0xDB: {3, 7, false}, 0xC3: {2, 8, false}, 0xD3: {2, 8, false},
+		// ISC
+		0xE7: {2, 5, false}, 0xF7: {2, 6, false}, 0xEF: {3, 6, false}, 0xFF: {3, 7, false},
+		0xFB: {3, 7, false}, 0xE3: {2, 8, false}, 0xF3: {2, 8, false},
+		// JAM
+		0x02: {1, 2, false}, 0x12: {1, 2, false}, 0x22: {1, 2, false}, 0x32: {1, 2, false},
+		0x42: {1, 2, false}, 0x52: {1, 2, false}, 0x62: {1, 2, false}, 0x72: {1, 2, false},
+		0x92: {1, 2, false}, 0xB2: {1, 2, false}, 0xD2: {1, 2, false}, 0xF2: {1, 2, false},
+		// LAS
+		0xBB: {3, 4, true},
+		// LAX
+		0xA7: {2, 3, false}, 0xB7: {2, 4, false}, 0xAF: {3, 4, false}, 0xBF: {3, 4, true},
+		0xA3: {2, 6, false}, 0xB3: {2, 5, true},
+		// NOP
+		0x1A: {1, 2, false}, 0x3A: {1, 2, false}, 0x5A: {1, 2, false}, 0x7A: {1, 2, false},
+		0xDA: {1, 2, false}, 0xFA: {1, 2, false}, 0x80: {2, 2, false}, 0x82: {2, 2, false},
+		0x89: {2, 2, false}, 0xC2: {2, 2, false}, 0xE2: {2, 2, false}, 0x04: {2, 3, false},
+		0x44: {2, 3, false}, 0x64: {2, 3, false}, 0x14: {2, 4, false}, 0x34: {2, 4, false},
+		0x54: {2, 4, false}, 0x74: {2, 4, false}, 0xD4: {2, 4, false}, 0xF4: {2, 4, false},
+		0x0C: {3, 4, false}, 0x1C: {3, 4, true}, 0x3C: {3, 4, true}, 0x5C: {3, 4, true},
+		0x7C: {3, 4, true}, 0xDC: {3, 4, true}, 0xFC: {3, 4, true},
+		// RLA
+		0x27: {2, 5, false}, 0x37: {2, 6, false}, 0x2F: {3, 6, false}, 0x3F: {3, 7, false},
+		0x3B: {3, 7, false}, 0x23: {2, 8, false}, 0x33: {2, 8, false},
+		// RRA
+		0x67: {2, 5, false}, 0x77: {2, 6, false}, 0x6F: {3, 6, false}, 0x7F: {3, 7, false},
+		0x7B: {3, 7, false}, 0x63: {2, 8, false}, 0x73: {2, 8, false},
+		// SAX
+		0x87: {2, 3, false}, 0x97: {2, 4, false}, 0x8F: {3, 4, false}, 0x83: {2, 6, false},
+		// SHA, SHX, SHY and TAS
+		0x9F: {3, 5, false}, 0x93: {2, 6, false}, 0x9E: {3, 5, false}, 0x9C: {3, 5, false},
+		0x9B: {3, 5, false},
+		// SLO
+		0x07: {2, 5, false}, 0x17: {2, 6, false}, 0x0F: {3, 6, false}, 0x1F: {3, 7, false},
+		0x1B: {3, 7, false}, 0x03: {2, 8, false}, 0x13: {2, 8, false},
+		// SRE
+		0x47: {2, 5, false}, 0x57: {2, 6, false}, 0x4F: {3, 6, false}, 0x5F: {3, 7, false},
+		0x5B: {3, 7, false}, 0x43: {2, 8, false}, 0x53: {2, 8, false},
+	}
+
+	mnemonics := AllUndocumentedOpcodes(DefaultMagicConstants)
+	if len(mnemonics) != len(want) {
+		t.Errorf("AllUndocumentedOpcodes() got %v opcodes, want %v", len(mnemonics), len(want))
+	}
+
+	for _, mnemonic := range mnemonics {
+		if _, err := MnemonicFromOpCode(mnemonic.Opcode); err == nil {
+			t.Errorf("AllUndocumentedOpcodes() opcode $%02X is a legal opcode", mnemonic.Opcode)
+		}
+
+		w, ok := want[mnemonic.Opcode]
+		if !ok {
+			t.Errorf("AllUndocumentedOpcodes() unexpected opcode $%02X", mnemonic.Opcode)
+			continue
+		}
+
+		detail := NewMnemonicDisplayDetails(mnemonic)
+		got := expected{detail.Bytes, detail.Cycles, detail.PageBoundaryPenalty}
+		if got != w {
+			t.Errorf("AllUndocumentedOpcodes() opcode $%02X (%v) got = %+v, want = %+v", mnemonic.Opcode, detail.Assembler, got, w)
+		}
+
+		instruction := NewInstruction(mnemonic)
+		if instruction.Mode == UnknownMode || instruction.Type == UnknownOperation {
+			t.Errorf("AllUndocumentedOpcodes() opcode $%02X has no bus information", mnemonic.Opcode)
+		}
+	}
+}
+
+func TestAllUndocumentedOpcodesMagicConstants(t *testing.T) {
+	tests := []struct {
+		name      string
+		constants MagicConstants
+		opcode    Opcode
+		state     State
+		wantState State
+	}{
+		{
+			name:      "ANE uses the default magic constant",
+			constants: DefaultMagicConstants,
+			opcode:    aneOpcode,
+			state:     State{X: 0xFF},
+			wantState: State{A: 0xEE, X: 0xFF, P: FlagNegative},
+		},
+		{
+			name:      "ANE uses the provided magic constant",
+			constants: MagicConstants{Ane: 0x11},
+			opcode:    aneOpcode,
+			state:     State{X: 0xFF},
+			wantState: State{A: 0x11, X: 0xFF},
+		},
+		{
+			name:      "LXA uses the default magic constant",
+			constants: DefaultMagicConstants,
+			opcode:    lxaOpcode,
+			wantState: State{A: 0xEE, X: 0xEE, P: FlagNegative},
+		},
+		{
+			name:      "LXA uses the provided magic constant",
+			constants: MagicConstants{Lxa: 0x00},
+			opcode:    lxaOpcode,
+			wantState: State{P: FlagZero},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, mnemonic := range AllUndocumentedOpcodes(tt.constants) {
+				if mnemonic.Opcode != tt.opcode {
+					continue
+				}
+				got, err := mnemonic.Operation.Operation(tt.state, &Addressing{Value: 0xFF})
+				if err != nil {
+					t.Fatalf("unexpected error = %v", err)
+				}
+				if got != tt.wantState {
+					t.Errorf("State got = %v, want = %v", got, tt.wantState)
+				}
+				return
+			}
+			t.Errorf("opcode $%02X not found", tt.opcode)
+		})
+	}
+}
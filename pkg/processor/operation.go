@@ -8,57 +8,67 @@ package processor
 // starting State and the State returned by addressing. The new CPU State is
 // returned. The PC of the input State will be pointing to the next instruction
 // to execute.
-type Operation func(State, Addressing) (State, error)
+type Operation func(State, *Addressing) (State, error)
 
 // AddWithCarry (ADC). ADC results are dependent on the setting of the decimal flag.
 // In decimal mode, addition is carried out on the assumption that the values involved
 // are packed BCD (Binary Coded Decimal). There is no way to add without carry.
 //
-// Implementation borrowed from testADC() in https://github.com/skilldrick/easy6502/blob/gh-pages/simulator/assembler.js
+// In decimal mode the accumulator and carry flag hold the BCD result, including the
+// results for invalid BCD values. As on the NMOS 6502 the negative and overflow flags
+// reflect the result before the upper nibble is adjusted for the decimal carry and the
+// zero flag reflects the result of the equivalent binary addition.
 //
 //	See: APPENDIX A: WHAT ABOUT INVALID BCD VALUES AND INVALID FLAGS?
 //	At: http://www.6502.org/tutorials/decimal_mode.html#A
-func AddWithCarry(state State, addressing Addressing) (State, error) {
+func AddWithCarry(state State, addressing *Addressing) (State, error) {
 	accum := uint16(state.A)
 	value := uint16(addressing.Value)
 	carry := uint16(0)
-	result := uint16(0)
 
 	if state.P.ToFlags().Carry {
 		carry = 1
 	}
 
-	if state.P.ToFlags().Decimal {
-		// Do the lower nibble first.
-		result = (accum & 0x0F) + (value & 0x0F) + carry
+	binary := accum + value + carry
 
-		if result >= 10 {
-			result = 0x10 | ((result + 0x06) & 0x0F)
-		}
+	if !state.P.ToFlags().Decimal {
+		overflowSet(&state.P, accum, value, binary)
+		carrySet(&state.P, binary)
+		negativeSet(&state.P, binary)
+		zeroSet(&state.P, binary)
 
-		// Do the upper nibble.
-		result += (accum & 0xF0) + (value & 0xF0)
+		state.A = uint8(binary)
+		return state, nil
+	}
 
-		// Adjust for carry in decimal mode.
-		if result >= 0xA0 {
-			result += 0x60
-		}
+	// Do the lower nibble first.
+	lower := (accum & 0x0F) + (value & 0x0F) + carry
 
-	} else {
-		result = accum + value + carry
+	if lower >= 0x0A {
+		lower = ((lower + 0x06) & 0x0F) + 0x10
 	}
 
-	overflowSet(&state.P, accum, value, result)
-	carrySet(&state.P, result)
+	// Do the upper nibble.
+	result := (accum & 0xF0) + (value & 0xF0) + lower
+
 	negativeSet(&state.P, result)
-	zeroSet(&state.P, result)
+	decimalOverflowSet(&state.P, accum, value, lower)
+
+	// Adjust for carry in decimal mode.
+	if result >= 0xA0 {
+		result += 0x60
+	}
+
+	carrySet(&state.P, result)
+	zeroSet(&state.P, binary)
 
 	state.A = uint8(result)
 	return state, nil
 }
 
 // AndWithA (AND). Bitwise AND memory with accumulator register A.
-func AndWithA(state State, addressing Addressing) (State, error) {
+func AndWithA(state State, addressing *Addressing) (State, error) {
 
 	state.A = addressing.Value & state.A
 
@@ -69,7 +79,7 @@ func AndWithA(state State, addressing Addressing) (State, error) {
 }
 
 // ArithmeticShiftLeft (ASL).Shift Left One Bit (Memory or Accumulator).
-func ArithmeticShiftLeft(state State, addressing Addressing) (State, error) {
+func ArithmeticShiftLeft(state State, addressing *Addressing) (State, error) {
 
 	value := uint16(addressing.Value) << 1
 
@@ -80,86 +90,57 @@ func ArithmeticShiftLeft(state State, addressing Addressing) (State, error) {
 	return addressing.Store(state, uint8(value&0x00FF))
 }
 
-// TODO: A branch not taken requires two machine cycles. Add one if the branch is taken and add one more if the branch crosses a page boundary.
-
-// BranchOnCarryClear (BCC). Branch on Carry flag not set.
-func BranchOnCarryClear(state State, addressing Addressing) (State, error) {
-
-	if !state.P.ToFlags().Carry {
+// branch moves the program counter to the effective address if the condition is met.
+// Taking the branch is reported in Addressing so the Cpu can apply the cycle penalties:
+// a branch not taken requires two machine cycles, one is added if the branch is taken
+// and one more is added if the branch crosses a page boundary.
+func branch(state State, addressing *Addressing, condition bool) (State, error) {
+	if condition {
 		state.PC = addressing.EffectiveAddress
+		addressing.BranchTaken = true
 	}
 
 	return state, nil
 }
 
-// BranchOnCarrySet (BCS). Branch on Carry flag set.
-func BranchOnCarrySet(state State, addressing Addressing) (State, error) {
-
-	if state.P.ToFlags().Carry {
-		state.PC = addressing.EffectiveAddress
-	}
+// BranchOnCarryClear (BCC). Branch on Carry flag not set.
+func BranchOnCarryClear(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, !state.P.ToFlags().Carry)
+}
 
-	return state, nil
+// BranchOnCarrySet (BCS). Branch on Carry flag set.
+func BranchOnCarrySet(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P.ToFlags().Carry)
 }
 
 // BranchOnEqual (BEQ). Branch on Zero flag not set.
-func BranchOnEqual(state State, addressing Addressing) (State, error) {
-
-	if state.P.ToFlags().Zero {
-		state.PC = addressing.EffectiveAddress
-	}
-
-	return state, nil
+func BranchOnEqual(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P.ToFlags().Zero)
 }
 
 // BranchOnMinus (BMI). Branch on Negative flag set.
-func BranchOnMinus(state State, addressing Addressing) (State, error) {
-
-	if state.P.ToFlags().Negative {
-		state.PC = addressing.EffectiveAddress
-	}
-
-	return state, nil
+func BranchOnMinus(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P.ToFlags().Negative)
 }
 
 // BranchOnNotEqual (BNE). Branch on Zero flag not set.
-func BranchOnNotEqual(state State, addressing Addressing) (State, error) {
-
-	if !state.P.ToFlags().Zero {
-		state.PC = addressing.EffectiveAddress
-	}
-
-	return state, nil
+func BranchOnNotEqual(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, !state.P.ToFlags().Zero)
 }
 
 // BranchOnOverflowClear (BVC). Branch on Carry flag not set.
-func BranchOnOverflowClear(state State, addressing Addressing) (State, error) {
-
-	if !state.P.ToFlags().Overflow {
-		state.PC = addressing.EffectiveAddress
-	}
-
-	return state, nil
+func BranchOnOverflowClear(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, !state.P.ToFlags().Overflow)
 }
 
 // BranchOnOverflowSet (BVS). Branch on Overflow flag set.
-func BranchOnOverflowSet(state State, addressing Addressing) (State, error) {
-
-	if state.P.ToFlags().Overflow {
-		state.PC = addressing.EffectiveAddress
-	}
-
-	return state, nil
+func BranchOnOverflowSet(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P.ToFlags().Overflow)
 }
 
 // BranchOnPlus (BPL). Branch on Plus; Negative flag not set.
-func BranchOnPlus(state State, addressing Addressing) (State, error) {
-
-	if !state.P.ToFlags().Negative {
-		state.PC = addressing.EffectiveAddress
-	}
-
-	return state, nil
+func BranchOnPlus(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, !state.P.ToFlags().Negative)
 }
 
 // Break (BRK). Break initiates a non-maskable software interrupt similar to a
@@ -175,7 +156,7 @@ func BranchOnPlus(state State, addressing Addressing) (State, error) {
 // is not set automatically.
 //
 // See also Nmi() and Interrupt()  which are very similar.
-func Break(state State, addressing Addressing) (State, error) {
+func Break(state State, addressing *Addressing) (State, error) {
 
 	// Get the interrupt vector from memory. We do this first to avoid
 	// the vector being overwritten in tests that use a tiny memory and
@@ -205,25 +186,25 @@ func Break(state State, addressing Addressing) (State, error) {
 }
 
 // ClearCarry (CLC). Clear carry flag.
-func ClearCarry(state State, _ Addressing) (State, error) {
+func ClearCarry(state State, _ *Addressing) (State, error) {
 	state.P.ClearCarry()
 	return state, nil
 }
 
 // ClearDecimal (CLD). Clear decimal flag.
-func ClearDecimal(state State, _ Addressing) (State, error) {
+func ClearDecimal(state State, _ *Addressing) (State, error) {
 	state.P.ClearDecimal()
 	return state, nil
 }
 
 // ClearInterrupt (CLI). Clear interrupt flag.
-func ClearInterrupt(state State, _ Addressing) (State, error) {
+func ClearInterrupt(state State, _ *Addressing) (State, error) {
 	state.P.ClearInterrupt()
 	return state, nil
 }
 
 // ClearOverflow (CLV). Clear overflow flag.
-func ClearOverflow(state State, _ Addressing) (State, error) {
+func ClearOverflow(state State, _ *Addressing) (State, error) {
 	state.P.ClearOverflow()
 	return state, nil
 }
@@ -250,25 +231,25 @@ func compare(s *Status, register, value uint8) {
 // had been carried out. If the value in the accumulator is equal or greater than the compared
 // value, the Carry will be set. The equal (Z) and negative (N) flags will be set based on
 // equality or lack thereof and the sign (i.e. A>=$80) of the accumulator.
-func CompareWithA(state State, addressing Addressing) (State, error) {
+func CompareWithA(state State, addressing *Addressing) (State, error) {
 	compare(&state.P, state.A, addressing.Value)
 	return state, nil
 }
 
 // CompareWithX (CPX). Compare memory with X. Same rules as CompareWithAccumulator but based on X.
-func CompareWithX(state State, addressing Addressing) (State, error) {
+func CompareWithX(state State, addressing *Addressing) (State, error) {
 	compare(&state.P, state.X, addressing.Value)
 	return state, nil
 }
 
 // CompareWithY (CPY). Compare memory with Y. Same rules as CompareWithAccumulator but based on Y.
-func CompareWithY(state State, addressing Addressing) (State, error) {
+func CompareWithY(state State, addressing *Addressing) (State, error) {
 	compare(&state.P, state.Y, addressing.Value)
 	return state, nil
 }
 
 // Decrement (DEC). Decrement Memory by One.
-func Decrement(state State, addressing Addressing) (State, error) {
+func Decrement(state State, addressing *Addressing) (State, error) {
 
 	value := addressing.Value - 1
 
@@ -279,7 +260,7 @@ func Decrement(state State, addressing Addressing) (State, error) {
 }
 
 // DecrementX (DEX). Decrement Index X by One.
-func DecrementX(state State, _ Addressing) (State, error) {
+func DecrementX(state State, _ *Addressing) (State, error) {
 
 	state.X--
 
@@ -290,7 +271,7 @@ func DecrementX(state State, _ Addressing) (State, error) {
 }
 
 // DecrementY (DEY). Decrement Index Y by One.
-func DecrementY(state State, _ Addressing) (State, error) {
+func DecrementY(state State, _ *Addressing) (State, error) {
 
 	state.Y--
 
@@ -301,7 +282,7 @@ func DecrementY(state State, _ Addressing) (State, error) {
 }
 
 // ExclusiveOrWithA (EOR). Exclusive or memory with accumulator register A.
-func ExclusiveOrWithA(state State, addressing Addressing) (State, error) {
+func ExclusiveOrWithA(state State, addressing *Addressing) (State, error) {
 
 	state.A = addressing.Value ^ state.A
 
@@ -312,7 +293,7 @@ func ExclusiveOrWithA(state State, addressing Addressing) (State, error) {
 }
 
 // Increment (INC). Increment memory by One.
-func Increment(state State, addressing Addressing) (State, error) {
+func Increment(state State, addressing *Addressing) (State, error) {
 
 	value := addressing.Value + 1
 
@@ -323,7 +304,7 @@ func Increment(state State, addressing Addressing) (State, error) {
 }
 
 // IncrementX (INX). Increment index X by One.
-func IncrementX(state State, _ Addressing) (State, error) {
+func IncrementX(state State, _ *Addressing) (State, error) {
 
 	state.X++
 
@@ -334,7 +315,7 @@ func IncrementX(state State, _ Addressing) (State, error) {
 }
 
 // IncrementY (INY). Increment index Y by One.
-func IncrementY(state State, _ Addressing) (State, error) {
+func IncrementY(state State, _ *Addressing) (State, error) {
 
 	state.Y++
 
@@ -347,7 +328,7 @@ func IncrementY(state State, _ Addressing) (State, error) {
 // Interrupt performs a hardware interrupt. This is similar in operation to break
 // except it does not advance the program counter before pushing and does not set
 // the break flag before pushing the status register to the stack. See also Nmi().
-func Interrupt(state State, addressing Addressing) (State, error) {
+func Interrupt(state State, addressing *Addressing) (State, error) {
 
 	// Get the interrupt vector from memory. We do this first to avoid
 	// the vector being overwritten in tests that use a tiny memory and
@@ -375,7 +356,7 @@ func Interrupt(state State, addressing Addressing) (State, error) {
 }
 
 // Jump (JMP). Jump to new Location.
-func Jump(state State, addressing Addressing) (State, error) {
+func Jump(state State, addressing *Addressing) (State, error) {
 
 	state.PC = addressing.EffectiveAddress
 	return state, nil
@@ -383,7 +364,7 @@ func Jump(state State, addressing Addressing) (State, error) {
 
 // JumpSubRoutine (JSR). Jump to new location saving return address onto the stack. The
 // return address that is pushed is the program counter - 1.
-func JumpSubRoutine(state State, addressing Addressing) (State, error) {
+func JumpSubRoutine(state State, addressing *Addressing) (State, error) {
 
 	addressToPush := state.PC - 1
 	state.PC = addressing.EffectiveAddress
@@ -391,7 +372,7 @@ func JumpSubRoutine(state State, addressing Addressing) (State, error) {
 }
 
 // LoadA (LDA). Load accumulator with memory.
-func LoadA(state State, addressing Addressing) (State, error) {
+func LoadA(state State, addressing *Addressing) (State, error) {
 
 	state.A = addressing.Value
 
@@ -402,7 +383,7 @@ func LoadA(state State, addressing Addressing) (State, error) {
 }
 
 // LoadX (LDX). Load index X with memory.
-func LoadX(state State, addressing Addressing) (State, error) {
+func LoadX(state State, addressing *Addressing) (State, error) {
 
 	state.X = addressing.Value
 
@@ -413,7 +394,7 @@ func LoadX(state State, addressing Addressing) (State, error) {
 }
 
 // LoadY (LDY). Load index Y with memory.
-func LoadY(state State, addressing Addressing) (State, error) {
+func LoadY(state State, addressing *Addressing) (State, error) {
 
 	state.Y = addressing.Value
 
@@ -425,7 +406,7 @@ func LoadY(state State, addressing Addressing) (State, error) {
 
 // LogicalShiftRight (LSR). Shift one bit right (memory or accumulator). Bit 7 is set to zero and bit
 // zero is shifted into the carry flag.
-func LogicalShiftRight(state State, addressing Addressing) (State, error) {
+func LogicalShiftRight(state State, addressing *Addressing) (State, error) {
 
 	value := uint16(addressing.Value) >> 1
 
@@ -443,7 +424,7 @@ func LogicalShiftRight(state State, addressing Addressing) (State, error) {
 
 // Nmi performs a non-maskable interrupt. This is very similar to Break and
 // Interrupt but uses a different vector.
-func Nmi(state State, addressing Addressing) (State, error) {
+func Nmi(state State, addressing *Addressing) (State, error) {
 
 	// Get the nmi vector from memory. We do this first to avoid
 	// the vector being overwritten in tests that use a tiny memory and
@@ -472,12 +453,21 @@ func Nmi(state State, addressing Addressing) (State, error) {
 
 // NoOperation simply returns the State passed in and does not access the
 // memory nor use the value.
-func NoOperation(state State, _ Addressing) (State, error) {
+func NoOperation(state State, _ *Addressing) (State, error) {
 	return state, nil
 }
 
+// Trap returns an operation for the opcode that always returns a TrapError. It must be
+// used with Implied addressing, so the PC of the opcode is the one before the State passed
+// in. The Cpu does not apply the State returned with the error.
+func Trap(opcode Opcode) Operation {
+	return func(state State, _ *Addressing) (State, error) {
+		return state, TrapError{PC: state.PC - 1, Opcode: opcode, State: state}
+	}
+}
+
 // OrWithA (ORA) performs a bitwise OR with Accumulator.
-func OrWithA(state State, addressing Addressing) (State, error) {
+func OrWithA(state State, addressing *Addressing) (State, error) {
 
 	state.A = addressing.Value | state.A
 
@@ -488,7 +478,7 @@ func OrWithA(state State, addressing Addressing) (State, error) {
 }
 
 // PushA (PHA) pushes the accumulator onto the stack.
-func PushA(state State, addressing Addressing) (State, error) {
+func PushA(state State, addressing *Addressing) (State, error) {
 
 	state, err := addressing.PushByte(state, state.A)
 	if err != nil {
@@ -499,7 +489,7 @@ func PushA(state State, addressing Addressing) (State, error) {
 
 // PushP (PHP) pushes the processor status register onto the stack. The status register will
 // be pushed with the break flag (and constant flag) set.
-func PushP(state State, addressing Addressing) (State, error) {
+func PushP(state State, addressing *Addressing) (State, error) {
 
 	state, err := addressing.PushStatus(state, state.P.WithBreakSet())
 	if err != nil {
@@ -510,7 +500,7 @@ func PushP(state State, addressing Addressing) (State, error) {
 
 // PullA (PLA) pulls the accumulator from the stack. This will set the sign and zero
 // flags based on the result pulled.
-func PullA(state State, addressing Addressing) (State, error) {
+func PullA(state State, addressing *Addressing) (State, error) {
 
 	state, value, err := addressing.PullByte(state)
 	if err != nil {
@@ -535,7 +525,7 @@ func PullA(state State, addressing Addressing) (State, error) {
 // Since there is no actual slot for the break flag, it will be always ignored, when
 // retrieved (PLP or RTI). The break flag is not accessed by the CPU at anytime and
 // there is no internal representation.
-func PullP(state State, addressing Addressing) (State, error) {
+func PullP(state State, addressing *Addressing) (State, error) {
 	state, err := addressing.PullStatus(state)
 	if err != nil {
 		return state, err
@@ -545,7 +535,7 @@ func PullP(state State, addressing Addressing) (State, error) {
 
 // RotateLeft (ROL) shifts all bits left one position. The Carry is
 // shifted into bit 0 and the original bit 7 is shifted into the Carry.
-func RotateLeft(state State, addressing Addressing) (State, error) {
+func RotateLeft(state State, addressing *Addressing) (State, error) {
 
 	value := uint16(addressing.Value) << 1
 	if state.P.ToFlags().Carry {
@@ -561,7 +551,7 @@ func RotateLeft(state State, addressing Addressing) (State, error) {
 
 // RotateRight (ROR) shifts all bits right one position. The Carry is
 // shifted into bit 7 and the original bit 0 is shifted into the Carry.
-func RotateRight(state State, addressing Addressing) (State, error) {
+func RotateRight(state State, addressing *Addressing) (State, error) {
 
 	value := uint16(addressing.Value)
 
@@ -584,11 +574,37 @@ func RotateRight(state State, addressing Addressing) (State, error) {
 	return addressing.Store(state, uint8(value&0x00FF))
 }
 
+// Reset performs the reset sequence once the opcode fetch and the following read have
+// taken place. The three stack pushes are suppressed, being performed as reads, so the
+// stack pointer is decremented by three without changing memory. The interrupt flag is
+// set and the reset vector is loaded into the program counter. All other registers and
+// flags are unchanged.
+func Reset(state State, addressing *Addressing) (State, error) {
+	if addressing.Memory == nil {
+		return state, MemoryMustBeProvided
+	}
+
+	for range 3 {
+		readAccess(addressing.Memory, BaseStack+Address(state.SP), DummyReadAccess)
+		state.SP--
+	}
+
+	resetVector, err := ReadResetVectorFromMemory(addressing.Memory)
+	if err != nil {
+		return state, err
+	}
+
+	state.P.SetInterrupt()
+	state.PC = resetVector
+
+	return state, nil
+}
+
 // ReturnFromInterrupt (RTI) retrieves the Processor Status Word (flags)
 // and the Program Counter from the stack in that order (interrupts push
 // the PC first and then P). Note that unlike RTS, the return address on
 // the stack is the actual address rather than the address - 1.
-func ReturnFromInterrupt(state State, addressing Addressing) (State, error) {
+func ReturnFromInterrupt(state State, addressing *Addressing) (State, error) {
 
 	state, err := addressing.PullStatus(state)
 	if err != nil {
@@ -609,7 +625,7 @@ func ReturnFromInterrupt(state State, addressing Addressing) (State, error) {
 // byte first) and transfers program control to that address + 1. It is
 // used, as expected, to exit a subroutine invoked via JSR which pushed
 // the address - 1.
-func ReturnFromSubroutine(state State, addressing Addressing) (State, error) {
+func ReturnFromSubroutine(state State, addressing *Addressing) (State, error) {
 
 	state, addressFromStack, err := addressing.PullAddress(state)
 	if err != nil {
@@ -630,39 +646,24 @@ func ReturnFromSubroutine(state State, addressing Addressing) (State, error) {
 // is set (i.e. the Carry flag is cleared). For more details, see:
 //
 //	https://www.righto.com/2012/12/the-6502-overflow-flag-explained.html#:~:text=The%206502%20has%20a%20SBC,the%20carry%20flag%20is%20used.
-func SubtractWithCarry(state State, addressing Addressing) (State, error) {
+//
+// In decimal mode the accumulator holds the BCD result, including the results for invalid
+// BCD values. As on the NMOS 6502 all the flags reflect the equivalent binary subtraction.
+func SubtractWithCarry(state State, addressing *Addressing) (State, error) {
 
 	accum := uint16(state.A)
 	value := uint16(addressing.Value) ^ 0x00FF
 	carry := uint16(0)
-	result := uint16(0)
 
 	if state.P.ToFlags().Carry {
 		carry = 1
 	}
 
-	if state.P.ToFlags().Decimal {
-
-		// Do the lower nibble
-		result = 0x0F + (accum & 0x0F) - (uint16(addressing.Value) & 0x0F) + carry
-		upperNibble := uint16(0)
-		if result < 0x10 {
-			result -= 0x06
-		} else {
-			result -= 0x10
-			upperNibble = 0x10
-		}
-
-		// Do the upper nibble
-		upperNibble += 0xF0 + (accum & 0xF0) - (uint16(addressing.Value) & 0xF0)
-
-		if upperNibble < 0x100 {
-			upperNibble -= 0x60
-		}
-		result += upperNibble
+	result := accum + value + carry
+	decimal := uint8(result)
 
-	} else {
-		result = accum + value + carry
+	if state.P.ToFlags().Decimal {
+		decimal = subtractDecimal(state, addressing.Value)
 	}
 
 	overflowSet(&state.P, accum, value, result)
@@ -670,47 +671,73 @@ func SubtractWithCarry(state State, addressing Addressing) (State, error) {
 	negativeSet(&state.P, result)
 	zeroSet(&state.P, result)
 
-	state.A = uint8(result)
+	state.A = decimal
 	return state, nil
 }
 
+// subtractDecimal returns the BCD result of subtracting value and the borrow from the
+// accumulator on the NMOS 6502. This is the result for invalid BCD values too.
+//
+//	See: APPENDIX A: WHAT ABOUT INVALID BCD VALUES AND INVALID FLAGS?
+//	At: http://www.6502.org/tutorials/decimal_mode.html#A
+func subtractDecimal(state State, value uint8) uint8 {
+	borrow := 1
+	if state.P.ToFlags().Carry {
+		borrow = 0
+	}
+
+	// Do the lower nibble.
+	lower := int(state.A&0x0F) - int(value&0x0F) - borrow
+	if lower < 0 {
+		lower = ((lower - 0x06) & 0x0F) - 0x10
+	}
+
+	// Do the upper nibble.
+	result := int(state.A&0xF0) - int(value&0xF0) + lower
+	if result < 0 {
+		result -= 0x60
+	}
+
+	return uint8(result)
+}
+
 // SetCarry (SEC). Sets the carry flag.
-func SetCarry(state State, _ Addressing) (State, error) {
+func SetCarry(state State, _ *Addressing) (State, error) {
 	state.P.SetCarry()
 	return state, nil
 }
 
 // SetDecimal (SED). Sets the decimal flag.
-func SetDecimal(state State, _ Addressing) (State, error) {
+func SetDecimal(state State, _ *Addressing) (State, error) {
 	state.P.SetDecimal()
 	return state, nil
 }
 
 // SetInterrupt (SEI). Sets the interrupt flag to present maskable interrupts (aka IRQs).
-func SetInterrupt(state State, _ Addressing) (State, error) {
+func SetInterrupt(state State, _ *Addressing) (State, error) {
 	state.P.SetInterrupt()
 	return state, nil
 }
 
 // StoreA (STA). Store accumulator to memory.
-func StoreA(state State, addressing Addressing) (State, error) {
+func StoreA(state State, addressing *Addressing) (State, error) {
 	return addressing.Store(state, state.A)
 }
 
 // StoreX (STX). Store index X to memory.
-func StoreX(state State, addressing Addressing) (State, error) {
+func StoreX(state State, addressing *Addressing) (State, error) {
 	return addressing.Store(state, state.X)
 }
 
 // StoreY (STY). Store index Y to memory.
-func StoreY(state State, addressing Addressing) (State, error) {
+func StoreY(state State, addressing *Addressing) (State, error) {
 	return addressing.Store(state, state.Y)
 }
 
 // TestBitsInMemoryWithAccumulator (BIT). Sets the Zero (Z) flag as though the value in the address tested were ANDed
 // with the accumulator (but does not change the accumulator). Bits 7 and 6 of the value from memory are copied into
 // the Sign (N) flag (bit 7) and Overflow (V) flags (bit 6).
-func TestBitsInMemoryWithAccumulator(state State, addressing Addressing) (State, error) {
+func TestBitsInMemoryWithAccumulator(state State, addressing *Addressing) (State, error) {
 
 	// Do zero and negative flags first.
 	value := uint16(addressing.Value & state.A)
@@ -726,7 +753,7 @@ func TestBitsInMemoryWithAccumulator(state State, addressing Addressing) (State,
 }
 
 // TransferAtoX (TAX). Transfer accumulator to index X.
-func TransferAtoX(state State, _ Addressing) (State, error) {
+func TransferAtoX(state State, _ *Addressing) (State, error) {
 
 	state.X = state.A
 
@@ -738,7 +765,7 @@ func TransferAtoX(state State, _ Addressing) (State, error) {
 }
 
 // TransferAtoY (TAY). Transfer accumulator to index Y.
-func TransferAtoY(state State, _ Addressing) (State, error) {
+func TransferAtoY(state State, _ *Addressing) (State, error) {
 
 	state.Y = state.A
 
@@ -750,7 +777,7 @@ func TransferAtoY(state State, _ Addressing) (State, error) {
 }
 
 // TransferSPtoX (TSX). Transfer stack pointer to index X.
-func TransferSPtoX(state State, _ Addressing) (State, error) {
+func TransferSPtoX(state State, _ *Addressing) (State, error) {
 
 	state.X = state.SP
 
@@ -762,7 +789,7 @@ func TransferSPtoX(state State, _ Addressing) (State, error) {
 }
 
 // TransferXtoA (TXA). Transfer index X to accumulator.
-func TransferXtoA(state State, _ Addressing) (State, error) {
+func TransferXtoA(state State, _ *Addressing) (State, error) {
 
 	state.A = state.X
 
@@ -774,7 +801,7 @@ func TransferXtoA(state State, _ Addressing) (State, error) {
 }
 
 // TransferXtoSP (TXS). Transfer index X to stack pointer.
-func TransferXtoSP(state State, _ Addressing) (State, error) {
+func TransferXtoSP(state State, _ *Addressing) (State, error) {
 
 	state.SP = state.X
 
@@ -782,7 +809,7 @@ func TransferXtoSP(state State, _ Addressing) (State, error) {
 }
 
 // TransferYtoA (TYA). Transfer index Y to accumulator.
-func TransferYtoA(state State, _ Addressing) (State, error) {
+func TransferYtoA(state State, _ *Addressing) (State, error) {
 
 	state.A = state.Y
 
@@ -851,16 +878,10 @@ func zeroSet(s *Status, value uint16) {
 // From https://www.nesdev.org/wiki/Status_flags:
 //
 //	ADC and SBC will set this flag if the signed result would be invalid[1],
-func isOverflow(decimal bool, accumulator, value, result uint16) bool {
+func isOverflow(accumulator, value, result uint16) bool {
 	overflow := (accumulator^value)&0x80 == 0
 
-	// Pick the correct comparator based on Decimal or Binary mode.
-	comparator := uint16(0x100)
-	if decimal {
-		comparator = 0xA0
-	}
-
-	if result >= comparator {
+	if result >= 0x100 {
 		if overflow && result >= 0x180 {
 			overflow = false
 		}
@@ -876,7 +897,24 @@ func isOverflow(decimal bool, accumulator, value, result uint16) bool {
 // overflowSet determines with an overflow occurred when value was added to
 // start (with carry) which resulted in result.
 func overflowSet(s *Status, accumulator, value, result uint16) {
-	if isOverflow(s.ToFlags().Decimal, accumulator, value, result) {
+	if isOverflow(accumulator, value, result) {
+		s.SetOverflow()
+	} else {
+		s.ClearOverflow()
+	}
+}
+
+// isDecimalOverflow determines if an overflow occurred when adding in decimal mode. The
+// upper nibbles of the accumulator and value are added as signed values along with the
+// lower nibble of the result, which includes any decimal carry from the lower nibble.
+func isDecimalOverflow(accumulator, value, lower uint16) bool {
+	result := int(int8(accumulator&0xF0)) + int(int8(value&0xF0)) + int(lower)
+	return result < -128 || result > 127
+}
+
+// decimalOverflowSet sets or clears the overflow flag after adding in decimal mode.
+func decimalOverflowSet(s *Status, accumulator, value, lower uint16) {
+	if isDecimalOverflow(accumulator, value, lower) {
 		s.SetOverflow()
 	} else {
 		s.ClearOverflow()
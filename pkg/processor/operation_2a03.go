@@ -0,0 +1,59 @@
+package processor
+
+// ************************************************************
+// ********** Ricoh 2A03 operation functions
+// ************************************************************
+
+// The Ricoh 2A03 (NTSC) and 2A07 (PAL) used by the NES contain an NMOS 6502 core with the
+// decimal mode circuitry disconnected. The decimal flag can still be set and cleared, and
+// is pushed to the stack as normal, but ADC and SBC along with the undocumented operations
+// built from them always use binary arithmetic. Details from:
+//   - https://www.nesdev.org/wiki/CPU
+//   - https://www.nesdev.org/wiki/Status_flags#D:_Decimal
+
+// AddWithCarryBinary (ADC) is the same as AddWithCarry except that the decimal flag is
+// ignored.
+func AddWithCarryBinary(state State, addressing *Addressing) (State, error) {
+	return withoutDecimal(state, addressing, AddWithCarry)
+}
+
+// AndWithARotateRightBinary (ARR) is the same as AndWithARotateRight except that the
+// decimal flag is ignored.
+func AndWithARotateRightBinary(state State, addressing *Addressing) (State, error) {
+	return withoutDecimal(state, addressing, AndWithARotateRight)
+}
+
+// IncrementAndSubtractBinary (ISC aka ISB) is the same as IncrementAndSubtract except that
+// the decimal flag is ignored.
+func IncrementAndSubtractBinary(state State, addressing *Addressing) (State, error) {
+	return withoutDecimal(state, addressing, IncrementAndSubtract)
+}
+
+// RotateRightAddWithCarryBinary (RRA) is the same as RotateRightAddWithCarry except that
+// the decimal flag is ignored.
+func RotateRightAddWithCarryBinary(state State, addressing *Addressing) (State, error) {
+	return withoutDecimal(state, addressing, RotateRightAddWithCarry)
+}
+
+// SubtractWithCarryBinary (SBC) is the same as SubtractWithCarry except that the decimal
+// flag is ignored.
+func SubtractWithCarryBinary(state State, addressing *Addressing) (State, error) {
+	return withoutDecimal(state, addressing, SubtractWithCarry)
+}
+
+// withoutDecimal performs the operation in binary mode, leaving the decimal flag as it was.
+func withoutDecimal(state State, addressing *Addressing, operation Operation) (State, error) {
+
+	decimal := state.P.ToFlags().Decimal
+	state.P.ClearDecimal()
+
+	state, err := operation(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	if decimal {
+		state.P.SetDecimal()
+	}
+	return state, nil
+}
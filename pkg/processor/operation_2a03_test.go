@@ -0,0 +1,109 @@
+package processor
+
+import "testing"
+
+func TestAddWithCarryBinary(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ADC binary is unchanged from the NMOS 6502.",
+			startState: State{A: 0x7F},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x80, P: FlagNegative | FlagOverflow},
+		},
+		{
+			name:       "ADC ignores the decimal flag; $09 + $01 is $0A.",
+			startState: State{A: 0x09, P: FlagDecimal},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x0A, P: FlagDecimal},
+		},
+		{
+			name:       "ADC ignores the decimal flag; $99 + $01 is $9A.",
+			startState: State{A: 0x99, P: FlagDecimal},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x9A, P: FlagDecimal | FlagNegative},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, AddWithCarryBinary)
+		})
+	}
+}
+
+func TestAndWithARotateRightBinary(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ARR ignores the decimal flag.",
+			startState: State{A: 0xFF, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x0E},
+			wantState:  State{A: 0x87, P: FlagDecimal | FlagNegative},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, AndWithARotateRightBinary)
+		})
+	}
+}
+
+func TestIncrementAndSubtractBinary(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ISC ignores the decimal flag; $10 - $0A is $06.",
+			startState: State{A: 0x10, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x09},
+			wantState:  State{A: 0x06, P: FlagDecimal | FlagCarry},
+			wantRam:    []uint8{0x0A, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, IncrementAndSubtractBinary)
+		})
+	}
+}
+
+func TestRotateRightAddWithCarryBinary(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "RRA ignores the decimal flag; $09 + $01 is $0A.",
+			startState: State{A: 0x09, P: FlagDecimal},
+			addressing: Addressing{Value: 0x02},
+			wantState:  State{A: 0x0A, P: FlagDecimal},
+			wantRam:    []uint8{0x01, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, RotateRightAddWithCarryBinary)
+		})
+	}
+}
+
+func TestSubtractWithCarryBinary(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SBC binary is unchanged from the NMOS 6502.",
+			startState: State{A: 0x80, P: FlagCarry},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x7F, P: FlagOverflow | FlagCarry},
+		},
+		{
+			name:       "SBC ignores the decimal flag; $10 - $01 is $0F.",
+			startState: State{A: 0x10, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x0F, P: FlagDecimal | FlagCarry},
+		},
+		{
+			name:       "SBC ignores the decimal flag; $00 - $01 is $FF and borrows.",
+			startState: State{A: 0x00, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0xFF, P: FlagDecimal | FlagNegative},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, SubtractWithCarryBinary)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package processor
+
+// ************************************************************
+// ********** 65C02 operation functions
+// ************************************************************
+
+// The following operations implement the new instructions of the CMOS 65C02 along with
+// the documented changes in behaviour from the NMOS 6502. The bit manipulation and bit
+// branch instructions were introduced by Rockwell and later adopted by WDC. Details from:
+//   - http://6502.org/tutorials/65c02opcodes.html
+//   - https://www.westerndesigncenter.com/wdc/documentation/w65c02s.pdf
+
+// AddWithCarryCmos (ADC) is the same as AddWithCarry except that in decimal mode the
+// negative and zero flags are valid; they reflect the BCD result in the accumulator.
+func AddWithCarryCmos(state State, addressing *Addressing) (State, error) {
+
+	state, err := AddWithCarry(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	negativeSet(&state.P, uint16(state.A))
+	zeroSet(&state.P, uint16(state.A))
+
+	return state, nil
+}
+
+// BranchAlways (BRA). Branch unconditionally.
+func BranchAlways(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, true)
+}
+
+// bitMask returns the mask for the given bit number (0 to 7).
+func bitMask(bit uint8) uint8 {
+	return 0x01 << (bit & 0x07)
+}
+
+// BranchOnBitReset returns the operation for BBRn. Branch if the bit in the zero page
+// value is not set. This requires ZeroPageRelative addressing.
+func BranchOnBitReset(bit uint8) Operation {
+	mask := bitMask(bit)
+	return func(state State, addressing *Addressing) (State, error) {
+		return branch(state, addressing, addressing.Value&mask == 0)
+	}
+}
+
+// BranchOnBitSet returns the operation for BBSn. Branch if the bit in the zero page
+// value is set. This requires ZeroPageRelative addressing.
+func BranchOnBitSet(bit uint8) Operation {
+	mask := bitMask(bit)
+	return func(state State, addressing *Addressing) (State, error) {
+		return branch(state, addressing, addressing.Value&mask != 0)
+	}
+}
+
+// BreakCmos (BRK) is the same as Break except that the decimal flag is cleared.
+func BreakCmos(state State, addressing *Addressing) (State, error) {
+
+	state, err := Break(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	state.P.ClearDecimal()
+	return state, nil
+}
+
+// InterruptCmos is the same as Interrupt except that the decimal flag is cleared.
+func InterruptCmos(state State, addressing *Addressing) (State, error) {
+
+	state, err := Interrupt(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	state.P.ClearDecimal()
+	return state, nil
+}
+
+// NmiCmos is the same as Nmi except that the decimal flag is cleared.
+func NmiCmos(state State, addressing *Addressing) (State, error) {
+
+	state, err := Nmi(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	state.P.ClearDecimal()
+	return state, nil
+}
+
+// PushX (PHX) pushes X onto the stack.
+func PushX(state State, addressing *Addressing) (State, error) {
+	return addressing.PushByte(state, state.X)
+}
+
+// PushY (PHY) pushes Y onto the stack.
+func PushY(state State, addressing *Addressing) (State, error) {
+	return addressing.PushByte(state, state.Y)
+}
+
+// PullX (PLX) pulls X from the stack. This will set the sign and zero flags based on
+// the result pulled.
+func PullX(state State, addressing *Addressing) (State, error) {
+
+	state, value, err := addressing.PullByte(state)
+	if err != nil {
+		return state, err
+	}
+	state.X = value
+
+	negativeSet(&state.P, uint16(value))
+	zeroSet(&state.P, uint16(value))
+
+	return state, nil
+}
+
+// PullY (PLY) pulls Y from the stack. This will set the sign and zero flags based on
+// the result pulled.
+func PullY(state State, addressing *Addressing) (State, error) {
+
+	state, value, err := addressing.PullByte(state)
+	if err != nil {
+		return state, err
+	}
+	state.Y = value
+
+	negativeSet(&state.P, uint16(value))
+	zeroSet(&state.P, uint16(value))
+
+	return state, nil
+}
+
+// ResetMemoryBit returns the operation for RMBn. Clear the bit in the zero page value.
+// No flags are affected.
+func ResetMemoryBit(bit uint8) Operation {
+	mask := bitMask(bit)
+	return func(state State, addressing *Addressing) (State, error) {
+		return addressing.Store(state, addressing.Value&^mask)
+	}
+}
+
+// SetMemoryBit returns the operation for SMBn. Set the bit in the zero page value.
+// No flags are affected.
+func SetMemoryBit(bit uint8) Operation {
+	mask := bitMask(bit)
+	return func(state State, addressing *Addressing) (State, error) {
+		return addressing.Store(state, addressing.Value|mask)
+	}
+}
+
+// Stop (STP). Stop the clock of the processor until it is reset. This is only available
+// on the WDC 65C02.
+func Stop(state State, addressing *Addressing) (State, error) {
+	addressing.RunState = Stopped
+	return state, nil
+}
+
+// ResetCmos is the same as Reset except that the decimal flag is cleared.
+func ResetCmos(state State, addressing *Addressing) (State, error) {
+
+	state, err := Reset(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	state.P.ClearDecimal()
+	return state, nil
+}
+
+// StoreZero (STZ). Store zero to memory.
+func StoreZero(state State, addressing *Addressing) (State, error) {
+	return addressing.Store(state, 0)
+}
+
+// SubtractWithCarryCmos (SBC) is the same as SubtractWithCarry except that in decimal
+// mode the accumulator is adjusted differently for invalid BCD values and the negative and
+// zero flags are valid; they reflect the BCD result in the accumulator.
+func SubtractWithCarryCmos(state State, addressing *Addressing) (State, error) {
+
+	start := state
+	state, err := SubtractWithCarry(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	if start.P.ToFlags().Decimal {
+		state.A = subtractDecimalCmos(start, addressing.Value)
+	}
+
+	negativeSet(&state.P, uint16(state.A))
+	zeroSet(&state.P, uint16(state.A))
+
+	return state, nil
+}
+
+// subtractDecimalCmos returns the BCD result of subtracting value and the borrow from the
+// accumulator on the 65C02. This is the result for invalid BCD values too.
+//
+//	See: APPENDIX A: WHAT ABOUT INVALID BCD VALUES AND INVALID FLAGS?
+//	At: http://www.6502.org/tutorials/decimal_mode.html#A
+func subtractDecimalCmos(state State, value uint8) uint8 {
+	borrow := 1
+	if state.P.ToFlags().Carry {
+		borrow = 0
+	}
+
+	lower := int(state.A&0x0F) - int(value&0x0F) - borrow
+	result := int(state.A) - int(value) - borrow
+
+	if result < 0 {
+		result -= 0x60
+	}
+	if lower < 0 {
+		result -= 0x06
+	}
+
+	return uint8(result)
+}
+
+// TestAndResetBits (TRB). The zero flag is set as though the value in memory were ANDed
+// with the accumulator, then the bits set in the accumulator are cleared in memory.
+func TestAndResetBits(state State, addressing *Addressing) (State, error) {
+
+	zeroSet(&state.P, uint16(addressing.Value&state.A))
+
+	return addressing.Store(state, addressing.Value&^state.A)
+}
+
+// TestAndSetBits (TSB). The zero flag is set as though the value in memory were ANDed
+// with the accumulator, then the bits set in the accumulator are set in memory.
+func TestAndSetBits(state State, addressing *Addressing) (State, error) {
+
+	zeroSet(&state.P, uint16(addressing.Value&state.A))
+
+	return addressing.Store(state, addressing.Value|state.A)
+}
+
+// TestBitsImmediate (BIT #). Unlike the other BIT addressing modes, the immediate form
+// only affects the zero flag; the negative and overflow flags are unchanged.
+func TestBitsImmediate(state State, addressing *Addressing) (State, error) {
+
+	zeroSet(&state.P, uint16(addressing.Value&state.A))
+
+	return state, nil
+}
+
+// WaitForInterrupt (WAI). Wait until an interrupt (IRQ or NMI) occurs. If the interrupt
+// disable flag is set when an IRQ occurs then execution continues with the following
+// instruction rather than servicing the interrupt. This is only available on the WDC
+// 65C02.
+func WaitForInterrupt(state State, addressing *Addressing) (State, error) {
+	addressing.RunState = Waiting
+	return state, nil
+}
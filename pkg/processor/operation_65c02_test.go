@@ -0,0 +1,425 @@
+package processor
+
+import "testing"
+
+func TestAddWithCarryCmos(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ADC binary is unchanged from the NMOS 6502.",
+			startState: State{A: 0x7F},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x80, P: FlagNegative | FlagOverflow},
+		},
+		{
+			name:       "ADC decimal; 99 + 1 sets zero and carry.",
+			startState: State{A: 0x99, P: FlagDecimal},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x00, P: FlagDecimal | FlagZero | FlagCarry},
+		},
+		{
+			name:       "ADC decimal; 79 + 1 sets negative.",
+			startState: State{A: 0x79, P: FlagDecimal},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x80, P: FlagDecimal | FlagNegative | FlagOverflow},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, AddWithCarryCmos)
+		})
+	}
+}
+
+func TestBranchAlways(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "BRA branches with no flags set.",
+			startState: State{PC: 0x02},
+			addressing: Addressing{EffectiveAddress: 0x1234},
+			wantState:  State{PC: 0x1234},
+		},
+		{
+			name:       "BRA branches with all flags set.",
+			startState: State{PC: 0x02, P: 0xFF},
+			addressing: Addressing{EffectiveAddress: 0x1234},
+			wantState:  State{PC: 0x1234, P: 0xFF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, BranchAlways)
+		})
+	}
+}
+
+func TestBranchOnBitReset(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "BBR3 branches when bit 3 is clear.",
+			startState: State{PC: 0x03},
+			addressing: Addressing{EffectiveAddress: 0x1234, Value: 0xF7},
+			wantState:  State{PC: 0x1234},
+		},
+		{
+			name:       "BBR3 does not branch when bit 3 is set.",
+			startState: State{PC: 0x03},
+			addressing: Addressing{EffectiveAddress: 0x1234, Value: 0x08},
+			wantState:  State{PC: 0x03},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, BranchOnBitReset(3))
+		})
+	}
+}
+
+func TestBranchOnBitSet(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "BBS7 branches when bit 7 is set.",
+			startState: State{PC: 0x03},
+			addressing: Addressing{EffectiveAddress: 0x1234, Value: 0x80},
+			wantState:  State{PC: 0x1234},
+		},
+		{
+			name:       "BBS7 does not branch when bit 7 is clear.",
+			startState: State{PC: 0x03},
+			addressing: Addressing{EffectiveAddress: 0x1234, Value: 0x7F},
+			wantState:  State{PC: 0x03},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, BranchOnBitSet(7))
+		})
+	}
+}
+
+func TestBreakCmos(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "BRK pushes the return address and status then clears the decimal flag.",
+			startState: State{PC: 0x1234, SP: StackPointerStart, P: FlagDecimal},
+			startRam:   []uint8{0, 0, 0, 0, 0, 0, 0x00, 0x80},
+			wantState:  State{PC: 0x8000, SP: StackPointerStart - 3, P: FlagInterrupt},
+			wantRam:    []uint8{0, 0, 0, FlagConstant | FlagBreak | FlagDecimal, 0x35, 0x12, 0x00, 0x80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, BreakCmos)
+		})
+	}
+}
+
+func TestInterruptCmos(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "IRQ pushes the return address and status then clears the decimal flag.",
+			startState: State{PC: 0x1234, SP: StackPointerStart, P: FlagDecimal},
+			startRam:   []uint8{0, 0, 0, 0, 0, 0, 0x00, 0x80},
+			wantState:  State{PC: 0x8000, SP: StackPointerStart - 3, P: FlagInterrupt},
+			wantRam:    []uint8{0, 0, 0, FlagConstant | FlagDecimal, 0x34, 0x12, 0x00, 0x80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, InterruptCmos)
+		})
+	}
+}
+
+func TestNmiCmos(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "NMI pushes the return address and status then clears the decimal flag.",
+			startState: State{PC: 0x1234, SP: StackPointerStart, P: FlagDecimal},
+			startRam:   []uint8{0, 0, 0x00, 0x90, 0, 0, 0, 0},
+			wantState:  State{PC: 0x9000, SP: StackPointerStart - 3, P: FlagInterrupt},
+			wantRam:    []uint8{0, 0, 0x00, FlagConstant | FlagDecimal, 0x34, 0x12, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, NmiCmos)
+		})
+	}
+}
+
+func TestPushX(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "PHX pushes X onto the stack.",
+			startState: State{X: 0xAB, SP: StackPointerStart},
+			wantState:  State{X: 0xAB, SP: StackPointerStart - 1},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0xAB, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, PushX)
+		})
+	}
+}
+
+func TestPushY(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "PHY pushes Y onto the stack.",
+			startState: State{Y: 0xCD, SP: StackPointerStart},
+			wantState:  State{Y: 0xCD, SP: StackPointerStart - 1},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0xCD, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, PushY)
+		})
+	}
+}
+
+func TestPullX(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "PLX pulls a negative value into X.",
+			startState: State{SP: StackPointerStart},
+			startRam:   []uint8{0, 0, 0, 0, 0, 0, 0x80, 0},
+			wantState:  State{X: 0x80, SP: StackPointerStart + 1, P: FlagNegative},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0, 0x80, 0},
+		},
+		{
+			name:       "PLX pulls a zero value into X.",
+			startState: State{X: 0x12, SP: StackPointerStart},
+			wantState:  State{SP: StackPointerStart + 1, P: FlagZero},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, PullX)
+		})
+	}
+}
+
+func TestPullY(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "PLY pulls a negative value into Y.",
+			startState: State{SP: StackPointerStart},
+			startRam:   []uint8{0, 0, 0, 0, 0, 0, 0x80, 0},
+			wantState:  State{Y: 0x80, SP: StackPointerStart + 1, P: FlagNegative},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0, 0x80, 0},
+		},
+		{
+			name:       "PLY pulls a zero value into Y.",
+			startState: State{Y: 0x12, SP: StackPointerStart},
+			wantState:  State{SP: StackPointerStart + 1, P: FlagZero},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, PullY)
+		})
+	}
+}
+
+func TestResetCmos(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "Reset is unchanged from the NMOS 6502 except the decimal flag is cleared.",
+			startState: State{SP: 0x80, A: 0x12, P: FlagDecimal | FlagCarry},
+			startRam:   []uint8{0, 0, 0, 0, 0x00, 0x90, 0, 0},
+			wantState:  State{PC: 0x9000, SP: 0x7D, A: 0x12, P: FlagInterrupt | FlagCarry},
+			wantRam:    []uint8{0, 0, 0, 0, 0x00, 0x90, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, ResetCmos)
+		})
+	}
+}
+
+func TestResetMemoryBit(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "RMB5 clears bit 5 and no flags are affected.",
+			startState: State{P: FlagZero},
+			startRam:   []uint8{0, 0, 0, 0, 0, 0, 0, 0xFF},
+			addressing: Addressing{EffectiveAddress: 0x07, Value: 0xFF},
+			wantState:  State{P: FlagZero},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0, 0, 0xDF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, ResetMemoryBit(5))
+		})
+	}
+}
+
+func TestSetMemoryBit(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SMB0 sets bit 0 and no flags are affected.",
+			startState: State{P: FlagZero},
+			addressing: Addressing{EffectiveAddress: 0x07},
+			wantState:  State{P: FlagZero},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0, 0, 0x01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, SetMemoryBit(0))
+		})
+	}
+}
+
+func TestStop(t *testing.T) {
+	state := State{A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative}
+	addressing := Addressing{}
+	got, err := Stop(state, &addressing)
+	if err != nil || got != state {
+		t.Errorf("Stop() got = %v, err = %v, want = %v", got, err, state)
+	}
+	if addressing.RunState != Stopped {
+		t.Errorf("Stop() RunState got = %v, want = %v", addressing.RunState, Stopped)
+	}
+}
+
+func TestStoreZero(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "STZ stores zero and no flags are affected.",
+			startState: State{A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative},
+			startRam:   []uint8{0, 0, 0, 0xAA, 0, 0, 0, 0},
+			addressing: Addressing{EffectiveAddress: 0x03, Value: 0xAA},
+			wantState:  State{A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, StoreZero)
+		})
+	}
+}
+
+func TestSubtractWithCarryCmos(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SBC binary is unchanged from the NMOS 6502.",
+			startState: State{A: 0x80, P: FlagCarry},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x7F, P: FlagOverflow | FlagCarry},
+		},
+		{
+			name:       "SBC decimal; 0 - 1 sets negative and borrows.",
+			startState: State{A: 0x00, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x99, P: FlagDecimal | FlagNegative},
+		},
+		{
+			name:       "SBC decimal; 1 - 1 sets zero.",
+			startState: State{A: 0x01, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x00, P: FlagDecimal | FlagZero | FlagCarry},
+		},
+		{
+			name:       "SBC decimal; 0 - $0C is adjusted differently from the NMOS 6502.",
+			startState: State{A: 0x00, P: FlagDecimal | FlagCarry},
+			addressing: Addressing{Value: 0x0C},
+			wantState:  State{A: 0x8E, P: FlagDecimal | FlagNegative},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, SubtractWithCarryCmos)
+		})
+	}
+}
+
+func TestTestAndResetBits(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "TRB clears the bits set in A; no common bits sets zero.",
+			startState: State{A: 0x0F, P: FlagNegative | FlagOverflow},
+			startRam:   []uint8{0, 0, 0, 0xF0, 0, 0, 0, 0},
+			addressing: Addressing{EffectiveAddress: 0x03, Value: 0xF0},
+			wantState:  State{A: 0x0F, P: FlagNegative | FlagOverflow | FlagZero},
+			wantRam:    []uint8{0, 0, 0, 0xF0, 0, 0, 0, 0},
+		},
+		{
+			name:       "TRB clears the bits set in A; common bits clears zero.",
+			startState: State{A: 0x81, P: FlagZero},
+			startRam:   []uint8{0, 0, 0, 0xFF, 0, 0, 0, 0},
+			addressing: Addressing{EffectiveAddress: 0x03, Value: 0xFF},
+			wantState:  State{A: 0x81},
+			wantRam:    []uint8{0, 0, 0, 0x7E, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, TestAndResetBits)
+		})
+	}
+}
+
+func TestTestAndSetBits(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "TSB sets the bits set in A; no common bits sets zero.",
+			startState: State{A: 0x0F},
+			startRam:   []uint8{0, 0, 0, 0xF0, 0, 0, 0, 0},
+			addressing: Addressing{EffectiveAddress: 0x03, Value: 0xF0},
+			wantState:  State{A: 0x0F, P: FlagZero},
+			wantRam:    []uint8{0, 0, 0, 0xFF, 0, 0, 0, 0},
+		},
+		{
+			name:       "TSB sets the bits set in A; common bits clears zero.",
+			startState: State{A: 0x81, P: FlagZero},
+			startRam:   []uint8{0, 0, 0, 0x01, 0, 0, 0, 0},
+			addressing: Addressing{EffectiveAddress: 0x03, Value: 0x01},
+			wantState:  State{A: 0x81},
+			wantRam:    []uint8{0, 0, 0, 0x81, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, TestAndSetBits)
+		})
+	}
+}
+
+func TestTestBitsImmediate(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "BIT immediate with no common bits sets zero only.",
+			startState: State{A: 0x01},
+			addressing: Addressing{Value: 0xC0},
+			wantState:  State{A: 0x01, P: FlagZero},
+		},
+		{
+			name:       "BIT immediate does not change negative or overflow.",
+			startState: State{A: 0xFF, P: FlagZero | FlagNegative | FlagOverflow},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0xFF, P: FlagNegative | FlagOverflow},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, TestBitsImmediate)
+		})
+	}
+}
+
+func TestWaitForInterrupt(t *testing.T) {
+	state := State{A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative}
+	addressing := Addressing{}
+	got, err := WaitForInterrupt(state, &addressing)
+	if err != nil || got != state {
+		t.Errorf("WaitForInterrupt() got = %v, err = %v, want = %v", got, err, state)
+	}
+	if addressing.RunState != Waiting {
+		t.Errorf("WaitForInterrupt() RunState got = %v, want = %v", addressing.RunState, Waiting)
+	}
+}
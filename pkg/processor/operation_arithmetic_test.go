@@ -143,12 +143,11 @@ func Test_zeroSet(t *testing.T) {
 
 func Test_isOverflow(t *testing.T) {
 	tests := []struct {
-		name    string
-		decimal bool
-		start   uint16
-		value   uint16
-		result  uint16
-		want    bool
+		name   string
+		start  uint16
+		value  uint16
+		result uint16
+		want   bool
 	}{
 		{name: "All zeros does not overflow"},
 		/* These test cases are from http://www.6502.org/tutorials/vflag.html
@@ -199,15 +198,10 @@ func Test_isOverflow(t *testing.T) {
 		{name: "Binary ; 1 + 1 = 2, returns V = 0", start: 1, value: 1, result: 2, want: false},
 		{name: "Binary ; 63 + 64 + 1 = 128, returns V = 1", start: 0x3F, value: 0x40, result: 0x80, want: true},
 		{name: "Binary ; -64 - 64 - 1 = -129, returns V = 1", start: 0xC0, value: (0x40 ^ 0xFF) + 1, result: 0x17F, want: true},
-		// Decimal mode tests.
-		{name: "Decimal ; 56 + 47 = 105, returns V = 1", decimal: true, start: 0x56, value: 0x47, result: 0x105, want: true},
-		{name: "Decimal ; 12 + 34 = 46, returns V = 0", decimal: true, start: 0x12, value: 0x34, result: 0x46, want: false},
-		{name: "Decimal ; 15 + 26 = 41, returns V = 0", decimal: true, start: 0x15, value: 0x26, result: 0x41, want: false},
-		{name: "Decimal ; 81 + 92 = 173, returns V = 1", decimal: true, start: 0x81, value: 0x92, result: 0x173, want: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := isOverflow(tt.decimal, tt.start, tt.value, tt.result); got != tt.want {
+			if got := isOverflow(tt.start, tt.value, tt.result); got != tt.want {
 				t.Errorf("isOverflow() = %v, want %v", got, tt.want)
 			}
 		})
@@ -217,7 +211,6 @@ func Test_isOverflow(t *testing.T) {
 func Test_overflowSet(t *testing.T) {
 	tests := []struct {
 		name         string
-		decimal      bool
 		start        uint16
 		value        uint16
 		result       uint16
@@ -228,23 +221,44 @@ func Test_overflowSet(t *testing.T) {
 		{name: "Binary ; 1 + -1 = 0, returns V = 0", start: 1, value: 0xFF, result: 0, wantOverflow: false},
 		{name: "Binary ; 127 + 1 = 128, returns V = 1", start: 0x7F, value: 1, result: 0x80, wantOverflow: true},
 		{name: "Binary ; -128 + -1 = -129, returns V = 1", start: 0x80, value: 0xFF, result: 0x17F, wantOverflow: true},
-		// Decimal mode tests.
-		{name: "Decimal ; 56 + 47 = 105, returns V = 1", decimal: true, start: 0x56, value: 0x47, result: 0x105, wantOverflow: true},
-		{name: "Decimal ; 12 + 34 = 46, returns V = 0", decimal: true, start: 0x12, value: 0x34, result: 0x46, wantOverflow: false},
-		{name: "Decimal ; 15 + 26 = 41, returns V = 0", decimal: true, start: 0x15, value: 0x26, result: 0x41, wantOverflow: false},
-		{name: "Decimal ; 81 + 92 = 173, returns V = 1", decimal: true, start: 0x81, value: 0x92, result: 0x173, wantOverflow: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var status Status
 			overflowSet(&status, tt.start, tt.value, tt.result)
-			if got := isOverflow(tt.decimal, tt.start, tt.value, tt.result); got != tt.wantOverflow {
+			if got := isOverflow(tt.start, tt.value, tt.result); got != tt.wantOverflow {
 				t.Errorf("overflowSet() = %v, want %v", got, tt.wantOverflow)
 			}
 		})
 	}
 }
 
+func Test_isDecimalOverflow(t *testing.T) {
+	tests := []struct {
+		name  string
+		start uint16
+		value uint16
+		lower uint16
+		want  bool
+	}{
+		{name: "All zeros does not overflow"},
+		{name: "Decimal ; 56 + 47 = 105, returns V = 1", start: 0x56, value: 0x47, lower: 0x13, want: true},
+		{name: "Decimal ; 12 + 34 = 46, returns V = 0", start: 0x12, value: 0x34, lower: 0x06, want: false},
+		{name: "Decimal ; 15 + 26 = 41, returns V = 0", start: 0x15, value: 0x26, lower: 0x11, want: false},
+		{name: "Decimal ; 81 + 92 = 173, returns V = 1", start: 0x81, value: 0x92, lower: 0x03, want: true},
+		{name: "Decimal ; 79 + 1 = 80, returns V = 1", start: 0x79, value: 0x01, lower: 0x10, want: true},
+		{name: "Decimal ; 90 + 90 = 180, returns V = 1", start: 0x90, value: 0x90, lower: 0x00, want: true},
+		{name: "Decimal ; 99 + 1 = 100, returns V = 0", start: 0x99, value: 0x01, lower: 0x10, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDecimalOverflow(tt.start, tt.value, tt.lower); got != tt.want {
+				t.Errorf("isDecimalOverflow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAddWithCarry(t *testing.T) {
 	tests := []testOperationConfig{
 		// These test cases are from http://www.6502.org/tutorials/vflag.html
@@ -501,7 +515,7 @@ func TestAddWithCarry(t *testing.T) {
 			name:       "Decimal mode (BCD addition: 58 + 46 + 1 = 105)",
 			startState: State{A: 0x58, P: FlagDecimal | FlagCarry},
 			addressing: Addressing{Value: 0x46},
-			wantState:  State{A: 0x05, P: FlagDecimal | FlagCarry | FlagOverflow},
+			wantState:  State{A: 0x05, P: FlagDecimal | FlagCarry | FlagOverflow | FlagNegative},
 		},
 		{
 			/*
@@ -626,10 +640,10 @@ func TestAddWithCarry(t *testing.T) {
 			wantState:  State{A: 0x41, P: FlagDecimal},
 		},
 		{
-			name:       "BCD $99 add $01 is $00",
+			name:       "BCD $99 add $01 is $00 (N reflects $A0 and Z reflects the binary $9A)",
 			startState: State{A: 0x99, P: FlagDecimal},
 			addressing: Addressing{Value: 0x01},
-			wantState:  State{A: 0x00, P: FlagDecimal | FlagCarry | FlagZero},
+			wantState:  State{A: 0x00, P: FlagDecimal | FlagCarry | FlagNegative},
 		},
 		// Test that the carry flag works with BCD.
 		{
@@ -657,10 +671,10 @@ func TestAddWithCarry(t *testing.T) {
 			wantState:  State{A: 0x61, P: FlagDecimal},
 		},
 		{
-			name:       "BCD $99 add $00 with Carry is $00",
+			name:       "BCD $99 add $00 with Carry is $00 (N reflects $A0 and Z reflects the binary $9A)",
 			startState: State{A: 0x99, P: FlagDecimal | FlagCarry},
 			addressing: Addressing{Value: 0x00},
-			wantState:  State{A: 0x00, P: FlagDecimal | FlagCarry | FlagZero},
+			wantState:  State{A: 0x00, P: FlagDecimal | FlagCarry | FlagNegative},
 		},
 	}
 	for _, tt := range tests {
@@ -1028,16 +1042,16 @@ func TestSubtractWithCarry(t *testing.T) {
 			wantState:  State{A: 0x10, P: FlagDecimal | FlagNoBorrow},
 		},
 		{
-			name:       "BCD $20 subtract $0B is $0F",
+			name:       "BCD $20 subtract $0B is $1F",
 			startState: State{A: 0x20, P: FlagDecimal | FlagNoBorrow},
 			addressing: Addressing{Value: 0x0B},
-			wantState:  State{A: 0x0F, P: FlagDecimal | FlagNoBorrow},
+			wantState:  State{A: 0x1F, P: FlagDecimal | FlagNoBorrow},
 		},
 		{
-			name:       "BCD zero subtract $0C is $8E (and borrows)",
+			name:       "BCD zero subtract $0C is $9E (and borrows)",
 			startState: State{A: 0x00, P: FlagDecimal | FlagNoBorrow},
 			addressing: Addressing{Value: 0x0C},
-			wantState:  State{A: 0x8E, P: FlagDecimal | FlagNone | FlagNegative},
+			wantState:  State{A: 0x9E, P: FlagDecimal | FlagNone | FlagNegative},
 		},
 		{
 			name:       "BCD $0D subtract zero is $0D",
@@ -1046,10 +1060,10 @@ func TestSubtractWithCarry(t *testing.T) {
 			wantState:  State{A: 0x0D, P: FlagDecimal | FlagNoBorrow},
 		},
 		{
-			name:       "BCD zero subtract $0E is $0E (and borrows)",
+			name:       "BCD zero subtract $0E (with borrow) is $9B (and borrows)",
 			startState: State{A: 0x00, P: FlagDecimal},
 			addressing: Addressing{Value: 0x0E},
-			wantState:  State{A: 0x8B, P: FlagDecimal | FlagNone | FlagNegative},
+			wantState:  State{A: 0x9B, P: FlagDecimal | FlagNone | FlagNegative},
 		},
 		{
 			name:       "BCD $0F subtract zero (with borrow) is $0F",
@@ -1058,10 +1072,10 @@ func TestSubtractWithCarry(t *testing.T) {
 			wantState:  State{A: 0x0E, P: FlagDecimal | FlagNoBorrow},
 		},
 		{
-			name:       "BCD $01 subtract $0C (with borrow) is $13",
+			name:       "BCD $01 subtract $0C (with borrow) is $9E",
 			startState: State{A: 0x01, P: FlagDecimal},
 			addressing: Addressing{Value: 0x0C},
-			wantState:  State{A: 0x8E, P: FlagDecimal | FlagNone | FlagNegative},
+			wantState:  State{A: 0x9E, P: FlagDecimal | FlagNone | FlagNegative},
 		},
 		{
 			name:       "BCD $10 subtract $05 is $05",
@@ -1230,7 +1244,7 @@ func TestAllAddsAndSubtracts(t *testing.T) {
 		startState := State{A: uint8(numOne), P: p}
 		addressing := Addressing{Value: uint8(numTwo)}
 
-		result, err := AddWithCarry(startState, addressing)
+		result, err := AddWithCarry(startState, &addressing)
 		if err != nil {
 			panic(err)
 		}
@@ -1280,7 +1294,7 @@ func TestAllAddsAndSubtracts(t *testing.T) {
 		startState := State{A: uint8(numOne), P: p}
 		addressing := Addressing{Value: uint8(numTwo)}
 
-		result, err := SubtractWithCarry(startState, addressing)
+		result, err := SubtractWithCarry(startState, &addressing)
 		if err != nil {
 			panic(err)
 		}
@@ -1328,7 +1342,7 @@ func TestAllAddsAndSubtracts(t *testing.T) {
 		startState := State{A: uint8(numOne), P: p}
 		addressing := Addressing{Value: uint8(numTwo)}
 
-		result, err := AddWithCarry(startState, addressing)
+		result, err := AddWithCarryCmos(startState, &addressing)
 		if err != nil {
 			panic(err)
 		}
@@ -1341,8 +1355,8 @@ func TestAllAddsAndSubtracts(t *testing.T) {
 			fmt.Printf("  Actual .... : B: 0x%02X, Carry: %v, Flags: %v\n", result.A, carry, result.P.ToFlags())
 		}
 
-		// On the 6502, only the C flag is valid in decimal mode. On the 65C02 and 65816, the Z flag is valid.
-		// In our simulator, the C, N amd Z flags are valid but V is not.
+		// On the 6502, only the C flag is valid in decimal mode. On the 65C02 and 65816, the N and Z
+		// flags are valid too, so the 65C02 operations are used. The V flag is never valid.
 		expectedFlags.ClearOverflow()
 		result.P.ClearOverflow()
 
@@ -1387,7 +1401,7 @@ func TestAllAddsAndSubtracts(t *testing.T) {
 		startState := State{A: uint8(numOne), P: p}
 		addressing := Addressing{Value: uint8(numTwo)}
 
-		result, err := SubtractWithCarry(startState, addressing)
+		result, err := SubtractWithCarryCmos(startState, &addressing)
 		if err != nil {
 			panic(err)
 		}
@@ -1400,8 +1414,8 @@ func TestAllAddsAndSubtracts(t *testing.T) {
 			fmt.Printf("  Actual .... : B: 0x%02X, Borrow: %v, Flags: %v\n", result.A, borrow, result.P.ToFlags())
 		}
 
-		// On the 6502, only the C flag is valid in decimal mode. On the 65C02 and 65816, the Z flag is valid.
-		// In our simulator, the C, N amd Z flags are valid but V is not.
+		// On the 6502, only the C flag is valid in decimal mode. On the 65C02 and 65816, the N and Z
+		// flags are valid too, so the 65C02 operations are used. The V flag is never valid.
 		expectedFlags.ClearOverflow()
 		result.P.ClearOverflow()
 
@@ -222,3 +222,41 @@ func TestBranchOnPlus(t *testing.T) {
 		})
 	}
 }
+
+func Test_branch(t *testing.T) {
+	tests := []struct {
+		name            string
+		startState      State
+		condition       bool
+		wantState       State
+		wantBranchTaken bool
+	}{
+		{
+			name:       "Condition not met does not branch or report the branch taken.",
+			startState: State{PC: 0x1234},
+			wantState:  State{PC: 0x1234},
+		},
+		{
+			name:            "Condition met branches and reports the branch taken.",
+			startState:      State{PC: 0x1234},
+			condition:       true,
+			wantState:       State{PC: 0x1240},
+			wantBranchTaken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addressing := Addressing{EffectiveAddress: 0x1240}
+			got, err := branch(tt.startState, &addressing, tt.condition)
+			if err != nil {
+				t.Errorf("branch() unexpected error = %v", err)
+			}
+			if got != tt.wantState {
+				t.Errorf("branch() State got = %v, want = %v", got, tt.wantState)
+			}
+			if addressing.BranchTaken != tt.wantBranchTaken {
+				t.Errorf("branch() BranchTaken got = %v, want = %v", addressing.BranchTaken, tt.wantBranchTaken)
+			}
+		})
+	}
+}
@@ -137,6 +137,40 @@ func TestNmi(t *testing.T) {
 	}
 }
 
+func TestReset(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:      "Reset with zero registers",
+			startRam:  []uint8{0, 0, 0, 0, 0x02, 0xA0, 0, 0},
+			wantState: State{PC: 0xA002, SP: 0xFD, P: FlagInterrupt},
+			wantRam:   []uint8{0, 0, 0, 0, 0x02, 0xA0, 0, 0},
+		},
+		{
+			name:       "Reset leaves the other registers and flags unchanged",
+			startState: State{PC: 0x1234, SP: 0x80, A: 0x12, X: 0x34, Y: 0x56, P: FlagCarry | FlagDecimal},
+			startRam:   []uint8{0xFF, 0xFF, 0xFF, 0xFF, 0x02, 0xA0, 0xFF, 0xFF},
+			wantState:  State{PC: 0xA002, SP: 0x7D, A: 0x12, X: 0x34, Y: 0x56, P: FlagCarry | FlagDecimal | FlagInterrupt},
+			wantRam:    []uint8{0xFF, 0xFF, 0xFF, 0xFF, 0x02, 0xA0, 0xFF, 0xFF},
+		},
+		{
+			name:       "Reset with the interrupt flag already set",
+			startState: State{SP: 0xFD, P: FlagInterrupt | FlagNegative},
+			startRam:   []uint8{0, 0, 0, 0, 0x02, 0xA0, 0, 0},
+			wantState:  State{PC: 0xA002, SP: 0xFA, P: FlagInterrupt | FlagNegative},
+			wantRam:    []uint8{0, 0, 0, 0, 0x02, 0xA0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, Reset)
+		})
+	}
+
+	if _, err := Reset(State{}, &Addressing{}); err != MemoryMustBeProvided {
+		t.Errorf("Reset() with no memory error = %v, want %v", err, MemoryMustBeProvided)
+	}
+}
+
 func TestReturnFromInterrupt(t *testing.T) {
 	tests := []testOperationConfig{
 		{
@@ -31,7 +31,7 @@ func testOperation(t *testing.T, tt testOperationConfig, operation Operation) {
 	}
 	memory := NewPopulatedRam(RepeatingRamSize(len(tt.startRam)), tt.startRam)
 	tt.addressing.Memory = &memory
-	got, err := operation(tt.startState, tt.addressing)
+	got, err := operation(tt.startState, &tt.addressing)
 
 	if (err != nil) != tt.wantErr {
 		t.Errorf("testOperation() error = %v, wantErr %v", err, tt.wantErr)
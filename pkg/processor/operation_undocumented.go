@@ -0,0 +1,333 @@
+package processor
+
+// ************************************************************
+// ********** Undocumented NMOS 6502 operation functions
+// ************************************************************
+
+// The following operations implement the undocumented (aka illegal) opcodes of the NMOS
+// 6502. Most are combinations of two documented operations that the decoding logic of the
+// processor happens to activate at the same time. Details from:
+//   - https://www.masswerk.at/nowgobang/2021/6502-illegal-opcodes
+//   - https://csdb.dk/release/?id=212346 (No More Secrets, NMOS 6510 Unintended Opcodes)
+
+// MagicConstants holds the values that the unstable ANE and LXA operations OR with the
+// accumulator before the operation is performed. On real hardware the value depends on
+// the individual chip, its temperature and even the data bus, so it is configurable.
+type MagicConstants struct {
+	Ane uint8 // The magic constant used by ANE (aka XAA).
+	Lxa uint8 // The magic constant used by LXA (aka LAX immediate).
+}
+
+// DefaultMagicConstants are the most commonly observed magic constants.
+var DefaultMagicConstants = MagicConstants{Ane: 0xEE, Lxa: 0xEE}
+
+// AndWithACopyToCarry (ANC). Bitwise AND memory with accumulator register A and then
+// copy the negative flag into the carry flag.
+func AndWithACopyToCarry(state State, addressing *Addressing) (State, error) {
+
+	state, err := AndWithA(state, addressing)
+	if err != nil {
+		return state, err
+	}
+
+	state.P.ClearCarry()
+	if state.P.ToFlags().Negative {
+		state.P.SetCarry()
+	}
+
+	return state, nil
+}
+
+// AndWithAShiftRight (ALR). Bitwise AND memory with accumulator register A and then
+// shift the accumulator right one bit.
+func AndWithAShiftRight(state State, addressing *Addressing) (State, error) {
+
+	return LogicalShiftRight(state, &Addressing{Accumulator: true, Value: addressing.Value & state.A})
+}
+
+// AndWithARotateRight (ARR). Bitwise AND memory with accumulator register A and then
+// rotate the accumulator right one bit. The flags are not set as they would be by ROR,
+// instead the carry is set from bit 6 of the result and overflow is set from bit 6
+// exclusive or bit 5 of the result. In decimal mode the result is then BCD corrected
+// in a similar way to ADC with the negative and zero flags reflecting the uncorrected
+// result.
+func AndWithARotateRight(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value & state.A
+	result := value >> 1
+	if state.P.ToFlags().Carry {
+		result |= 0x80
+	}
+
+	negativeSet(&state.P, uint16(result))
+	zeroSet(&state.P, uint16(result))
+
+	if !state.P.ToFlags().Decimal {
+		state.P.ClearCarry()
+		if result&0x40 != 0 {
+			state.P.SetCarry()
+		}
+		state.P.ClearOverflow()
+		if (result^(result<<1))&0x40 != 0 {
+			state.P.SetOverflow()
+		}
+
+		state.A = result
+		return state, nil
+	}
+
+	state.P.ClearOverflow()
+	if (result^value)&0x40 != 0 {
+		state.P.SetOverflow()
+	}
+
+	// Correct the lower nibble.
+	if (value&0x0F)+(value&0x01) > 0x05 {
+		result = (result & 0xF0) | ((result + 0x06) & 0x0F)
+	}
+
+	// Correct the upper nibble, which also determines the carry.
+	state.P.ClearCarry()
+	if uint16(value&0xF0)+uint16(value&0x10) > 0x50 {
+		result += 0x60
+		state.P.SetCarry()
+	}
+
+	state.A = result
+	return state, nil
+}
+
+// AndXWithMagic returns the operation for ANE (aka XAA). The accumulator is ORed with
+// the magic constant, then ANDed with X and the value in memory. This is highly unstable
+// on real hardware and should not be relied upon; it is provided for completeness.
+func AndXWithMagic(magic uint8) Operation {
+	return func(state State, addressing *Addressing) (State, error) {
+
+		state.A = (state.A | magic) & state.X & addressing.Value
+
+		negativeSet(&state.P, uint16(state.A))
+		zeroSet(&state.P, uint16(state.A))
+
+		return state, nil
+	}
+}
+
+// DecrementAndCompare (DCP). Decrement memory by one and then compare the result with
+// the accumulator.
+func DecrementAndCompare(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value - 1
+
+	compare(&state.P, state.A, value)
+
+	return addressing.Store(state, value)
+}
+
+// IncrementAndSubtract (ISC aka ISB). Increment memory by one and then subtract the
+// result from the accumulator with borrow.
+func IncrementAndSubtract(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value + 1
+
+	state, err := addressing.Store(state, value)
+	if err != nil {
+		return state, err
+	}
+
+	return SubtractWithCarry(state, &Addressing{Value: value})
+}
+
+// Halt (JAM aka KIL). Halt the processor until it is reset. On real hardware the bus
+// is locked up and neither IRQ nor NMI are serviced.
+func Halt(state State, addressing *Addressing) (State, error) {
+	addressing.RunState = Halted
+	return state, nil
+}
+
+// LoadAAndX (LAX). Load both the accumulator and X with memory.
+func LoadAAndX(state State, addressing *Addressing) (State, error) {
+
+	state.A = addressing.Value
+	state.X = addressing.Value
+
+	negativeSet(&state.P, uint16(state.A))
+	zeroSet(&state.P, uint16(state.A))
+
+	return state, nil
+}
+
+// LoadAAndXWithMagic returns the operation for LXA (aka LAX immediate). The accumulator
+// is ORed with the magic constant and then ANDed with the value in memory; the result is
+// loaded into both the accumulator and X. This is unstable on real hardware.
+func LoadAAndXWithMagic(magic uint8) Operation {
+	return func(state State, addressing *Addressing) (State, error) {
+
+		state.A = (state.A | magic) & addressing.Value
+		state.X = state.A
+
+		negativeSet(&state.P, uint16(state.A))
+		zeroSet(&state.P, uint16(state.A))
+
+		return state, nil
+	}
+}
+
+// LoadAXAndSPWithSP (LAS aka LAR). Bitwise AND memory with the stack pointer and load
+// the result into the accumulator, X and the stack pointer.
+func LoadAXAndSPWithSP(state State, addressing *Addressing) (State, error) {
+
+	state.SP = addressing.Value & state.SP
+	state.A = state.SP
+	state.X = state.SP
+
+	negativeSet(&state.P, uint16(state.A))
+	zeroSet(&state.P, uint16(state.A))
+
+	return state, nil
+}
+
+// RotateLeftAndWithA (RLA). Rotate memory left one bit and then bitwise AND the result
+// with the accumulator.
+func RotateLeftAndWithA(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value << 1
+	if state.P.ToFlags().Carry {
+		value |= 0x01
+	}
+
+	state.P.ClearCarry()
+	if addressing.Value&0x80 != 0 {
+		state.P.SetCarry()
+	}
+
+	state, err := addressing.Store(state, value)
+	if err != nil {
+		return state, err
+	}
+
+	return AndWithA(state, &Addressing{Value: value})
+}
+
+// RotateRightAddWithCarry (RRA). Rotate memory right one bit and then add the result to
+// the accumulator with the carry rotated out of memory.
+func RotateRightAddWithCarry(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value >> 1
+	if state.P.ToFlags().Carry {
+		value |= 0x80
+	}
+
+	state.P.ClearCarry()
+	if addressing.Value&0x01 != 0 {
+		state.P.SetCarry()
+	}
+
+	state, err := addressing.Store(state, value)
+	if err != nil {
+		return state, err
+	}
+
+	return AddWithCarry(state, &Addressing{Value: value})
+}
+
+// ShiftLeftOrWithA (SLO aka ASO). Shift memory left one bit and then bitwise OR the
+// result with the accumulator.
+func ShiftLeftOrWithA(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value << 1
+
+	state.P.ClearCarry()
+	if addressing.Value&0x80 != 0 {
+		state.P.SetCarry()
+	}
+
+	state, err := addressing.Store(state, value)
+	if err != nil {
+		return state, err
+	}
+
+	return OrWithA(state, &Addressing{Value: value})
+}
+
+// ShiftRightExclusiveOrWithA (SRE aka LSE). Shift memory right one bit and then bitwise
+// exclusive or the result with the accumulator.
+func ShiftRightExclusiveOrWithA(state State, addressing *Addressing) (State, error) {
+
+	value := addressing.Value >> 1
+
+	state.P.ClearCarry()
+	if addressing.Value&0x01 != 0 {
+		state.P.SetCarry()
+	}
+
+	state, err := addressing.Store(state, value)
+	if err != nil {
+		return state, err
+	}
+
+	return ExclusiveOrWithA(state, &Addressing{Value: value})
+}
+
+// StoreAAndX (SAX aka AXS). Store the bitwise AND of the accumulator and X to memory.
+// No flags are affected.
+func StoreAAndX(state State, addressing *Addressing) (State, error) {
+	return addressing.Store(state, state.A&state.X)
+}
+
+// storeWithHigh performs the store of the unstable SHA, SHX, SHY and TAS operations.
+// The value stored is ANDed with the high byte of the base address plus one. If indexing
+// crossed a page boundary then the high byte of the effective address is replaced with
+// the value stored.
+func storeWithHigh(state State, addressing *Addressing, value uint8) (State, error) {
+
+	high := uint8(addressing.EffectiveAddress >> 8)
+	if !addressing.PageBoundaryCrossed {
+		high++
+	}
+	value &= high
+
+	if addressing.PageBoundaryCrossed {
+		addressing.EffectiveAddress = (addressing.EffectiveAddress & 0x00FF) | Address(value)<<8
+	}
+
+	return addressing.Store(state, value)
+}
+
+// StoreAAndXAndHigh (SHA aka AHX). Store the bitwise AND of the accumulator, X and the
+// high byte of the address plus one to memory. This is unstable on real hardware.
+func StoreAAndXAndHigh(state State, addressing *Addressing) (State, error) {
+	return storeWithHigh(state, addressing, state.A&state.X)
+}
+
+// StoreXAndHigh (SHX aka SXA). Store the bitwise AND of X and the high byte of the
+// address plus one to memory. This is unstable on real hardware.
+func StoreXAndHigh(state State, addressing *Addressing) (State, error) {
+	return storeWithHigh(state, addressing, state.X)
+}
+
+// StoreYAndHigh (SHY aka SYA). Store the bitwise AND of Y and the high byte of the
+// address plus one to memory. This is unstable on real hardware.
+func StoreYAndHigh(state State, addressing *Addressing) (State, error) {
+	return storeWithHigh(state, addressing, state.Y)
+}
+
+// SubtractFromAAndX (SBX aka AXS). X is set to the bitwise AND of the accumulator and X
+// minus the value in memory without borrow. The flags are set as they would be by CMP;
+// the decimal flag is ignored.
+func SubtractFromAAndX(state State, addressing *Addressing) (State, error) {
+
+	value := state.A & state.X
+	compare(&state.P, value, addressing.Value)
+	state.X = value - addressing.Value
+
+	return state, nil
+}
+
+// TransferAAndXToSPAndStore (TAS aka SHS). Transfer the bitwise AND of the accumulator
+// and X to the stack pointer and then store the bitwise AND of the stack pointer and the
+// high byte of the address plus one to memory. This is unstable on real hardware.
+func TransferAAndXToSPAndStore(state State, addressing *Addressing) (State, error) {
+
+	state.SP = state.A & state.X
+	return storeWithHigh(state, addressing, state.SP)
+}
@@ -0,0 +1,581 @@
+package processor
+
+import "testing"
+
+func TestAndWithACopyToCarry(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ANC with bit 7 set sets negative and carry.",
+			startState: State{A: 0xFF},
+			addressing: Addressing{Value: 0x80},
+			wantState:  State{A: 0x80, P: FlagNegative | FlagCarry},
+		},
+		{
+			name:       "ANC with bit 7 clear clears negative and carry.",
+			startState: State{A: 0xFF, P: FlagNegative | FlagCarry},
+			addressing: Addressing{Value: 0x7F},
+			wantState:  State{A: 0x7F},
+		},
+		{
+			name:       "ANC with a zero result sets zero.",
+			startState: State{A: 0xF0, P: FlagCarry},
+			addressing: Addressing{Value: 0x0F},
+			wantState:  State{A: 0x00, P: FlagZero},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, AndWithACopyToCarry)
+		})
+	}
+}
+
+func TestAndWithAShiftRight(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ALR shifts bit 0 into carry.",
+			startState: State{A: 0xFF},
+			addressing: Addressing{Value: 0x03},
+			wantState:  State{A: 0x01, P: FlagCarry},
+		},
+		{
+			name:       "ALR with a zero result sets zero and clears carry.",
+			startState: State{A: 0xF0, P: FlagCarry | FlagNegative},
+			addressing: Addressing{Value: 0x0F},
+			wantState:  State{A: 0x00, P: FlagZero},
+		},
+		{
+			name:       "ALR never sets negative.",
+			startState: State{A: 0xFF},
+			addressing: Addressing{Value: 0xFE},
+			wantState:  State{A: 0x7F},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, AndWithAShiftRight)
+		})
+	}
+}
+
+func TestAndWithARotateRight(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ARR binary; carry set from bit 6.",
+			startState: State{A: 0xFF},
+			addressing: Addressing{Value: 0xFF},
+			wantState:  State{A: 0x7F, P: FlagCarry},
+		},
+		{
+			name:       "ARR binary; carry rotated into bit 7.",
+			startState: State{A: 0xFF, P: FlagCarry},
+			addressing: Addressing{Value: 0xFF},
+			wantState:  State{A: 0xFF, P: FlagNegative | FlagCarry},
+		},
+		{
+			name:       "ARR binary; overflow set from bit 6 XOR bit 5.",
+			startState: State{A: 0xFF, P: FlagCarry},
+			addressing: Addressing{Value: 0x40},
+			wantState:  State{A: 0xA0, P: FlagNegative | FlagOverflow},
+		},
+		{
+			name:       "ARR binary; zero result.",
+			startState: State{A: 0xFF},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x00, P: FlagZero},
+		},
+		{
+			name:       "ARR decimal; no correction.",
+			startState: State{A: 0x22, P: FlagDecimal},
+			addressing: Addressing{Value: 0xFF},
+			wantState:  State{A: 0x11, P: FlagDecimal},
+		},
+		{
+			name:       "ARR decimal; both nibbles corrected.",
+			startState: State{A: 0xFF, P: FlagDecimal},
+			addressing: Addressing{Value: 0xFF},
+			wantState:  State{A: 0xD5, P: FlagDecimal | FlagCarry},
+		},
+		{
+			name:       "ARR decimal; overflow from bit 6 changing.",
+			startState: State{A: 0xFF, P: FlagDecimal},
+			addressing: Addressing{Value: 0x40},
+			wantState:  State{A: 0x20, P: FlagDecimal | FlagOverflow},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, AndWithARotateRight)
+		})
+	}
+}
+
+func TestAndXWithMagic(t *testing.T) {
+	tests := []struct {
+		magic uint8
+		tt    testOperationConfig
+	}{
+		{
+			magic: 0xEE,
+			tt: testOperationConfig{
+				name:       "ANE with magic 0xEE.",
+				startState: State{A: 0x00, X: 0xFF},
+				addressing: Addressing{Value: 0xFF},
+				wantState:  State{A: 0xEE, X: 0xFF, P: FlagNegative},
+			},
+		},
+		{
+			magic: 0xFF,
+			tt: testOperationConfig{
+				name:       "ANE with magic 0xFF.",
+				startState: State{A: 0x00, X: 0x0F},
+				addressing: Addressing{Value: 0x3C},
+				wantState:  State{A: 0x0C, X: 0x0F},
+			},
+		},
+		{
+			magic: 0x00,
+			tt: testOperationConfig{
+				name:       "ANE with magic 0x00 and a zero result.",
+				startState: State{A: 0x00, X: 0xFF},
+				addressing: Addressing{Value: 0xFF},
+				wantState:  State{A: 0x00, X: 0xFF, P: FlagZero},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.tt.name, func(t *testing.T) {
+			testOperation(t, test.tt, AndXWithMagic(test.magic))
+		})
+	}
+}
+
+func TestDecrementAndCompare(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "DCP result equal to A.",
+			startState: State{A: 0x10},
+			addressing: Addressing{Value: 0x11},
+			wantState:  State{A: 0x10, P: FlagZero | FlagCarry},
+			wantRam:    []uint8{0x10, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "DCP result greater than A.",
+			startState: State{A: 0x10, P: FlagCarry},
+			addressing: Addressing{EffectiveAddress: 0x0003, Value: 0x00},
+			wantState:  State{A: 0x10},
+			wantRam:    []uint8{0, 0, 0, 0xFF, 0, 0, 0, 0},
+		},
+		{
+			name:       "DCP result less than A.",
+			startState: State{A: 0x80},
+			addressing: Addressing{Value: 0x01},
+			wantState:  State{A: 0x80, P: FlagNegative | FlagCarry},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, DecrementAndCompare)
+		})
+	}
+}
+
+func TestHalt(t *testing.T) {
+	state := State{A: 0x12, X: 0x34, Y: 0x56, P: FlagNegative}
+	addressing := Addressing{}
+	got, err := Halt(state, &addressing)
+	if err != nil || got != state {
+		t.Errorf("Halt() got = %v, err = %v, want = %v", got, err, state)
+	}
+	if addressing.RunState != Halted {
+		t.Errorf("Halt() RunState got = %v, want = %v", addressing.RunState, Halted)
+	}
+}
+
+func TestIncrementAndSubtract(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "ISC result equal to A.",
+			startState: State{A: 0x10, P: FlagCarry},
+			addressing: Addressing{Value: 0x0F},
+			wantState:  State{A: 0x00, P: FlagZero | FlagCarry},
+			wantRam:    []uint8{0x10, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "ISC memory wraps around to zero.",
+			startState: State{A: 0x00, P: FlagCarry},
+			addressing: Addressing{EffectiveAddress: 0x0002, Value: 0xFF},
+			wantState:  State{A: 0x00, P: FlagZero | FlagCarry},
+		},
+		{
+			name:       "ISC with a borrow.",
+			startState: State{A: 0x00, P: FlagCarry},
+			addressing: Addressing{Value: 0x00},
+			wantState:  State{A: 0xFF, P: FlagNegative},
+			wantRam:    []uint8{0x01, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "ISC in decimal mode.",
+			startState: State{A: 0x20, P: FlagCarry | FlagDecimal},
+			addressing: Addressing{Value: 0x08},
+			wantState:  State{A: 0x11, P: FlagCarry | FlagDecimal},
+			wantRam:    []uint8{0x09, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, IncrementAndSubtract)
+		})
+	}
+}
+
+func TestLoadAAndX(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "LAX loads a negative value.",
+			addressing: Addressing{Value: 0x80},
+			wantState:  State{A: 0x80, X: 0x80, P: FlagNegative},
+		},
+		{
+			name:       "LAX loads zero.",
+			startState: State{A: 0x12, X: 0x34, P: FlagNegative},
+			addressing: Addressing{Value: 0x00},
+			wantState:  State{P: FlagZero},
+		},
+		{
+			name:       "LAX does not change other registers.",
+			startState: State{PC: 0x1234, SP: 0x56, Y: 0x78, P: FlagCarry},
+			addressing: Addressing{Value: 0x42},
+			wantState:  State{PC: 0x1234, SP: 0x56, Y: 0x78, A: 0x42, X: 0x42, P: FlagCarry},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, LoadAAndX)
+		})
+	}
+}
+
+func TestLoadAAndXWithMagic(t *testing.T) {
+	tests := []struct {
+		magic uint8
+		tt    testOperationConfig
+	}{
+		{
+			magic: 0xEE,
+			tt: testOperationConfig{
+				name:       "LXA with magic 0xEE.",
+				startState: State{A: 0x01},
+				addressing: Addressing{Value: 0xFF},
+				wantState:  State{A: 0xEF, X: 0xEF, P: FlagNegative},
+			},
+		},
+		{
+			magic: 0xFF,
+			tt: testOperationConfig{
+				name:       "LXA with magic 0xFF behaves like LAX.",
+				startState: State{A: 0x00, X: 0x12},
+				addressing: Addressing{Value: 0x34},
+				wantState:  State{A: 0x34, X: 0x34},
+			},
+		},
+		{
+			magic: 0x00,
+			tt: testOperationConfig{
+				name:       "LXA with magic 0x00 and a zero result.",
+				startState: State{A: 0xF0, X: 0x12},
+				addressing: Addressing{Value: 0x0F},
+				wantState:  State{A: 0x00, X: 0x00, P: FlagZero},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.tt.name, func(t *testing.T) {
+			testOperation(t, test.tt, LoadAAndXWithMagic(test.magic))
+		})
+	}
+}
+
+func TestLoadAXAndSPWithSP(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "LAS ANDs memory with SP.",
+			startState: State{SP: 0xF0},
+			addressing: Addressing{Value: 0x3F},
+			wantState:  State{SP: 0x30, A: 0x30, X: 0x30},
+		},
+		{
+			name:       "LAS with a negative result.",
+			startState: State{SP: 0xFF, A: 0x01, X: 0x02},
+			addressing: Addressing{Value: 0x81},
+			wantState:  State{SP: 0x81, A: 0x81, X: 0x81, P: FlagNegative},
+		},
+		{
+			name:       "LAS with a zero result.",
+			startState: State{SP: 0xF0, A: 0x01, X: 0x02},
+			addressing: Addressing{Value: 0x0F},
+			wantState:  State{P: FlagZero},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, LoadAXAndSPWithSP)
+		})
+	}
+}
+
+func TestRotateLeftAndWithA(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "RLA rotates carry in and bit 7 out.",
+			startState: State{A: 0xFF, P: FlagCarry},
+			addressing: Addressing{Value: 0x81},
+			wantState:  State{A: 0x03, P: FlagCarry},
+			wantRam:    []uint8{0x03, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "RLA with a negative result.",
+			startState: State{A: 0x80},
+			addressing: Addressing{EffectiveAddress: 0x0001, Value: 0x40},
+			wantState:  State{A: 0x80, P: FlagNegative},
+			wantRam:    []uint8{0, 0x80, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "RLA with a zero result.",
+			startState: State{A: 0x0F},
+			addressing: Addressing{Value: 0x80},
+			wantState:  State{A: 0x00, P: FlagZero | FlagCarry},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, RotateLeftAndWithA)
+		})
+	}
+}
+
+func TestRotateRightAddWithCarry(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "RRA without carry.",
+			startState: State{A: 0x10},
+			addressing: Addressing{Value: 0x02},
+			wantState:  State{A: 0x11},
+			wantRam:    []uint8{0x01, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "RRA adds the carry rotated out of memory.",
+			startState: State{A: 0x10},
+			addressing: Addressing{Value: 0x03},
+			wantState:  State{A: 0x12},
+			wantRam:    []uint8{0x01, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "RRA rotates carry into bit 7 and the addition carries.",
+			startState: State{A: 0x7F, P: FlagCarry},
+			addressing: Addressing{EffectiveAddress: 0x0007, Value: 0x01},
+			wantState:  State{A: 0x00, P: FlagZero | FlagCarry},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0, 0, 0x80},
+		},
+		{
+			name:       "RRA in decimal mode.",
+			startState: State{A: 0x09, P: FlagDecimal},
+			addressing: Addressing{Value: 0x02},
+			wantState:  State{A: 0x10, P: FlagDecimal},
+			wantRam:    []uint8{0x01, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, RotateRightAddWithCarry)
+		})
+	}
+}
+
+func TestShiftLeftOrWithA(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SLO shifts bit 7 into carry.",
+			startState: State{A: 0x10},
+			addressing: Addressing{Value: 0x81},
+			wantState:  State{A: 0x12, P: FlagCarry},
+			wantRam:    []uint8{0x02, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "SLO with a negative result.",
+			startState: State{P: FlagCarry},
+			addressing: Addressing{EffectiveAddress: 0x0004, Value: 0x40},
+			wantState:  State{A: 0x80, P: FlagNegative},
+			wantRam:    []uint8{0, 0, 0, 0, 0x80, 0, 0, 0},
+		},
+		{
+			name:       "SLO with a zero result.",
+			addressing: Addressing{Value: 0x80},
+			wantState:  State{A: 0x00, P: FlagZero | FlagCarry},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, ShiftLeftOrWithA)
+		})
+	}
+}
+
+func TestShiftRightExclusiveOrWithA(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SRE shifts bit 0 into carry.",
+			startState: State{A: 0xFF},
+			addressing: Addressing{Value: 0x03},
+			wantState:  State{A: 0xFE, P: FlagNegative | FlagCarry},
+			wantRam:    []uint8{0x01, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "SRE with a zero result.",
+			startState: State{A: 0x01, P: FlagCarry},
+			addressing: Addressing{EffectiveAddress: 0x0005, Value: 0x02},
+			wantState:  State{A: 0x00, P: FlagZero},
+			wantRam:    []uint8{0, 0, 0, 0, 0, 0x01, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, ShiftRightExclusiveOrWithA)
+		})
+	}
+}
+
+func TestStoreAAndX(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SAX stores A AND X.",
+			startState: State{A: 0xF0, X: 0x3C},
+			addressing: Addressing{EffectiveAddress: 0x0002},
+			wantState:  State{A: 0xF0, X: 0x3C},
+			wantRam:    []uint8{0, 0, 0x30, 0, 0, 0, 0, 0},
+		},
+		{
+			name:       "SAX does not affect the flags.",
+			startState: State{A: 0x0F, X: 0xF0, P: FlagNegative},
+			addressing: Addressing{EffectiveAddress: 0x0001},
+			wantState:  State{A: 0x0F, X: 0xF0, P: FlagNegative},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, StoreAAndX)
+		})
+	}
+}
+
+func TestStoreWithHigh(t *testing.T) {
+	tests := []struct {
+		name        string
+		operation   Operation
+		state       State
+		addressing  Addressing
+		wantState   State
+		wantAddress Address
+		wantValue   uint8
+	}{
+		{
+			name:        "SHA stores A AND X AND the high byte plus 1.",
+			operation:   StoreAAndXAndHigh,
+			state:       State{A: 0xFF, X: 0xF7},
+			addressing:  Addressing{EffectiveAddress: 0x1234},
+			wantState:   State{A: 0xFF, X: 0xF7},
+			wantAddress: 0x1234,
+			wantValue:   0x13,
+		},
+		{
+			name:        "SHA crossing a page replaces the high byte of the address.",
+			operation:   StoreAAndXAndHigh,
+			state:       State{A: 0x0F, X: 0xFF},
+			addressing:  Addressing{EffectiveAddress: 0x1305, PageBoundaryCrossed: true},
+			wantState:   State{A: 0x0F, X: 0xFF},
+			wantAddress: 0x0305,
+			wantValue:   0x03,
+		},
+		{
+			name:        "SHX stores X AND the high byte plus 1.",
+			operation:   StoreXAndHigh,
+			state:       State{X: 0xFF},
+			addressing:  Addressing{EffectiveAddress: 0x7F00},
+			wantState:   State{X: 0xFF},
+			wantAddress: 0x7F00,
+			wantValue:   0x80,
+		},
+		{
+			name:        "SHX crossing a page replaces the high byte of the address.",
+			operation:   StoreXAndHigh,
+			state:       State{X: 0x21},
+			addressing:  Addressing{EffectiveAddress: 0x4001, PageBoundaryCrossed: true},
+			wantState:   State{X: 0x21},
+			wantAddress: 0x0001,
+			wantValue:   0x00,
+		},
+		{
+			name:        "SHY stores Y AND the high byte plus 1.",
+			operation:   StoreYAndHigh,
+			state:       State{Y: 0x0F},
+			addressing:  Addressing{EffectiveAddress: 0x0210},
+			wantState:   State{Y: 0x0F},
+			wantAddress: 0x0210,
+			wantValue:   0x03,
+		},
+		{
+			name:        "TAS transfers A AND X to SP and stores SP AND the high byte plus 1.",
+			operation:   TransferAAndXToSPAndStore,
+			state:       State{A: 0xF3, X: 0x3F, SP: 0xFF},
+			addressing:  Addressing{EffectiveAddress: 0x3333},
+			wantState:   State{A: 0xF3, X: 0x3F, SP: 0x33},
+			wantAddress: 0x3333,
+			wantValue:   0x34 & 0x33,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &traceMemory{}
+			tt.addressing.Memory = memory
+			got, err := tt.operation(tt.state, &tt.addressing)
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+			if got != tt.wantState {
+				t.Errorf("State got = %v, want = %v", got, tt.wantState)
+			}
+			want := []busAccess{{write: true, address: tt.wantAddress, value: tt.wantValue}}
+			if len(memory.trace) != 1 || memory.trace[0] != want[0] {
+				t.Errorf("bus activity got = %v, want = %v", memory.trace, want)
+			}
+		})
+	}
+}
+
+func TestSubtractFromAAndX(t *testing.T) {
+	tests := []testOperationConfig{
+		{
+			name:       "SBX without borrow.",
+			startState: State{A: 0xFF, X: 0x0F},
+			addressing: Addressing{Value: 0x05},
+			wantState:  State{A: 0xFF, X: 0x0A, P: FlagCarry},
+		},
+		{
+			name:       "SBX with borrow ignores the carry.",
+			startState: State{A: 0xFF, X: 0x0F, P: FlagCarry},
+			addressing: Addressing{Value: 0x10},
+			wantState:  State{A: 0xFF, X: 0xFF, P: FlagNegative},
+		},
+		{
+			name:       "SBX with a zero result ignores decimal mode.",
+			startState: State{A: 0x1F, X: 0xF1, P: FlagDecimal},
+			addressing: Addressing{Value: 0x11},
+			wantState:  State{A: 0x1F, X: 0x00, P: FlagDecimal | FlagZero | FlagCarry},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOperation(t, tt, SubtractFromAAndX)
+		})
+	}
+}
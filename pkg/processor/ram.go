@@ -0,0 +1,129 @@
+package processor
+
+import (
+	"errors"
+	"io"
+)
+
+// FlatRam is 64K of RAM that fills the whole address space. The contents can also be
+// accessed directly by indexing it with an Address.
+type FlatRam [0x10000]uint8
+
+// NewFlatRam returns a FlatRam that is filled with zeros.
+func NewFlatRam() *FlatRam {
+	return &FlatRam{}
+}
+
+// Read a value from the RAM.
+func (r *FlatRam) Read(address Address) uint8 {
+	return r[address]
+}
+
+// Write a value to the RAM.
+func (r *FlatRam) Write(address Address, value uint8) {
+	r[address] = value
+}
+
+// Size returns the number of bytes in the RAM, which is always 64K.
+func (r *FlatRam) Size() int {
+	return len(r)
+}
+
+// Fill repeats the pattern across the whole of the RAM, such as the alternating blocks of
+// $00 and $FF found in many machines at power on. An empty pattern fills it with zeros.
+func (r *FlatRam) Fill(pattern ...uint8) {
+	fill(r[:], pattern)
+}
+
+// ReadFrom loads the RAM from the reader, starting at $0000, until it is full or the reader
+// returns io.EOF. It returns the number of bytes loaded. See io.ReaderFrom.
+func (r *FlatRam) ReadFrom(reader io.Reader) (int64, error) {
+	return readFrom(r[:], reader)
+}
+
+// WriteTo dumps the whole of the RAM to the writer. See io.WriterTo.
+func (r *FlatRam) WriteTo(writer io.Writer) (int64, error) {
+	n, err := writer.Write(r[:])
+	return int64(n), err
+}
+
+// Ram is RAM of any size up to 64K, addressed from zero, that can be mapped anywhere using
+// a Bus. Reads beyond the end of the RAM return zero and writes are ignored.
+type Ram struct {
+	data []uint8
+}
+
+// NewRam returns a Ram of the size that is filled with zeros. An InvalidMemorySizeProvided
+// error is returned if the size is not between 1 byte and 64K.
+func NewRam(size int) (*Ram, error) {
+	if size <= 0 || size > 0x10000 {
+		return nil, InvalidMemorySizeProvided
+	}
+	return &Ram{data: make([]uint8, size)}, nil
+}
+
+// Read a value from the RAM.
+func (r *Ram) Read(address Address) uint8 {
+	if int(address) >= len(r.data) {
+		return 0
+	}
+	return r.data[address]
+}
+
+// Write a value to the RAM.
+func (r *Ram) Write(address Address, value uint8) {
+	if int(address) >= len(r.data) {
+		return
+	}
+	r.data[address] = value
+}
+
+// Size returns the number of bytes in the RAM.
+func (r *Ram) Size() int {
+	return len(r.data)
+}
+
+// Bytes returns the contents of the RAM, which are changed by writes to the RAM and vice
+// versa.
+func (r *Ram) Bytes() []uint8 {
+	return r.data
+}
+
+// Fill repeats the pattern across the whole of the RAM. An empty pattern fills it with
+// zeros.
+func (r *Ram) Fill(pattern ...uint8) {
+	fill(r.data, pattern)
+}
+
+// ReadFrom loads the RAM from the reader, starting at $0000, until it is full or the reader
+// returns io.EOF. It returns the number of bytes loaded. See io.ReaderFrom.
+func (r *Ram) ReadFrom(reader io.Reader) (int64, error) {
+	return readFrom(r.data, reader)
+}
+
+// WriteTo dumps the whole of the RAM to the writer. See io.WriterTo.
+func (r *Ram) WriteTo(writer io.Writer) (int64, error) {
+	n, err := writer.Write(r.data)
+	return int64(n), err
+}
+
+// fill repeats the pattern across the data.
+func fill(data, pattern []uint8) {
+	if len(pattern) == 0 {
+		clear(data)
+		return
+	}
+	// Each copy doubles the length of the repeated pattern.
+	for n := copy(data, pattern); n < len(data); n *= 2 {
+		copy(data[n:], data[:n])
+	}
+}
+
+// readFrom reads into the data until it is full or the reader is at its end.
+func readFrom(data []uint8, reader io.Reader) (int64, error) {
+	n, err := io.ReadFull(reader, data)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+	return int64(n), err
+}
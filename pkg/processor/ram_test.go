@@ -0,0 +1,162 @@
+package processor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestNewRam(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		wantErr error
+	}{
+		{name: "One byte", size: 1},
+		{name: "Odd size", size: 0x0C00},
+		{name: "64K", size: 0x10000},
+		{name: "Empty", size: 0, wantErr: InvalidMemorySizeProvided},
+		{name: "Larger than 64K", size: 0x10001, wantErr: InvalidMemorySizeProvided},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram, err := NewRam(tt.size)
+			if err != tt.wantErr {
+				t.Fatalf("NewRam() error got = %v, want = %v", err, tt.wantErr)
+			}
+			if err == nil && (ram.Size() != tt.size || len(ram.Bytes()) != tt.size) {
+				t.Errorf("Size() got = %v, want = %v", ram.Size(), tt.size)
+			}
+		})
+	}
+}
+
+func TestRam_ReadWrite(t *testing.T) {
+	ram, err := NewRam(0x0C00)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat := NewFlatRam()
+	tests := []struct {
+		name    string
+		memory  SizedMemory
+		address Address
+		want    uint8
+	}{
+		{name: "Ram", memory: ram, address: 0x0BFF, want: 0x42},
+		{name: "Ram beyond the end", memory: ram, address: 0x0C00, want: 0x00},
+		{name: "FlatRam", memory: flat, address: 0xFFFF, want: 0x42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.memory.Write(tt.address, 0x42)
+			if got := tt.memory.Read(tt.address); got != tt.want {
+				t.Errorf("Read() got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+	if flat[0xFFFF] != 0x42 || ram.Bytes()[0x0BFF] != 0x42 {
+		t.Errorf("the contents were not written")
+	}
+}
+
+func TestRam_Fill(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern []uint8
+		want    []uint8 // The start of the RAM.
+	}{
+		{name: "Zeros", pattern: nil, want: []uint8{0x00, 0x00, 0x00}},
+		{name: "Value", pattern: []uint8{0xEA}, want: []uint8{0xEA, 0xEA, 0xEA}},
+		{name: "Pattern", pattern: []uint8{0x00, 0xFF, 0x55}, want: []uint8{0x00, 0xFF, 0x55, 0x00, 0xFF, 0x55, 0x00}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram, err := NewRam(0x0A01)
+			if err != nil {
+				t.Fatal(err)
+			}
+			flat := NewFlatRam()
+			ram.Fill(0x11)
+			flat.Fill(0x11)
+			ram.Fill(tt.pattern...)
+			flat.Fill(tt.pattern...)
+
+			for _, memory := range []SizedMemory{ram, flat} {
+				for i := range memory.Size() {
+					want := uint8(0)
+					if len(tt.pattern) > 0 {
+						want = tt.pattern[i%len(tt.pattern)]
+					}
+					if got := memory.Read(Address(i)); got != want {
+						t.Fatalf("Read($%04X) got = %v, want = %v", i, got, want)
+					}
+				}
+			}
+			if !bytes.HasPrefix(ram.Bytes(), tt.want) {
+				t.Errorf("Fill() got = %v, want = %v", ram.Bytes()[:len(tt.want)], tt.want)
+			}
+		})
+	}
+}
+
+func TestRam_ReadFromWriteTo(t *testing.T) {
+	ram, err := NewRam(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat := NewFlatRam()
+
+	// Loading stops when the RAM is full or at the end of the reader.
+	if n, err := ram.ReadFrom(bytes.NewReader([]uint8{1, 2, 3, 4, 5})); n != 4 || err != nil {
+		t.Errorf("ReadFrom() got = %v, %v, want = 4", n, err)
+	}
+	if n, err := flat.ReadFrom(bytes.NewReader([]uint8{1, 2, 3})); n != 3 || err != nil {
+		t.Errorf("ReadFrom() got = %v, %v, want = 3", n, err)
+	}
+	if flat[0x0002] != 3 || flat[0x0003] != 0 {
+		t.Errorf("ReadFrom() loaded the wrong contents")
+	}
+
+	var buffer bytes.Buffer
+	if n, err := ram.WriteTo(&buffer); n != 4 || err != nil || !bytes.Equal(buffer.Bytes(), []uint8{1, 2, 3, 4}) {
+		t.Errorf("WriteTo() got = %v, %v, %v", n, err, buffer.Bytes())
+	}
+	buffer.Reset()
+	if n, err := flat.WriteTo(&buffer); n != 0x10000 || err != nil || !bytes.Equal(buffer.Bytes(), flat[:]) {
+		t.Errorf("WriteTo() got = %v, %v", n, err)
+	}
+
+	failed := errors.New("failed")
+	if _, err := ram.ReadFrom(failingReader{failed}); err != failed {
+		t.Errorf("ReadFrom() error got = %v, want = %v", err, failed)
+	}
+}
+
+// failingReader is an io.Reader that always returns the error.
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read([]uint8) (int, error) {
+	return 0, r.err
+}
+
+func TestRam_Bus(t *testing.T) {
+	// A 3K RAM, which is not a power of two, mapped at $2000.
+	ram, err := NewRam(0x0C00)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus, err := NewBusBuilder().Add(Mapping{Name: "RAM", Start: 0x2000, End: 0x2BFF, Device: ram}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus.Write(0x2ABC, 0x42)
+	if got := ram.Read(0x0ABC); got != 0x42 {
+		t.Errorf("the RAM at $0ABC got = %v, want = $42", got)
+	}
+	if _, err := NewBusBuilder().Map("RAM", 0x2000, 0x2FFF, ram).Build(); !errors.Is(err, DeviceSizeMismatch) {
+		t.Errorf("Build() error got = %v, want = %v", err, DeviceSizeMismatch)
+	}
+}
@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"io"
+	"os"
+)
+
+// RomWrite is a write to a Rom, which is ignored.
+type RomWrite struct {
+	Address Address // The address within the Rom.
+	Value   uint8
+}
+
+// Rom is a read only memory of up to 64K, addressed from zero. Writes do not change its
+// contents but are counted, passed to the write handler if there is one and, if enabled
+// with SetFaultOnWrite, reported as a MemoryFaultError, so software that scribbles over its
+// firmware can be caught. A Rom is a SizedMemory, so a Bus checks it exactly fills the range
+// it is mapped to, and a FaultingMemory.
+type Rom struct {
+	data         []uint8
+	onWrite      func(address Address, value uint8)
+	faultOnWrite bool
+	writes       uint64
+	last         RomWrite
+	fault        MemoryFaultError
+	faulty       bool
+}
+
+// NewRom returns a Rom containing a copy of the data. An InvalidMemorySizeProvided error is
+// returned if the data is empty or larger than 64K.
+func NewRom(data []uint8) (*Rom, error) {
+	if len(data) == 0 || len(data) > 0x10000 {
+		return nil, InvalidMemorySizeProvided
+	}
+	return &Rom{data: append([]uint8(nil), data...)}, nil
+}
+
+// ReadRom returns a Rom containing everything read from the reader, which must be between 1
+// byte and 64K.
+func ReadRom(reader io.Reader) (*Rom, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, 0x10000+1))
+	if err != nil {
+		return nil, err
+	}
+	return NewRom(data)
+}
+
+// LoadRom returns a Rom containing the file, which must be between 1 byte and 64K.
+func LoadRom(name string) (*Rom, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRom(file)
+}
+
+// Size returns the number of bytes in the Rom.
+func (r *Rom) Size() int {
+	return len(r.data)
+}
+
+// Read returns the byte at the address, or zero if it is beyond the end of the Rom.
+func (r *Rom) Read(address Address) uint8 {
+	if int(address) >= len(r.data) {
+		return 0
+	}
+	return r.data[address]
+}
+
+// Write leaves the Rom unchanged, recording the write and passing it to the write handler.
+func (r *Rom) Write(address Address, value uint8) {
+	r.writes++
+	r.last = RomWrite{Address: address, Value: value}
+	if r.faultOnWrite && !r.faulty {
+		r.fault, r.faulty = MemoryFaultError{Address: address, Access: WriteAccess}, true
+	}
+	if r.onWrite != nil {
+		r.onWrite(address, value)
+	}
+}
+
+// SetWriteHandler sets the function called with every write to the Rom; it may be nil.
+func (r *Rom) SetWriteHandler(onWrite func(address Address, value uint8)) {
+	r.onWrite = onWrite
+}
+
+// SetFaultOnWrite sets whether writes are reported as a MemoryFaultError, which the Cpu
+// returns from Step or Tick.
+func (r *Rom) SetFaultOnWrite(fault bool) {
+	r.faultOnWrite = fault
+}
+
+// Writes returns the number of writes to the Rom and the most recent, which is only valid if
+// the number is not zero.
+func (r *Rom) Writes() (uint64, RomWrite) {
+	return r.writes, r.last
+}
+
+// Fault returns the first write since it was last called, clearing it, if SetFaultOnWrite
+// is enabled. See FaultingMemory.
+func (r *Rom) Fault() (MemoryFaultError, bool) {
+	fault, faulty := r.fault, r.faulty
+	r.fault, r.faulty = MemoryFaultError{}, false
+	return fault, faulty
+}
@@ -0,0 +1,147 @@
+package processor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewRom(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rom.bin")
+	if err := os.WriteFile(file, []uint8{0x01, 0x02, 0x03}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		load    func() (*Rom, error)
+		want    []uint8
+		wantErr error
+	}{
+		{name: "Slice", load: func() (*Rom, error) { return NewRom([]uint8{0xEA, 0x60}) }, want: []uint8{0xEA, 0x60}},
+		{name: "Reader", load: func() (*Rom, error) { return ReadRom(bytes.NewReader([]uint8{0x4C})) }, want: []uint8{0x4C}},
+		{name: "File", load: func() (*Rom, error) { return LoadRom(file) }, want: []uint8{0x01, 0x02, 0x03}},
+		{name: "64K", load: func() (*Rom, error) { return NewRom(make([]uint8, 0x10000)) }, want: make([]uint8, 0x10000)},
+		{name: "Empty", load: func() (*Rom, error) { return NewRom(nil) }, wantErr: InvalidMemorySizeProvided},
+		{
+			name:    "Reader larger than 64K",
+			load:    func() (*Rom, error) { return ReadRom(bytes.NewReader(make([]uint8, 0x10001))) },
+			wantErr: InvalidMemorySizeProvided,
+		},
+		{
+			name:    "Missing file",
+			load:    func() (*Rom, error) { return LoadRom(filepath.Join(t.TempDir(), "missing.bin")) },
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom, err := tt.load()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error got = %v, want = %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if rom.Size() != len(tt.want) {
+				t.Errorf("Size() got = %v, want = %v", rom.Size(), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got := rom.Read(Address(i)); got != want {
+					t.Errorf("Read($%04X) got = %v, want = %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRom_Write(t *testing.T) {
+	data := []uint8{0xA9, 0x00}
+	rom, err := NewRom(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[0] = 0xFF
+	if got := rom.Read(0x0000); got != 0xA9 {
+		t.Errorf("the Rom shares the data, Read() got = %v", got)
+	}
+
+	var handled []RomWrite
+	rom.SetWriteHandler(func(address Address, value uint8) {
+		handled = append(handled, RomWrite{Address: address, Value: value})
+	})
+	rom.Write(0x0000, 0x12)
+	rom.Write(0x0001, 0x34)
+	if rom.Read(0x0000) != 0xA9 || rom.Read(0x0001) != 0x00 {
+		t.Errorf("Write() changed the Rom")
+	}
+	if writes, last := rom.Writes(); writes != 2 || last != (RomWrite{Address: 0x0001, Value: 0x34}) {
+		t.Errorf("Writes() got = %v, %v", writes, last)
+	}
+	if len(handled) != 2 {
+		t.Errorf("the write handler got = %v, want 2 writes", handled)
+	}
+	if _, faulty := rom.Fault(); faulty {
+		t.Errorf("Fault() reported a write when SetFaultOnWrite is disabled")
+	}
+
+	// A write to the Rom on a Bus is returned by the Cpu as a fault at its address on the Bus.
+	rom.SetFaultOnWrite(true)
+	vectors, err := NewRom([]uint8{0x00, 0x02, 0x00, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	bus, err := NewBusBuilder().
+		Map("RAM", 0x0000, 0x03FF, &ram).
+		Map("ROM", 0xE000, 0xE001, rom).
+		Map("Vectors", 0xFFFC, 0xFFFF, vectors).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteContiguousDataToMemory(bus, 0x0200, []uint8{
+		0x8D, 0x01, 0xE0, // STA $E001
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cpu, err := NewCpu(newLegalInstructionSet(), bus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	var fault MemoryFaultError
+	if _, err := cpu.Step(); !errors.As(err, &fault) || fault.Address != 0xE001 || fault.Access != WriteAccess {
+		t.Errorf("Step() error got = %v, want a write fault at $E001", err)
+	}
+}
+
+func TestRom_Mapping(t *testing.T) {
+	rom, err := NewRom(make([]uint8, 0x2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		mapping Mapping
+		want    error
+	}{
+		{name: "Fits", mapping: Mapping{Start: 0xE000, End: 0xFFFF}},
+		{name: "Mirrored", mapping: Mapping{Start: 0x8000, End: 0xFFFF, Mask: 0x1FFF}},
+		{name: "Too small", mapping: Mapping{Start: 0xC000, End: 0xFFFF}, want: DeviceSizeMismatch},
+		{name: "Too large", mapping: Mapping{Start: 0xF000, End: 0xFFFF}, want: DeviceSizeMismatch},
+		{name: "Mirror too large", mapping: Mapping{Start: 0x8000, End: 0xFFFF, Mask: 0x0FFF}, want: DeviceSizeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mapping.Name, tt.mapping.Device = "ROM", rom
+			if _, err := NewBusBuilder().Add(tt.mapping).Build(); !errors.Is(err, tt.want) {
+				t.Errorf("Build() error got = %v, want = %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// The Runner executes a Cpu in its own goroutine. The Cpu is run in batches of cycles and
+// the Runner holds a lock while each batch executes, so other goroutines can safely read
+// the State or drive the interrupt lines between batches. When a clock rate is given, the
+// host time taken by the batches is measured and the Runner sleeps whenever it gets ahead
+// of the clock.
+
+// runnerMaxLag is how far the Runner may fall behind the clock before it stops trying to
+// catch up. Without this a host that stalls, such as a laptop that sleeps, would cause the
+// Cpu to run flat out until it had made up all the missed time.
+const runnerMaxLag = 100 * time.Millisecond
+
+// runnerDefaultBatchCycles is the batch size of a Runner without a clock rate.
+const runnerDefaultBatchCycles = 1000
+
+// runnerWaitingDelay is how long a Runner without a clock rate sleeps between batches while
+// the Cpu is Waiting, rather than spinning until another goroutine interrupts it.
+const runnerWaitingDelay = time.Millisecond
+
+// RunnerConfig describes how a Runner executes the Cpu.
+type RunnerConfig struct {
+	ClockRate   float64 // The clock rate of the Cpu in Hz, e.g. 1e6; zero runs as fast as possible.
+	BatchCycles uint    // The cycles executed between checks of the clock; zero uses 1ms of cycles.
+	StartPaused bool    // If true the Runner is paused until Resume is called.
+}
+
+// runnerCommand is sent to the goroutine of a Runner to change how it runs.
+type runnerCommand uint8
+
+const (
+	pauseCommand runnerCommand = iota
+	resumeCommand
+	stopCommand
+)
+
+// Runner executes a Cpu in the background until the Cpu returns an error, the context is
+// cancelled or Stop is called. Once a Runner has been started the Cpu must only be used
+// through the Runner (see Do), otherwise access to it is not synchronised.
+//
+// A Cpu that is Waiting for an interrupt continues to be run, with the cycles elapsing,
+// so that an interrupt injected from another goroutine can wake it. Without a clock rate
+// the batch ends once the Cpu is Waiting and the Runner sleeps for runnerWaitingDelay before
+// the next. Any other error, including CpuStopped and JamError, stops the Runner and is
+// returned by Wait.
+type Runner struct {
+	cpu         *Cpu
+	clockRate   float64
+	batchCycles uint
+	commands    chan runnerCommand
+	done        chan struct{}
+
+	mu      sync.Mutex // Held while the Cpu executes and to access the fields below.
+	started bool
+	paused  bool
+	err     error
+}
+
+// NewRunner returns a Runner for the Cpu that has not been started. An error is returned
+// if the Cpu is nil or the clock rate is negative.
+func NewRunner(cpu *Cpu, config RunnerConfig) (*Runner, error) {
+	if cpu == nil {
+		return nil, UninitialisedCpu
+	}
+	if config.ClockRate < 0 {
+		return nil, InvalidClockRate
+	}
+
+	batchCycles := config.BatchCycles
+	if batchCycles == 0 {
+		batchCycles = runnerDefaultBatchCycles
+		if config.ClockRate > 0 {
+			batchCycles = max(1, uint(config.ClockRate/1000))
+		}
+	}
+
+	return &Runner{
+		cpu:         cpu,
+		clockRate:   config.ClockRate,
+		batchCycles: batchCycles,
+		commands:    make(chan runnerCommand),
+		done:        make(chan struct{}),
+		paused:      config.StartPaused,
+	}, nil
+}
+
+// Start starts executing the Cpu in a new goroutine, which runs until the Cpu returns an
+// error, the context is cancelled or Stop is called. A Runner can only be started once.
+func (r *Runner) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started {
+		return RunnerAlreadyStarted
+	}
+	r.started = true
+
+	go func() {
+		err := r.run(ctx)
+		r.mu.Lock()
+		r.err = err
+		r.mu.Unlock()
+		close(r.done)
+	}()
+	return nil
+}
+
+// Wait waits for the Runner to finish and returns the reason it finished: nil if Stop was
+// called, the error of the context if it was cancelled, or the error returned by the Cpu.
+func (r *Runner) Wait() error {
+	if !r.isStarted() {
+		return RunnerNotStarted
+	}
+	<-r.done
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Done returns a channel that is closed when the Runner has finished.
+func (r *Runner) Done() <-chan struct{} {
+	return r.done
+}
+
+// Pause stops executing the Cpu, without finishing the Runner, until Resume is called. No
+// more cycles are executed once Pause has returned.
+func (r *Runner) Pause() error {
+	return r.send(pauseCommand)
+}
+
+// Resume continues executing a paused Cpu. The clock is measured from the point the Cpu
+// resumes, so the time spent paused is not made up.
+func (r *Runner) Resume() error {
+	return r.send(resumeCommand)
+}
+
+// Stop finishes the Runner, which then returns nil from Wait. No more cycles are executed
+// once Stop has returned.
+func (r *Runner) Stop() error {
+	return r.send(stopCommand)
+}
+
+// send passes the command to the goroutine of the Runner, which receives it between batches.
+func (r *Runner) send(command runnerCommand) error {
+	if !r.isStarted() {
+		return RunnerNotStarted
+	}
+	select {
+	case r.commands <- command:
+		return nil
+	case <-r.done:
+		return RunnerFinished
+	}
+}
+
+// isStarted returns true if Start has been called.
+func (r *Runner) isStarted() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.started
+}
+
+// Paused returns true if the Runner is paused.
+func (r *Runner) Paused() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused
+}
+
+// State returns a copy of the State of the Cpu, taken between instructions.
+func (r *Runner) State() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cpu.State
+}
+
+// Snapshot returns a copy of the State, RunState and Counters of the Cpu, all taken at the
+// same point between instructions.
+func (r *Runner) Snapshot() Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cpu.Snapshot()
+}
+
+// RunState returns the RunState of the Cpu.
+func (r *Runner) RunState() RunState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cpu.RunState()
+}
+
+// SetIrqLine asserts or releases the IRQ line of the Cpu on behalf of the source. The line
+// is sampled from the start of the next batch. See Cpu.SetIrqLine.
+func (r *Runner) SetIrqLine(source InterruptSource, asserted bool) error {
+	return r.Do(func(cpu *Cpu) error { return cpu.SetIrqLine(source, asserted) })
+}
+
+// SetNmiLine asserts or releases the NMI line of the Cpu on behalf of the source. The line
+// is sampled from the start of the next batch. See Cpu.SetNmiLine.
+func (r *Runner) SetNmiLine(source InterruptSource, asserted bool) error {
+	return r.Do(func(cpu *Cpu) error { return cpu.SetNmiLine(source, asserted) })
+}
+
+// Do calls the function with the Cpu while no instructions are being executed, returning
+// the error from the function. It can be used whether or not the Runner is running, for
+// example to reset the Cpu or to access its memory.
+func (r *Runner) Do(f func(cpu *Cpu) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return f(r.cpu)
+}
+
+// run executes the Cpu until it returns an error, the context is cancelled or the Runner
+// is stopped.
+func (r *Runner) run(ctx context.Context) error {
+	start := time.Now()
+	cycles := uint(0) // The cycles executed since start.
+
+	for {
+		if r.Paused() {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case command := <-r.commands:
+				if r.command(command) {
+					return nil
+				}
+				start, cycles = time.Now(), 0
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case command := <-r.commands:
+			if r.command(command) {
+				return nil
+			}
+			continue
+		default:
+		}
+
+		batch, waiting, err := r.execute()
+		if err != nil {
+			return err
+		}
+		if r.clockRate == 0 {
+			if waiting {
+				if finished, err := r.sleep(ctx, runnerWaitingDelay); finished {
+					return err
+				}
+			}
+			continue
+		}
+
+		cycles += batch
+		ahead := time.Duration(float64(cycles)/r.clockRate*float64(time.Second)) - time.Since(start)
+		if ahead < -runnerMaxLag {
+			start, cycles = time.Now(), 0
+		} else if ahead > 0 {
+			if finished, err := r.sleep(ctx, ahead); finished {
+				return err
+			}
+		}
+	}
+}
+
+// sleep waits for the duration unless a command is received or the context is cancelled
+// first. It returns true if the Runner should finish, along with the error to finish with.
+func (r *Runner) sleep(ctx context.Context, duration time.Duration) (bool, error) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return true, ctx.Err()
+	case command := <-r.commands:
+		return r.command(command), nil
+	case <-timer.C:
+	}
+	return false, nil
+}
+
+// command applies the command, returning true if the Runner should stop.
+func (r *Runner) command(command runnerCommand) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch command {
+	case pauseCommand:
+		r.paused = true
+	case resumeCommand:
+		r.paused = false
+	case stopCommand:
+		return true
+	}
+	return false
+}
+
+// execute runs a batch of cycles with the lock held, returning the number of cycles taken
+// and whether the Cpu is Waiting. Without a clock rate the batch ends once it is Waiting.
+func (r *Runner) execute() (uint, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cycles := uint(0)
+	for cycles < r.batchCycles {
+		stepCycles, err := r.cpu.Step()
+		cycles += stepCycles
+		if errors.Is(err, CpuWaiting) {
+			if r.clockRate == 0 {
+				return cycles, true, nil
+			}
+		} else if err != nil {
+			return cycles, false, err
+		}
+	}
+	return cycles, r.cpu.RunState() == Waiting, nil
+}
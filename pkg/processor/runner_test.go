@@ -0,0 +1,296 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newRunnerCpu returns a reset W65C02S Cpu with the program at $0200. The IRQ and NMI
+// handler at $0300 increments $0010 and returns.
+func newRunnerCpu(t *testing.T, program []uint8) *Cpu {
+	t.Helper()
+	is, err := NewInstructionSetBuilder().
+		AddMnemonics(AllW65C02SOpcodes()...).
+		WithInterruptOperations(InterruptCmos, NmiCmos).
+		WithResetOperation(ResetCmos).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	if err := WriteContiguousDataToMemory(&ram, 0x0200, program); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteContiguousDataToMemory(&ram, 0x0300, []uint8{
+		0xE6, 0x10, // INC $10
+		0x40, // RTI
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteResetVectorToMemory(&ram, 0x0200); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteIrqVectorToMemory(&ram, 0x0300); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteNmiVectorToMemory(&ram, 0x0300); err != nil {
+		t.Fatal(err)
+	}
+
+	cpu, err := NewCpu(is, &ram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	return &cpu
+}
+
+// loopForever is a program that never finishes.
+var loopForever = []uint8{
+	0x58,             // CLI
+	0x4C, 0x01, 0x02, // loop: JMP loop
+}
+
+// waitUntil polls the condition until it is true, failing the test if it takes too long.
+func waitUntil(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the runner")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunner_Finishes(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []uint8
+		cancel  bool
+		stop    bool
+		want    error
+	}{
+		{
+			name: "CPU stops",
+			program: []uint8{
+				0xA2, 0x10, // LDX #$10
+				0xCA,       // loop: DEX
+				0xD0, 0xFD, // BNE loop
+				0xDB, // STP
+			},
+			want: CpuStopped,
+		},
+		{name: "Context is cancelled", program: loopForever, cancel: true, want: context.Canceled},
+		{name: "Runner is stopped", program: loopForever, stop: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, err := NewRunner(newRunnerCpu(t, tt.program), RunnerConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if err := runner.Start(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if err := runner.Start(ctx); err != RunnerAlreadyStarted {
+				t.Errorf("Start() error got = %v, want = %v", err, RunnerAlreadyStarted)
+			}
+			if tt.cancel {
+				cancel()
+			}
+			if tt.stop {
+				if err := runner.Stop(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := runner.Wait(); !errors.Is(err, tt.want) {
+				t.Errorf("Wait() error got = %v, want = %v", err, tt.want)
+			}
+			if err := runner.Pause(); err != RunnerFinished {
+				t.Errorf("Pause() error got = %v, want = %v", err, RunnerFinished)
+			}
+		})
+	}
+}
+
+func TestRunner_PauseAndResume(t *testing.T) {
+	runner, err := NewRunner(newRunnerCpu(t, []uint8{
+		0xE6, 0x10, // loop: INC $10
+		0x80, 0xFC, // BRA loop
+	}), RunnerConfig{StartPaused: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Pause(); err != RunnerNotStarted {
+		t.Errorf("Pause() error got = %v, want = %v", err, RunnerNotStarted)
+	}
+	counter := func() uint8 {
+		var value uint8
+		_ = runner.Do(func(cpu *Cpu) error {
+			value = cpu.memory.Read(0x0010)
+			return nil
+		})
+		return value
+	}
+
+	if err := runner.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if !runner.Paused() || counter() != 0 || runner.State().PC != 0x0200 {
+		t.Fatalf("the runner started paused but executed, State = %v", runner.State())
+	}
+
+	if err := runner.Resume(); err != nil {
+		t.Fatal(err)
+	}
+	waitUntil(t, func() bool { return counter() != 0 })
+
+	if err := runner.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	state, value := runner.State(), counter()
+	time.Sleep(10 * time.Millisecond)
+	if runner.State() != state || counter() != value {
+		t.Errorf("the runner executed while paused, State = %v", runner.State())
+	}
+
+	if err := runner.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Wait(); err != nil {
+		t.Errorf("Wait() error got = %v, want = nil", err)
+	}
+}
+
+func TestRunner_Interrupts(t *testing.T) {
+	runner, err := NewRunner(newRunnerCpu(t, []uint8{
+		0xCB, // WAI
+		0xDB, // STP
+	}), RunnerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitUntil(t, func() bool { return runner.RunState() == Waiting })
+
+	// The NMI wakes the CPU, which then runs the handler and stops.
+	if err := runner.SetNmiLine(1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runner.Wait(); !errors.Is(err, CpuStopped) {
+		t.Fatalf("Wait() error got = %v, want = %v", err, CpuStopped)
+	}
+	if got := runner.Snapshot(); got.RunState != Stopped || got.Counters.Nmis != 1 {
+		t.Errorf("Snapshot() got = %+v", got)
+	}
+	_ = runner.Do(func(cpu *Cpu) error {
+		if got := cpu.memory.Read(0x0010); got != 1 {
+			t.Errorf("the NMI handler ran %v times, want = 1", got)
+		}
+		return nil
+	})
+}
+
+func TestRunner_ClockRate(t *testing.T) {
+	const clockRate = 100_000
+	program := []uint8{
+		0xA2, 0x00, // LDX #$00
+		0xCA,       // loop: DEX
+		0xD0, 0xFD, // BNE loop
+		0xDB, // STP
+	}
+	// LDX 2, DEX 2 * 256, BNE 3 * 255 + 2 and STP 3 is 1284 cycles, which takes 12.84ms.
+	const cycles = 1284
+
+	runner, err := NewRunner(newRunnerCpu(t, program), RunnerConfig{ClockRate: clockRate, BatchCycles: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := runner.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Wait(); !errors.Is(err, CpuStopped) {
+		t.Fatalf("Wait() error got = %v, want = %v", err, CpuStopped)
+	}
+
+	// The final batch is cut short by STP so it is not waited for.
+	want := time.Duration((cycles - 100) * float64(time.Second) / clockRate)
+	if elapsed := time.Since(start); elapsed < want {
+		t.Errorf("the runner took %v, want at least %v", elapsed, want)
+	}
+
+	if _, err := NewRunner(newRunnerCpu(t, program), RunnerConfig{ClockRate: -1}); err != InvalidClockRate {
+		t.Errorf("NewRunner() error got = %v, want = %v", err, InvalidClockRate)
+	}
+	if _, err := NewRunner(nil, RunnerConfig{}); err != UninitialisedCpu {
+		t.Errorf("NewRunner() error got = %v, want = %v", err, UninitialisedCpu)
+	}
+}
+
+func TestRunner_CommandWhileSleeping(t *testing.T) {
+	// Each batch of 1000 cycles at 1 kHz is followed by a second of sleep.
+	runner, err := NewRunner(newRunnerCpu(t, loopForever), RunnerConfig{ClockRate: 1000, BatchCycles: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitUntil(t, func() bool { return runner.Snapshot().Counters.Cycles >= 1000 })
+
+	start := time.Now()
+	if err := runner.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Wait(); err != nil {
+		t.Errorf("Wait() error got = %v, want = nil", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Pause() and Stop() took %v, want them to interrupt the sleep", elapsed)
+	}
+}
+
+func TestRunner_WaitingWithoutClockRate(t *testing.T) {
+	runner, err := NewRunner(newRunnerCpu(t, []uint8{
+		0xCB, // WAI
+		0xDB, // STP
+	}), RunnerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitUntil(t, func() bool { return runner.RunState() == Waiting })
+	before := runner.Snapshot().Counters.Cycles
+	time.Sleep(20 * time.Millisecond)
+
+	// The Runner sleeps between the cycles of a waiting Cpu rather than spinning.
+	if cycles := runner.Snapshot().Counters.Cycles - before; cycles > 100 {
+		t.Errorf("the waiting Cpu ran %v cycles in 20ms", cycles)
+	}
+	if err := runner.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if err := runner.Wait(); err != nil {
+		t.Errorf("Wait() error got = %v, want = nil", err)
+	}
+}
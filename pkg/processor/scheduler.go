@@ -0,0 +1,240 @@
+package processor
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// The Scheduler runs several Cpus that make up one machine, such as a C64 and its 1541 disk
+// drive, each with its own clock rate. The time of each Cpu is the number of cycles it has
+// executed divided by its clock rate, and the Cpu whose time is earliest always executes
+// the next cycle, using Cpu.Tick, so no Cpu gets more than a cycle ahead of the others. The
+// times are compared exactly using integers and ties are broken by the order the Cpus were
+// added, so the same machine always runs in the same order.
+//
+// The Cpus can share a Memory, by passing the same one to NewCpu, or have their own. Events
+// such as interrupts and handshakes between Cpus are scheduled for a cycle of the Cpu that
+// receives them and are delivered before it executes that cycle, even if it is in the
+// middle of an instruction. The bus activity within an instruction is only modelled for
+// the NMOS 6502 (see Cpu.Tick).
+
+// ProcessorID identifies a Cpu that has been added to a Scheduler.
+type ProcessorID int
+
+// ProcessorError is returned by the Scheduler when a Cpu, or an event delivered to it,
+// returns an error. It unwraps to the error that was returned.
+type ProcessorError struct {
+	ID    ProcessorID
+	Cycle uint64 // The cycle of the Cpu at which the error occurred.
+	Err   error
+}
+
+func (e ProcessorError) Error() string {
+	return fmt.Sprintf("processor %d at cycle %d: %v", e.ID, e.Cycle, e.Err)
+}
+
+func (e ProcessorError) Unwrap() error {
+	return e.Err
+}
+
+// Event is called with the Cpu it was scheduled for.
+type Event func(cpu *Cpu) error
+
+// scheduledEvent is an Event waiting to be delivered at a cycle of a Cpu.
+type scheduledEvent struct {
+	cycle    uint64
+	sequence uint64 // The order the event was scheduled, for events at the same cycle.
+	event    Event
+}
+
+// eventQueue is a heap of events ordered by cycle and then by the order they were scheduled.
+type eventQueue []scheduledEvent
+
+func (q eventQueue) Len() int { return len(q) }
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].cycle != q[j].cycle {
+		return q[i].cycle < q[j].cycle
+	}
+	return q[i].sequence < q[j].sequence
+}
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x any)   { *q = append(*q, x.(scheduledEvent)) }
+func (q *eventQueue) Pop() any {
+	old := *q
+	event := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return event
+}
+
+// scheduledProcessor is a Cpu run by the Scheduler.
+type scheduledProcessor struct {
+	cpu       *Cpu
+	clockRate uint64
+	cycles    uint64 // The cycles executed since the Cpu was added.
+	events    eventQueue
+}
+
+// Scheduler interleaves the execution of several Cpus in cycle order. It is not safe for use
+// by multiple goroutines; events and interrupts raised from within a Cpu, such as by a
+// memory mapped device, should use Schedule or Deliver.
+type Scheduler struct {
+	processors []*scheduledProcessor
+	sequence   uint64
+}
+
+// NewScheduler returns a Scheduler without any Cpus.
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add adds the Cpu to the Scheduler, starting at cycle zero, and returns the ProcessorID used
+// to refer to it. The clock rate is usually in Hz but only the ratios between the clock rates
+// matter, so a rate with a fraction can be scaled along with the others, for example 3579545
+// and 2000000 for 1.7897725 MHz and 1 MHz. An error is returned if the clock rate is zero.
+func (s *Scheduler) Add(cpu *Cpu, clockRate uint64) (ProcessorID, error) {
+	if cpu == nil {
+		return 0, UninitialisedCpu
+	}
+	if clockRate == 0 {
+		return 0, InvalidClockRate
+	}
+	s.processors = append(s.processors, &scheduledProcessor{cpu: cpu, clockRate: clockRate})
+	return ProcessorID(len(s.processors) - 1), nil
+}
+
+// Cpu returns the Cpu with the ProcessorID.
+func (s *Scheduler) Cpu(id ProcessorID) (*Cpu, error) {
+	p, err := s.processor(id)
+	if err != nil {
+		return nil, err
+	}
+	return p.cpu, nil
+}
+
+// Cycles returns the number of cycles the Cpu with the ProcessorID has executed.
+func (s *Scheduler) Cycles(id ProcessorID) (uint64, error) {
+	p, err := s.processor(id)
+	if err != nil {
+		return 0, err
+	}
+	return p.cycles, nil
+}
+
+// processor returns the processor with the ProcessorID.
+func (s *Scheduler) processor(id ProcessorID) (*scheduledProcessor, error) {
+	if id < 0 || int(id) >= len(s.processors) {
+		return nil, UnknownProcessor
+	}
+	return s.processors[id], nil
+}
+
+// Schedule schedules the event for the Cpu with the ProcessorID. It is delivered once the Cpu
+// has executed the cycle, before it executes the next one; if the Cpu is already past the
+// cycle then it is delivered before its next cycle. Events for the same cycle are delivered
+// in the order they were scheduled.
+func (s *Scheduler) Schedule(id ProcessorID, cycle uint64, event Event) error {
+	p, err := s.processor(id)
+	if err != nil {
+		return err
+	}
+	heap.Push(&p.events, scheduledEvent{cycle: cycle, sequence: s.sequence, event: event})
+	s.sequence++
+	return nil
+}
+
+// Deliver schedules the event for the Cpu identified by to, at its cycle that matches the
+// current time of the Cpu identified by from. This is used to pass interrupts and handshakes
+// between Cpus, for example from a device that is written to by the from Cpu.
+func (s *Scheduler) Deliver(from, to ProcessorID, event Event) error {
+	source, err := s.processor(from)
+	if err != nil {
+		return err
+	}
+	target, err := s.processor(to)
+	if err != nil {
+		return err
+	}
+
+	// The cycle of the target at the same time, rounded up: cycles * to rate / from rate.
+	hi, lo := bits.Mul64(source.cycles, target.clockRate)
+	if hi >= source.clockRate {
+		return s.Schedule(to, ^uint64(0), event)
+	}
+	cycle, remainder := bits.Div64(hi, lo, source.clockRate)
+	if remainder != 0 {
+		cycle++
+	}
+	return s.Schedule(to, cycle, event)
+}
+
+// Step executes a single cycle on the Cpu whose time is the earliest, first delivering any
+// events that are due. It returns the ProcessorID of the Cpu and true if the cycle completed
+// an instruction or interrupt sequence. A Cpu that is Waiting or Stopped is idle while the
+// others continue, so it takes a cycle and no error is returned (see Cpu.RunState). Any other
+// error is returned as a ProcessorError; the Cpu is still advanced by a cycle so that the
+// others continue to be run if the Scheduler is stepped again.
+func (s *Scheduler) Step() (ProcessorID, bool, error) {
+	if len(s.processors) == 0 {
+		return 0, false, NoProcessors
+	}
+
+	id := s.next()
+	p := s.processors[id]
+	for len(p.events) > 0 && p.events[0].cycle <= p.cycles {
+		event := heap.Pop(&p.events).(scheduledEvent)
+		if err := event.event(p.cpu); err != nil {
+			return id, false, ProcessorError{ID: id, Cycle: p.cycles, Err: err}
+		}
+	}
+
+	done, err := p.cpu.Tick()
+	switch {
+	case err == nil:
+	case errors.Is(err, CpuWaiting), errors.Is(err, CpuStopped):
+		err = nil
+	default:
+		err = ProcessorError{ID: id, Cycle: p.cycles, Err: err}
+	}
+	p.cycles++
+	return id, done, err
+}
+
+// RunUntil steps the Cpus until the Cpu with the ProcessorID has reached the cycle and all the
+// other Cpus have caught up with it. It stops at the first error.
+func (s *Scheduler) RunUntil(id ProcessorID, cycle uint64) error {
+	target, err := s.processor(id)
+	if err != nil {
+		return err
+	}
+	until := &scheduledProcessor{clockRate: target.clockRate, cycles: cycle}
+
+	for before(s.processors[s.next()], until) {
+		if _, _, err := s.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next returns the ProcessorID of the Cpu whose time is the earliest, the first added if
+// there is a tie.
+func (s *Scheduler) next() ProcessorID {
+	next := 0
+	for i, p := range s.processors[1:] {
+		if before(p, s.processors[next]) {
+			next = i + 1
+		}
+	}
+	return ProcessorID(next)
+}
+
+// before returns true if the time of a is earlier than the time of b. The times are the
+// cycles divided by the clock rates, which are compared by multiplying each by the other's
+// clock rate to avoid any rounding.
+func before(a, b *scheduledProcessor) bool {
+	aHi, aLo := bits.Mul64(a.cycles, b.clockRate)
+	bHi, bLo := bits.Mul64(b.cycles, a.clockRate)
+	return aHi < bHi || aHi == bHi && aLo < bLo
+}
@@ -0,0 +1,240 @@
+package processor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newScheduledCpu returns a reset W65C02S Cpu using the memory, with the program at $0200
+// and an NMI handler at $0300 that stops the Cpu.
+func newScheduledCpu(t *testing.T, memory Memory, program []uint8) *Cpu {
+	t.Helper()
+	is, err := NewInstructionSetBuilder().AddMnemonics(AllW65C02SOpcodes()...).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteContiguousDataToMemory(memory, 0x0200, program); err != nil {
+		t.Fatal(err)
+	}
+	memory.Write(0x0300, 0xDB) // STP
+	if err := WriteResetVectorToMemory(memory, 0x0200); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteNmiVectorToMemory(memory, 0x0300); err != nil {
+		t.Fatal(err)
+	}
+
+	cpu, err := NewCpu(is, memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	return &cpu
+}
+
+// nopLoop is a program of 2 cycle NOPs that never finishes.
+var nopLoop = func() []uint8 {
+	program := make([]uint8, 0x100)
+	for i := range program {
+		program[i] = 0xEA
+	}
+	return program
+}()
+
+func TestScheduler_Order(t *testing.T) {
+	tests := []struct {
+		name       string
+		clockRates []uint64
+		want       []ProcessorID
+	}{
+		{
+			name:       "Same clock rate alternates",
+			clockRates: []uint64{1_000_000, 1_000_000},
+			want:       []ProcessorID{0, 1, 0, 1, 0, 1},
+		},
+		{
+			name:       "Twice the clock rate steps twice as often",
+			clockRates: []uint64{2_000_000, 1_000_000},
+			want:       []ProcessorID{0, 1, 0, 0, 1, 0, 0, 1, 0},
+		},
+		{
+			name:       "Three processors",
+			clockRates: []uint64{1_000_000, 3_000_000, 1_500_000},
+			want:       []ProcessorID{0, 1, 2, 1, 1, 2, 0, 1, 1, 2, 1, 0, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := NewScheduler()
+			for _, clockRate := range tt.clockRates {
+				ram := NewPopulatedRam(OneKiloByte, nil)
+				if _, err := scheduler.Add(newScheduledCpu(t, &ram, nopLoop), clockRate); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Each processor completes a NOP every second cycle.
+			got := make([]ProcessorID, 0, len(tt.want))
+			steps := map[ProcessorID]int{}
+			for range tt.want {
+				id, done, err := scheduler.Step()
+				if err != nil {
+					t.Fatal(err)
+				}
+				steps[id]++
+				if want := steps[id]%2 == 0; done != want {
+					t.Errorf("Step() done got = %v, want = %v", done, want)
+				}
+				got = append(got, id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Step() order got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduler_SharedMemory(t *testing.T) {
+	// The first processor waits 8 cycles and then sets a flag that the second is polling.
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	writer := newScheduledCpu(t, &ram, []uint8{
+		0xEA, 0xEA, 0xEA, 0xEA, // NOP * 4
+		0xA9, 0x01, // LDA #$01
+		0x85, 0x10, // STA $10
+		0xDB, // STP
+	})
+	reader := *writer
+	reader.State.PC = 0x0280
+	if err := WriteContiguousDataToMemory(&ram, 0x0280, []uint8{
+		0xA5, 0x10, // loop: LDA $10
+		0xF0, 0xFC, // BEQ loop
+		0xDB, // STP
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	scheduler := NewScheduler()
+	writerID, _ := scheduler.Add(writer, 1_000_000)
+	readerID, _ := scheduler.Add(&reader, 1_000_000)
+
+	// The writer stores the flag from cycle 10 to 13, after NOP 8 and LDA 2, so the reader
+	// sees it with the LDA from cycle 12 to 15. The BEQ is not taken and STP runs from
+	// cycle 17 to 20.
+	for {
+		id, done, err := scheduler.Step()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id == readerID && done && reader.RunState() == Stopped {
+			break
+		}
+	}
+	if cycles, _ := scheduler.Cycles(readerID); cycles != 20 || reader.State.A != 0x01 {
+		t.Errorf("the reader stopped at cycle %v with State = %v, want cycle 20", cycles, reader.State)
+	}
+
+	// A stopped processor is idle while the others continue.
+	if err := scheduler.RunUntil(readerID, 100); err != nil {
+		t.Fatal(err)
+	}
+	if cycles, _ := scheduler.Cycles(writerID); cycles != 100 || writer.RunState() != Stopped {
+		t.Errorf("Cycles() of the writer got = %v, want = 100", cycles)
+	}
+}
+
+func TestScheduler_Events(t *testing.T) {
+	scheduler := NewScheduler()
+	var delivered []string
+	var cycles []uint64
+	for _, clockRate := range []uint64{1_000_000, 2_000_000} {
+		ram := NewPopulatedRam(OneKiloByte, nil)
+		if _, err := scheduler.Add(newScheduledCpu(t, &ram, nopLoop), clockRate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	record := func(name string) Event {
+		return func(*Cpu) error {
+			cycle, _ := scheduler.Cycles(1)
+			delivered = append(delivered, name)
+			cycles = append(cycles, cycle)
+			return nil
+		}
+	}
+
+	// Processor 1 runs twice as fast, so cycle 10 of processor 0 is cycle 20 of processor 1.
+	if err := scheduler.RunUntil(0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.Deliver(0, 1, func(cpu *Cpu) error { return cpu.SetNmiLine(1, true) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.Schedule(1, 27, record("second")); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.Schedule(1, 23, record("first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.Schedule(0, 30, record("never")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The NMI is sampled during the NOP at cycle 20 and taken at cycle 22, so the STP runs
+	// from cycle 29 to 32. The events are delivered at their cycles, during the NMI sequence.
+	for {
+		id, done, err := scheduler.Step()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cpu, _ := scheduler.Cpu(id)
+		if done && cpu.RunState() == Stopped {
+			break
+		}
+	}
+	if cycles, _ := scheduler.Cycles(1); cycles != 32 {
+		t.Errorf("Cycles() got = %v, want = 32", cycles)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(delivered, want) {
+		t.Errorf("delivered got = %v, want = %v", delivered, want)
+	}
+	if want := []uint64{23, 27}; !reflect.DeepEqual(cycles, want) {
+		t.Errorf("delivered at cycles got = %v, want = %v", cycles, want)
+	}
+
+	failed := errors.New("failed")
+	if err := scheduler.Schedule(0, 0, func(*Cpu) error { return failed }); err != nil {
+		t.Fatal(err)
+	}
+	var processorError ProcessorError
+	if _, _, err := scheduler.Step(); !errors.Is(err, failed) || !errors.As(err, &processorError) {
+		t.Errorf("Step() error got = %v, want = %v", err, failed)
+	}
+	if processorError.ID != 0 || processorError.Cycle != 16 {
+		t.Errorf("Step() error got = %v, want processor 0 at cycle 16", processorError)
+	}
+}
+
+func TestScheduler_Errors(t *testing.T) {
+	scheduler := NewScheduler()
+	if _, _, err := scheduler.Step(); err != NoProcessors {
+		t.Errorf("Step() error got = %v, want = %v", err, NoProcessors)
+	}
+	if _, err := scheduler.Add(nil, 1); err != UninitialisedCpu {
+		t.Errorf("Add() error got = %v, want = %v", err, UninitialisedCpu)
+	}
+	ram := NewPopulatedRam(OneKiloByte, nil)
+	if _, err := scheduler.Add(newScheduledCpu(t, &ram, nopLoop), 0); err != InvalidClockRate {
+		t.Errorf("Add() error got = %v, want = %v", err, InvalidClockRate)
+	}
+	if _, err := scheduler.Cpu(0); err != UnknownProcessor {
+		t.Errorf("Cpu() error got = %v, want = %v", err, UnknownProcessor)
+	}
+	if err := scheduler.Schedule(1, 0, nil); err != UnknownProcessor {
+		t.Errorf("Schedule() error got = %v, want = %v", err, UnknownProcessor)
+	}
+	if err := scheduler.RunUntil(-1, 0); err != UnknownProcessor {
+		t.Errorf("RunUntil() error got = %v, want = %v", err, UnknownProcessor)
+	}
+}
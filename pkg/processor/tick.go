@@ -0,0 +1,711 @@
+package processor
+
+// tickState records the progress of the instruction currently being executed by the
+// cycle-stepped core. The zero value represents an instruction boundary.
+type tickState struct {
+	instruction Instruction
+	pc          Address // The address the opcode was fetched from.
+	cycle       uint    // The cycle within the instruction; the opcode is fetched in cycle 1.
+	step        uint    // The cycle within the operation; counted once the address is known.
+	remaining   uint    // Idle cycles left for instructions without bus information.
+
+	servicing bool // Is the interrupt sequence being performed instead of an instruction.
+	nmi       bool // Has an NMI hijacked the interrupt sequence or BRK.
+	pollNmi   bool // The NMI edge detector as polled during the instruction.
+	pollIrq   bool // The IRQ line as polled during the instruction.
+	disabled  bool // The interrupt disable flag before the instruction.
+
+	address   Address // The effective address being built by the addressing mode.
+	unfixed   Address // The effective address before the page boundary was fixed up.
+	pointer   uint8   // The zero page pointer used by the indirect addressing modes.
+	value     uint8   // The value read from the effective address.
+	crossed   bool    // Did indexing cross a page boundary.
+	addressed bool    // Has the addressing mode finished calculating the effective address.
+
+	replay replayMemory
+}
+
+// replayMemory is used to call an Operation once the bus activity for the instruction
+// has already been performed cycle by cycle. Reads of locations already read during the
+// instruction return the values read at the time and writes are discarded as they have
+// already been performed on the bus. Any other read is passed on with its kind.
+type replayMemory struct {
+	memory    Memory
+	count     int
+	addresses [4]Address
+	values    [4]uint8
+}
+
+// reset clears all the captured reads and sets the memory to fall back on.
+func (r *replayMemory) reset(memory Memory) {
+	r.memory = memory
+	r.count = 0
+}
+
+// capture records the value read from the address.
+func (r *replayMemory) capture(address Address, value uint8) {
+	if r.count < len(r.addresses) {
+		r.addresses[r.count] = address
+		r.values[r.count] = value
+		r.count++
+	}
+}
+
+// Read returns the captured value if the address has been read, otherwise it reads memory.
+func (r *replayMemory) Read(address Address) uint8 {
+	for i := range r.count {
+		if r.addresses[i] == address {
+			return r.values[i]
+		}
+	}
+	return r.memory.Read(address)
+}
+
+// Write is discarded as the write has already been performed on the bus.
+func (r *replayMemory) Write(Address, uint8) {}
+
+// AccessRead returns the captured value if the address has been read, otherwise it reads
+// memory with the given kind of access.
+func (r *replayMemory) AccessRead(address Address, access Access) uint8 {
+	for i := range r.count {
+		if r.addresses[i] == address {
+			return r.values[i]
+		}
+	}
+	return readAccess(r.memory, address, access)
+}
+
+// AccessWrite is discarded as the write has already been performed on the bus.
+func (r *replayMemory) AccessWrite(Address, uint8, Access) {}
+
+// Tick advances the Cpu by exactly one clock cycle, performing the same bus activity
+// as a real NMOS 6502 in that cycle. This includes the dummy reads, the double write
+// of read-modify-write instructions and the stack reads of JSR, RTS and RTI. Tick
+// returns true when the cycle completed an instruction. If the opcode read is not
+// present in the CPUs instruction set then an UnknownOpcodeError is returned at the end of
+// the opcode fetch cycle. A fault reported by a FaultingMemory is returned by the cycle
+// that caused it as a MemoryFaultError.
+//
+// Instructions whose Mode or Type are unknown are executed in their first cycle
+// followed by the correct number of idle cycles (with no bus activity). This includes
+// every instruction of the 65C02 and W65C02S, whose bus activity differs from the NMOS
+// 6502 and is not modelled. For those CPUs Tick takes the right number of cycles, but all
+// the memory accesses of an instruction are made in its first cycle, without the dummy
+// reads, and the interrupt lines are polled as they would be by an NMOS 6502.
+//
+// If the Cpu is Waiting, Stopped or Halted at the start of an instruction then there
+// is no bus activity and either CpuWaiting, CpuStopped or a JamError is returned. The
+// JamError is also returned by the cycle that completes a JAM opcode.
+//
+// The interrupt lines are sampled at the end of every cycle. The samples are polled in
+// the final cycle of each instruction, so a line must be asserted before the second to
+// last cycle for the interrupt to be serviced after the instruction. A branch that is
+// taken without crossing a page does not poll in its final cycle. If an interrupt is
+// pending then the seven cycle interrupt sequence is performed instead of the next
+// instruction; Tick returns true once it has completed.
+func (c *Cpu) Tick() (bool, error) {
+	if c == nil {
+		return false, UninitialisedCpu
+	}
+
+	t := &c.tick
+	if t.cycle == 0 {
+		switch c.runState {
+		case Waiting:
+			if !c.wake() {
+				c.sampleInterrupts()
+				c.counters.Cycles++
+				return false, CpuWaiting
+			}
+		case Stopped:
+			return false, CpuStopped
+		case Halted:
+			return false, c.jam
+		}
+	}
+
+	t.cycle++
+	c.counters.Cycles++
+
+	if t.instruction.Type != BranchOperation || t.cycle != 3 || t.crossed {
+		t.pollNmi, t.pollIrq = c.interrupts.nmiEdge, c.interrupts.irq
+	}
+
+	done, err := c.tickCycle()
+	c.sampleInterrupts()
+	return done, c.fault(err)
+}
+
+// tickCycle performs the current cycle of the instruction or interrupt sequence.
+func (c *Cpu) tickCycle() (bool, error) {
+	t := &c.tick
+
+	if t.cycle == 1 {
+		return c.tickFetch()
+	}
+
+	if t.remaining > 0 {
+		t.remaining--
+		return c.tickDone(t.remaining == 0), nil
+	}
+
+	done, err := c.tickExecute()
+	if err != nil {
+		*t = tickState{}
+		return true, err
+	}
+	return c.tickDone(done), nil
+}
+
+// tickDone polls the interrupts, counts the instruction or interrupt and resets the tick
+// state at the end of an instruction. Interrupts are not polled during the interrupt sequence.
+func (c *Cpu) tickDone(done bool) bool {
+	if done {
+		t := &c.tick
+		switch {
+		case !t.servicing:
+			c.counters.Instructions++
+			c.pollInterrupts(&t.instruction, t.pollNmi, t.pollIrq, t.disabled)
+		case t.nmi:
+			c.counters.Nmis++
+		default:
+			c.counters.Irqs++
+		}
+		*t = tickState{}
+	}
+	return done
+}
+
+// tickFetch performs the opcode fetch cycle that starts every instruction.
+func (c *Cpu) tickFetch() (bool, error) {
+	t := &c.tick
+
+	// The opcode is read and discarded without advancing the program counter.
+	if c.interrupts.pending {
+		c.interrupts.pending = false
+		t.servicing = true
+		c.read(c.State.PC, DummyReadAccess)
+		return false, nil
+	}
+
+	t.pc = c.State.PC
+	t.disabled = c.State.P&FlagInterrupt != 0
+	opcode := Opcode(c.read(c.State.PC, OpcodeFetchAccess))
+	c.State.PC++
+	instruction := c.instructionSet.lookup(opcode)
+	if instruction == nil {
+		*t = tickState{}
+		return true, UnknownOpcodeError{PC: c.State.PC - 1, Opcode: opcode, State: c.State}
+	}
+	t.instruction = *instruction
+
+	// Without bus information the instruction is executed atomically.
+	if instruction.Mode == UnknownMode || instruction.Type == UnknownOperation {
+		state, cycles, err := c.execute(instruction)
+		if err != nil {
+			*t = tickState{}
+			return true, err
+		}
+		c.State = state
+		if err := c.changeRunState(c.addressing.RunState, t.pc, opcode); err != nil {
+			*t = tickState{}
+			return true, err
+		}
+		t.remaining = cycles
+		return c.tickDone(cycles == 0), nil
+	}
+
+	return false, nil
+}
+
+// read performs a single bus read of the given kind.
+func (c *Cpu) read(address Address, access Access) uint8 {
+	return c.bus.AccessRead(address, access)
+}
+
+// write performs a single bus write of the given kind.
+func (c *Cpu) write(address Address, value uint8, access Access) {
+	c.bus.AccessWrite(address, value, access)
+}
+
+// fetch reads the operand at the program counter and advances the program counter.
+func (c *Cpu) fetch() uint8 {
+	value := c.read(c.State.PC, OperandAccess)
+	c.State.PC++
+	return value
+}
+
+// stack returns the address in the stack offset from the stack pointer.
+func (c *Cpu) stack(offset uint8) Address {
+	return BaseStack + Address(c.State.SP+offset)
+}
+
+// operate calls the instructions' Operation with the given memory. This is always
+// called in the cycle that the Operation accesses the bus (if it does at all).
+func (c *Cpu) operate(memory Memory) error {
+	t := &c.tick
+	addressing := c.newAddressing(Addressing{
+		EffectiveAddress:    t.address,
+		Value:               t.value,
+		PageBoundaryCrossed: t.crossed,
+		Memory:              memory,
+	})
+	if t.instruction.Mode == AccumulatorMode {
+		addressing.Accumulator = true
+		addressing.Value = c.State.A
+	}
+
+	state, err := t.instruction.Operation(c.State, addressing)
+	if err != nil {
+		return err
+	}
+	c.State = state
+	return c.changeRunState(addressing.RunState, t.pc, t.instruction.Opcode)
+}
+
+// tickExecute performs a single cycle of the current instruction after the opcode
+// fetch. It returns true if this was the last cycle of the instruction.
+func (c *Cpu) tickExecute() (bool, error) {
+	t := &c.tick
+
+	if t.servicing {
+		return c.tickInterrupt()
+	}
+
+	if t.instruction.Operation == nil {
+		return true, NoOperationFunction
+	}
+
+	switch t.instruction.Type {
+	case BranchOperation:
+		return c.tickBranch()
+	case BreakOperation:
+		return c.tickBreak()
+	case JumpSubroutineOperation:
+		return c.tickJumpSubroutine()
+	case PullOperation:
+		return c.tickPull()
+	case PushOperation:
+		return c.tickPush()
+	case ReturnFromInterruptOperation:
+		return c.tickReturnFromInterrupt()
+	case ReturnFromSubroutineOperation:
+		return c.tickReturnFromSubroutine()
+	}
+
+	switch t.instruction.Mode {
+	case ImpliedMode, AccumulatorMode:
+		// The byte following the opcode is read and discarded. Longer instructions, such
+		// as WAI and STP, are idle for their remaining cycles.
+		c.read(c.State.PC, DummyReadAccess)
+		if t.instruction.Cycles > 1 {
+			t.remaining = t.instruction.Cycles - 1
+			return false, c.operate(&c.bus)
+		}
+		return true, c.operate(&c.bus)
+
+	case ImmediateMode:
+		t.address = c.State.PC
+		t.value = c.fetch()
+		return true, c.operate(&c.bus)
+	}
+
+	if !t.addressed {
+		t.addressed = c.tickAddress()
+		if !t.addressed || t.instruction.Type != JumpOperation {
+			return false, nil
+		}
+		return true, c.operate(&c.bus)
+	}
+
+	t.step++
+	switch t.instruction.Type {
+	case ReadOperation:
+		return c.tickRead()
+	case WriteOperation:
+		return c.tickWrite()
+	case ReadModifyWriteOperation:
+		return c.tickReadModifyWrite()
+	}
+
+	// Any other combination is simply executed once the address is known.
+	return true, c.operate(&c.bus)
+}
+
+// indexed returns true if the addressing mode adds an index to a 16-bit address and
+// therefore reads from the unfixed address before fixing the page boundary.
+func (c *Cpu) indexed() bool {
+	switch c.tick.instruction.Mode {
+	case AbsoluteXMode, AbsoluteYMode, IndirectYMode:
+		return true
+	}
+	return false
+}
+
+// tickAddress performs one cycle of the addressing mode. It returns true in the cycle
+// the effective address is known.
+func (c *Cpu) tickAddress() bool {
+	t := &c.tick
+
+	switch t.instruction.Mode {
+	case ZeroPageMode:
+		t.address = Address(c.fetch())
+		return true
+
+	case ZeroPageXMode, ZeroPageYMode:
+		if t.cycle == 2 {
+			t.address = Address(c.fetch())
+			return false
+		}
+		// The zero page address is read while the index is added, without carry.
+		c.read(t.address, DummyReadAccess)
+		index := c.State.X
+		if t.instruction.Mode == ZeroPageYMode {
+			index = c.State.Y
+		}
+		t.address = Address(uint8(t.address) + index)
+		return true
+
+	case AbsoluteMode:
+		if t.cycle == 2 {
+			t.address = Address(c.fetch())
+			return false
+		}
+		t.address |= Address(c.fetch()) << 8
+		return true
+
+	case AbsoluteXMode, AbsoluteYMode:
+		if t.cycle == 2 {
+			t.address = Address(c.fetch())
+			return false
+		}
+		index := c.State.X
+		if t.instruction.Mode == AbsoluteYMode {
+			index = c.State.Y
+		}
+		c.index(t.address|Address(c.fetch())<<8, index)
+		return true
+
+	case IndirectMode:
+		switch t.cycle {
+		case 2:
+			t.unfixed = Address(c.fetch())
+		case 3:
+			t.unfixed |= Address(c.fetch()) << 8
+		case 4:
+			t.address = Address(c.read(t.unfixed, ReadAccess))
+		default:
+			// The high byte of the pointer is not incremented so the page wraps around.
+			pointer := (t.unfixed & 0xFF00) | Address(uint8(t.unfixed)+1)
+			t.address |= Address(c.read(pointer, ReadAccess)) << 8
+			return true
+		}
+		return false
+
+	case IndirectXMode:
+		switch t.cycle {
+		case 2:
+			t.pointer = c.fetch()
+		case 3:
+			// The pointer is read while X is added, without carry.
+			c.read(Address(t.pointer), DummyReadAccess)
+			t.pointer += c.State.X
+		case 4:
+			t.address = Address(c.read(Address(t.pointer), ReadAccess))
+		default:
+			t.address |= Address(c.read(Address(t.pointer+1), ReadAccess)) << 8
+			return true
+		}
+		return false
+
+	case IndirectYMode:
+		switch t.cycle {
+		case 2:
+			t.pointer = c.fetch()
+		case 3:
+			t.address = Address(c.read(Address(t.pointer), ReadAccess))
+		default:
+			base := t.address | Address(c.read(Address(t.pointer+1), ReadAccess))<<8
+			c.index(base, c.State.Y)
+			return true
+		}
+		return false
+	}
+
+	// Unsupported modes behave like zero page.
+	t.address = Address(c.fetch())
+	return true
+}
+
+// index adds the index to the base address, recording the unfixed address (which is on
+// the same page as the base) and whether a page boundary was crossed.
+func (c *Cpu) index(base Address, index uint8) {
+	t := &c.tick
+	t.address = base + Address(index)
+	t.unfixed = (base & 0xFF00) | (t.address & 0x00FF)
+	t.crossed = t.unfixed != t.address
+}
+
+// tickRead performs the cycles of an operation that reads from the effective address.
+// If indexing crossed a page boundary the unfixed address is read first.
+func (c *Cpu) tickRead() (bool, error) {
+	t := &c.tick
+	if t.step == 1 && c.indexed() && t.crossed {
+		c.read(t.unfixed, DummyReadAccess)
+		return false, nil
+	}
+	t.value = c.read(t.address, ReadAccess)
+	return true, c.operate(&c.bus)
+}
+
+// tickWrite performs the cycles of an operation that writes to the effective address.
+// Indexed addressing always reads the unfixed address before writing.
+func (c *Cpu) tickWrite() (bool, error) {
+	t := &c.tick
+	if t.step == 1 && c.indexed() {
+		c.read(t.unfixed, DummyReadAccess)
+		return false, nil
+	}
+	return true, c.operate(&c.bus)
+}
+
+// tickReadModifyWrite performs the cycles of an operation that reads, modifies and then
+// writes the effective address. The unmodified value is written back before the modified
+// value. Indexed addressing always reads the unfixed address first.
+func (c *Cpu) tickReadModifyWrite() (bool, error) {
+	t := &c.tick
+	step := t.step
+	if c.indexed() {
+		if step == 1 {
+			c.read(t.unfixed, DummyReadAccess)
+			return false, nil
+		}
+		step--
+	}
+
+	switch step {
+	case 1:
+		t.value = c.read(t.address, ReadAccess)
+		return false, nil
+	case 2:
+		c.write(t.address, t.value, WriteAccess)
+		return false, nil
+	}
+	return true, c.operate(&c.bus)
+}
+
+// tickBranch performs the cycles of a relative branch. A branch not taken requires two
+// cycles, a branch taken requires three and a branch taken to a different page four.
+// The opcode following the branch is read whilst the target is calculated, and the
+// target with the unfixed high byte is read while it is fixed.
+func (c *Cpu) tickBranch() (bool, error) {
+	t := &c.tick
+
+	switch t.cycle {
+	case 2:
+		offset := c.fetch()
+		relative := Address(offset)
+		if offset&0x80 != 0 {
+			relative |= 0xFF00
+		}
+		t.unfixed = c.State.PC
+		t.address = c.State.PC + relative
+
+		addressing := c.newAddressing(Addressing{
+			EffectiveAddress:    t.address,
+			Value:               offset,
+			PageBoundaryCrossed: (t.unfixed & 0xFF00) != (t.address & 0xFF00),
+			Memory:              &c.bus,
+		})
+		state, err := t.instruction.Operation(c.State, addressing)
+		if err != nil {
+			return true, err
+		}
+		if !addressing.BranchTaken {
+			c.State = state
+			return true, nil
+		}
+		t.value = offset
+		t.crossed = addressing.PageBoundaryCrossed
+		return false, nil
+
+	case 3:
+		c.read(t.unfixed, DummyReadAccess)
+		if !t.crossed {
+			c.State.PC = t.address
+			return true, nil
+		}
+		return false, nil
+	}
+
+	c.read((t.unfixed&0xFF00)|(t.address&0x00FF), DummyReadAccess)
+	c.State.PC = t.address
+	return true, nil
+}
+
+// tickBreak performs the seven cycles of BRK. The byte following the opcode is read
+// and skipped, the return address and status are pushed and the IRQ vector is read.
+// If an NMI is detected before the status is pushed then the NMI vector is read instead.
+func (c *Cpu) tickBreak() (bool, error) {
+	t := &c.tick
+
+	switch t.cycle {
+	case 2:
+		c.read(c.State.PC, DummyReadAccess)
+		t.replay.reset(&c.bus)
+	case 3:
+		c.write(c.stack(0), uint8((c.State.PC+1)>>8), StackWriteAccess)
+	case 4:
+		c.write(c.stack(0xFF), uint8(c.State.PC+1), StackWriteAccess)
+	case 5:
+		t.nmi = c.interrupts.nmiEdge
+		c.interrupts.nmiEdge = false
+		c.write(c.stack(0xFE), uint8(c.State.P.WithBreakSet()|FlagConstant), StackWriteAccess)
+	case 6:
+		// The Operation reads the IRQ vector, which is replayed from the vector read.
+		t.replay.capture(IrqVectorAddress, c.read(c.vector(IrqVectorAddress), ReadAccess))
+	default:
+		t.replay.capture(IrqVectorAddress+1, c.read(c.vector(IrqVectorAddress)+1, ReadAccess))
+		return true, c.operate(&t.replay)
+	}
+	return false, nil
+}
+
+// tickInterrupt performs the seven cycles of the interrupt sequence. It is the same as
+// BRK except the program counter is not advanced, the status is pushed without the break
+// flag and the vector is chosen when the status is pushed. An NMI detected by then is
+// serviced, hijacking an IRQ.
+func (c *Cpu) tickInterrupt() (bool, error) {
+	t := &c.tick
+
+	switch t.cycle {
+	case 2:
+		c.read(c.State.PC, DummyReadAccess)
+		t.replay.reset(&c.bus)
+	case 3:
+		c.write(c.stack(0), uint8(c.State.PC>>8), StackWriteAccess)
+	case 4:
+		c.write(c.stack(0xFF), uint8(c.State.PC), StackWriteAccess)
+	case 5:
+		t.nmi = c.interrupts.nmiEdge
+		c.interrupts.nmiEdge = false
+		c.write(c.stack(0xFE), uint8(c.State.P.WithConstantSet()), StackWriteAccess)
+	case 6:
+		vector := c.vector(IrqVectorAddress)
+		t.replay.capture(vector, c.read(vector, ReadAccess))
+	default:
+		vector := c.vector(IrqVectorAddress) + 1
+		t.replay.capture(vector, c.read(vector, ReadAccess))
+
+		operation := c.instructionSet.interruptOperation()
+		if t.nmi {
+			operation = c.instructionSet.nmiOperation()
+		}
+		state, err := operation(c.State, c.newAddressing(Addressing{Memory: &t.replay}))
+		if err != nil {
+			return true, err
+		}
+		c.State = state
+		return true, nil
+	}
+	return false, nil
+}
+
+// vector returns the address of the vector read by BRK or the interrupt sequence, which
+// is the NMI vector if an NMI has hijacked it.
+func (c *Cpu) vector(address Address) Address {
+	if c.tick.nmi {
+		return NmiVectorAddress
+	}
+	return address
+}
+
+// tickJumpSubroutine performs the six cycles of JSR. The low byte of the target is read,
+// the stack is read, the return address is pushed and then the high byte of the target
+// is read.
+func (c *Cpu) tickJumpSubroutine() (bool, error) {
+	t := &c.tick
+
+	switch t.cycle {
+	case 2:
+		t.address = Address(c.fetch())
+		t.replay.reset(&c.bus)
+	case 3:
+		c.read(c.stack(0), DummyReadAccess)
+	case 4:
+		c.write(c.stack(0), uint8(c.State.PC>>8), StackWriteAccess)
+	case 5:
+		c.write(c.stack(0xFF), uint8(c.State.PC), StackWriteAccess)
+	default:
+		t.address |= Address(c.read(c.State.PC, OperandAccess)) << 8
+		c.State.PC++
+		return true, c.operate(&t.replay)
+	}
+	return false, nil
+}
+
+// tickPush performs the three cycles of PHA and PHP.
+func (c *Cpu) tickPush() (bool, error) {
+	if c.tick.cycle == 2 {
+		c.read(c.State.PC, DummyReadAccess)
+		return false, nil
+	}
+	return true, c.operate(&c.bus)
+}
+
+// tickPull performs the four cycles of PLA and PLP.
+func (c *Cpu) tickPull() (bool, error) {
+	switch c.tick.cycle {
+	case 2:
+		c.read(c.State.PC, DummyReadAccess)
+	case 3:
+		c.read(c.stack(0), DummyReadAccess)
+	default:
+		return true, c.operate(&c.bus)
+	}
+	return false, nil
+}
+
+// tickReturnFromInterrupt performs the six cycles of RTI.
+func (c *Cpu) tickReturnFromInterrupt() (bool, error) {
+	t := &c.tick
+
+	switch t.cycle {
+	case 2:
+		c.read(c.State.PC, DummyReadAccess)
+		t.replay.reset(&c.bus)
+	case 3:
+		c.read(c.stack(0), DummyReadAccess)
+	case 4, 5:
+		address := c.stack(uint8(t.cycle - 3))
+		t.replay.capture(address, c.read(address, StackReadAccess))
+	default:
+		address := c.stack(3)
+		t.replay.capture(address, c.read(address, StackReadAccess))
+		return true, c.operate(&t.replay)
+	}
+	return false, nil
+}
+
+// tickReturnFromSubroutine performs the six cycles of RTS. The final cycle reads the
+// byte at the return address before advancing past it.
+func (c *Cpu) tickReturnFromSubroutine() (bool, error) {
+	t := &c.tick
+
+	switch t.cycle {
+	case 2:
+		c.read(c.State.PC, DummyReadAccess)
+		t.replay.reset(&c.bus)
+	case 3:
+		c.read(c.stack(0), DummyReadAccess)
+	case 4, 5:
+		address := c.stack(uint8(t.cycle - 3))
+		value := c.read(address, StackReadAccess)
+		t.replay.capture(address, value)
+		t.address |= Address(value) << (8 * (t.cycle - 4))
+	default:
+		c.read(t.address, DummyReadAccess)
+		return true, c.operate(&t.replay)
+	}
+	return false, nil
+}
@@ -0,0 +1,344 @@
+package processor
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// busAccess records a single access to memory.
+type busAccess struct {
+	write   bool
+	address Address
+	value   uint8
+}
+
+// traceMemory is a full 64K memory that records every bus access made to it.
+type traceMemory struct {
+	ram   [0x10000]uint8
+	trace []busAccess
+}
+
+func (m *traceMemory) Read(address Address) uint8 {
+	m.trace = append(m.trace, busAccess{address: address, value: m.ram[address]})
+	return m.ram[address]
+}
+
+func (m *traceMemory) Write(address Address, value uint8) {
+	m.trace = append(m.trace, busAccess{write: true, address: address, value: value})
+	m.ram[address] = value
+}
+
+// newLegalInstructionSet returns an InstructionSet with all the known legal opcodes.
+func newLegalInstructionSet() InstructionSet {
+	instructions := make(Instructions, 0, 0x100)
+	for _, mnemonic := range AllOpcodes() {
+		instructions = append(instructions, NewInstruction(mnemonic))
+	}
+	is, err := NewInstructionSet(instructions)
+	if err != nil {
+		panic(err)
+	}
+	return is
+}
+
+// newExtendedInstructionSet returns an InstructionSet with all the known legal and
+// undocumented opcodes.
+func newExtendedInstructionSet() InstructionSet {
+	mnemonics := append(AllOpcodes(), AllUndocumentedOpcodes(DefaultMagicConstants)...)
+	instructions := make(Instructions, 0, len(mnemonics))
+	for _, mnemonic := range mnemonics {
+		instructions = append(instructions, NewInstruction(mnemonic))
+	}
+	is, err := NewInstructionSet(instructions)
+	if err != nil {
+		panic(err)
+	}
+	return is
+}
+
+// newW65C02SInstructionSet returns an InstructionSet with all the W65C02S opcodes. As
+// with the 65C02 instruction sets, Tick executes each instruction atomically.
+func newW65C02SInstructionSet() InstructionSet {
+	instructions := make(Instructions, 0, 0x100)
+	for _, mnemonic := range AllW65C02SOpcodes() {
+		instruction := NewInstruction(mnemonic)
+		instruction.Mode = UnknownMode
+		instructions = append(instructions, instruction)
+	}
+	is, err := NewInstructionSet(instructions)
+	if err != nil {
+		panic(err)
+	}
+	return is
+}
+
+// tickInstruction calls Tick until the instruction completes, returning the number
+// of cycles, the bus activity recorded in each cycle and any error from the last cycle.
+func tickInstruction(cpu *Cpu, memory *traceMemory) (uint, [][]busAccess, error) {
+	cycles := uint(0)
+	trace := make([][]busAccess, 0)
+	for {
+		memory.trace = nil
+		done, err := cpu.Tick()
+		cycles++
+		trace = append(trace, memory.trace)
+		if done || err != nil || cycles > 10 {
+			return cycles, trace, err
+		}
+	}
+}
+
+// Every legal and undocumented opcode is executed with random starting states and memory
+// using both Step and Tick, the resulting state, memory, cycles and errors must match.
+// The only error expected is from the JAM opcodes.
+func TestCpu_TickMatchesStep(t *testing.T) {
+	random := rand.New(rand.NewSource(6502))
+	is := newExtendedInstructionSet()
+
+	for _, opcode := range is.Opcodes() {
+		for range 20 {
+			stepMemory := &traceMemory{}
+			random.Read(stepMemory.ram[:])
+			state := State{
+				PC: Address(random.Intn(0x10000)),
+				SP: uint8(random.Intn(0x100)),
+				A:  uint8(random.Intn(0x100)),
+				X:  uint8(random.Intn(0x100)),
+				Y:  uint8(random.Intn(0x100)),
+				P:  Status(random.Intn(0x100)) | FlagConstant,
+			}
+			stepMemory.ram[state.PC] = uint8(opcode)
+			tickMemory := &traceMemory{ram: stepMemory.ram}
+
+			stepCpu, _ := NewCpu(is, stepMemory)
+			stepCpu.State = state
+			stepCycles, stepErr := stepCpu.Step()
+			if _, ok := stepErr.(JamError); stepErr != nil && !ok {
+				t.Fatalf("Step() unexpected error = %v", stepErr)
+			}
+
+			tickCpu, _ := NewCpu(is, tickMemory)
+			tickCpu.State = state
+			tickCycles, _, tickErr := tickInstruction(&tickCpu, tickMemory)
+
+			if tickErr != stepErr {
+				t.Errorf("Opcode $%02X from %v error got = %v, want = %v", opcode, state, tickErr, stepErr)
+			}
+			if tickCycles != stepCycles {
+				t.Errorf("Opcode $%02X from %v cycles got = %v, want = %v", opcode, state, tickCycles, stepCycles)
+			}
+			if tickCpu.State != stepCpu.State {
+				t.Errorf("Opcode $%02X from %v State got = %v, want = %v", opcode, state, tickCpu.State, stepCpu.State)
+			}
+			if tickMemory.ram != stepMemory.ram {
+				t.Errorf("Opcode $%02X from %v memory differs", opcode, state)
+			}
+		}
+	}
+}
+
+func TestCpu_Tick(t *testing.T) {
+	read := func(address Address, value uint8) []busAccess {
+		return []busAccess{{address: address, value: value}}
+	}
+	write := func(address Address, value uint8) []busAccess {
+		return []busAccess{{write: true, address: address, value: value}}
+	}
+
+	tests := []struct {
+		name      string
+		state     State
+		ram       map[Address]uint8
+		wantState State
+		wantTrace [][]busAccess
+	}{
+		{
+			name:      "NOP reads the following byte",
+			state:     State{PC: 0x0200},
+			ram:       map[Address]uint8{0x0200: 0xEA, 0x0201: 0x11},
+			wantState: State{PC: 0x0201},
+			wantTrace: [][]busAccess{read(0x0200, 0xEA), read(0x0201, 0x11)},
+		},
+		{
+			name:      "LDA absolute,X crossing a page reads the unfixed address first",
+			state:     State{PC: 0x0200, X: 0x20},
+			ram:       map[Address]uint8{0x0200: 0xBD, 0x0201: 0xF0, 0x0202: 0x12, 0x1210: 0x55, 0x1310: 0x66},
+			wantState: State{PC: 0x0203, X: 0x20, A: 0x66},
+			wantTrace: [][]busAccess{
+				read(0x0200, 0xBD), read(0x0201, 0xF0), read(0x0202, 0x12), read(0x1210, 0x55), read(0x1310, 0x66),
+			},
+		},
+		{
+			name:      "STA absolute,X always reads the unfixed address before writing",
+			state:     State{PC: 0x0200, X: 0x01, A: 0x99},
+			ram:       map[Address]uint8{0x0200: 0x9D, 0x0201: 0x00, 0x0202: 0x12},
+			wantState: State{PC: 0x0203, X: 0x01, A: 0x99},
+			wantTrace: [][]busAccess{
+				read(0x0200, 0x9D), read(0x0201, 0x00), read(0x0202, 0x12), read(0x1201, 0x00), write(0x1201, 0x99),
+			},
+		},
+		{
+			name:      "INC zero page writes the unmodified value before the modified value",
+			state:     State{PC: 0x0200},
+			ram:       map[Address]uint8{0x0200: 0xE6, 0x0201: 0x10, 0x0010: 0x41},
+			wantState: State{PC: 0x0202},
+			wantTrace: [][]busAccess{
+				read(0x0200, 0xE6), read(0x0201, 0x10), read(0x0010, 0x41), write(0x0010, 0x41), write(0x0010, 0x42),
+			},
+		},
+		{
+			name:      "BNE taken across a page reads the next opcode and the unfixed target",
+			state:     State{PC: 0x02F0},
+			ram:       map[Address]uint8{0x02F0: 0xD0, 0x02F1: 0x20, 0x02F2: 0xEA},
+			wantState: State{PC: 0x0312},
+			wantTrace: [][]busAccess{
+				read(0x02F0, 0xD0), read(0x02F1, 0x20), read(0x02F2, 0xEA), read(0x0212, 0x00),
+			},
+		},
+		{
+			name:      "JSR reads the stack before pushing the return address",
+			state:     State{PC: 0x0200, SP: 0xFD},
+			ram:       map[Address]uint8{0x0200: 0x20, 0x0201: 0x34, 0x0202: 0x12},
+			wantState: State{PC: 0x1234, SP: 0xFB},
+			wantTrace: [][]busAccess{
+				read(0x0200, 0x20), read(0x0201, 0x34), read(0x01FD, 0x00), write(0x01FD, 0x02), write(0x01FC, 0x02), read(0x0202, 0x12),
+			},
+		},
+		{
+			name:      "RTS reads the stack before pulling and reads the return address",
+			state:     State{PC: 0x1234, SP: 0xFB},
+			ram:       map[Address]uint8{0x1234: 0x60, 0x1235: 0xEA, 0x01FC: 0x02, 0x01FD: 0x02, 0x0202: 0x12},
+			wantState: State{PC: 0x0203, SP: 0xFD},
+			wantTrace: [][]busAccess{
+				read(0x1234, 0x60), read(0x1235, 0xEA), read(0x01FB, 0x00), read(0x01FC, 0x02), read(0x01FD, 0x02), read(0x0202, 0x12),
+			},
+		},
+		{
+			name:      "BRK pushes the return address and status then reads the vector",
+			state:     State{PC: 0x0200, SP: 0xFD, P: FlagCarry},
+			ram:       map[Address]uint8{0x0200: 0x00, 0x0201: 0xFF, 0xFFFE: 0x00, 0xFFFF: 0x80},
+			wantState: State{PC: 0x8000, SP: 0xFA, P: FlagCarry | FlagInterrupt},
+			wantTrace: [][]busAccess{
+				read(0x0200, 0x00), read(0x0201, 0xFF), write(0x01FD, 0x02), write(0x01FC, 0x02),
+				write(0x01FB, FlagConstant|FlagBreak|FlagCarry), read(0xFFFE, 0x00), read(0xFFFF, 0x80),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := &traceMemory{}
+			for address, value := range tt.ram {
+				memory.ram[address] = value
+			}
+			cpu, err := NewCpu(newLegalInstructionSet(), memory)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cpu.State = tt.state
+
+			cycles, trace, err := tickInstruction(&cpu, memory)
+			if err != nil {
+				t.Fatalf("Tick() unexpected error = %v", err)
+			}
+			if cycles != uint(len(tt.wantTrace)) {
+				t.Errorf("Tick() cycles got = %v, want = %v", cycles, len(tt.wantTrace))
+			}
+			if !reflect.DeepEqual(trace, tt.wantTrace) {
+				t.Errorf("Tick() bus activity got = %v, want = %v", trace, tt.wantTrace)
+			}
+			if cpu.State != tt.wantState {
+				t.Errorf("Tick() State got = %v, want = %v", cpu.State, tt.wantState)
+			}
+		})
+	}
+
+	// Check support for nil
+	if _, err := (*Cpu)(nil).Tick(); err == nil {
+		t.Errorf("Tick() did not raise an error when called on nil")
+	}
+}
+
+func TestCpu_TickWithoutBusInformation(t *testing.T) {
+	// Instruction 0x2 in the test instruction set takes 3 cycles but has no Mode or Type.
+	memory := NewPopulatedRam(EightBytes, []uint8{0x02, 0xA0, 0x12, 0xFF, 0, 0, 0, 0})
+	cpu, err := NewCpu(NewTestInstructionSet(), &memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []bool{false, false, true} {
+		done, err := cpu.Tick()
+		if err != nil {
+			t.Fatalf("Tick() unexpected error = %v", err)
+		}
+		if done != want {
+			t.Errorf("Tick() cycle %v done got = %v, want = %v", i+1, done, want)
+		}
+	}
+	want := State{PC: 3, A: 0x10, X: 0xA0, Y: 0x12, P: FlagOverflow}
+	if cpu.State != want {
+		t.Errorf("Tick() State got = %v, want = %v", cpu.State, want)
+	}
+
+	// The unknown opcode errors at the end of the fetch cycle.
+	if done, err := cpu.Tick(); !done || err == nil {
+		t.Errorf("Tick() with an unknown opcode got done = %v, err = %v", done, err)
+	}
+}
+
+func TestCpu_TickLongImpliedInstructions(t *testing.T) {
+	// WAI and STP are implied instructions that take 3 cycles.
+	is, err := NewInstructionSetBuilder().AddMnemonics(AllW65C02SOpcodes()...).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, opcode := range []uint8{0xCB, 0xDB} {
+		memory := &traceMemory{}
+		memory.ram[0x0200] = opcode
+		cpu, err := NewCpu(is, memory)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cpu.State = State{PC: 0x0200}
+
+		stepCpu := cpu
+		stepCycles, _ := stepCpu.Step()
+		cycles, _, err := tickInstruction(&cpu, memory)
+		if err != nil || cycles != stepCycles || cycles != 3 {
+			t.Errorf("Opcode $%02X cycles got = %v, want = %v, error = %v", opcode, cycles, stepCycles, err)
+		}
+		if cpu.RunState() != stepCpu.RunState() {
+			t.Errorf("Opcode $%02X RunState got = %v, want = %v", opcode, cpu.RunState(), stepCpu.RunState())
+		}
+	}
+}
+
+func TestCpu_StepCompletesTick(t *testing.T) {
+	memory := &traceMemory{}
+	memory.ram[0x0200] = 0xEE // INC $1234
+	memory.ram[0x0201] = 0x34
+	memory.ram[0x0202] = 0x12
+	cpu, err := NewCpu(newLegalInstructionSet(), memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu.State = State{PC: 0x0200}
+
+	for range 2 {
+		if _, err := cpu.Tick(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cycles, err := cpu.Step()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cycles != 4 {
+		t.Errorf("Step() cycles got = %v, want = %v", cycles, 4)
+	}
+	if cpu.State.PC != 0x0203 || memory.ram[0x1234] != 0x01 {
+		t.Errorf("Step() did not complete the instruction, State = %v, memory = %v", cpu.State, memory.ram[0x1234])
+	}
+}
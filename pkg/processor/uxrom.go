@@ -0,0 +1,78 @@
+package processor
+
+import "fmt"
+
+// UxRom is the program ROM of an NES cartridge using the NROM or UxROM mapper, which is
+// mapped to $8000-$FFFF. The ROM is divided into 16K banks. With UxROM, a write anywhere in
+// the ROM selects the bank at $8000-$BFFF, while $C000-$FFFF is fixed to the last bank.
+// NROM cannot switch banks, so a 32K ROM fills the space and a 16K ROM is mirrored.
+type UxRom struct {
+	prg        []uint8
+	banks      int
+	selected   int // The bank at $8000-$BFFF.
+	fixed      int // The bank at $C000-$FFFF.
+	switchable bool
+}
+
+// NewNRom returns an UxRom that cannot switch banks, as used by the NROM mapper. An
+// InvalidMemorySizeProvided error is returned unless the ROM is 16K or 32K.
+func NewNRom(prg []uint8) (*UxRom, error) {
+	if len(prg) != SixteenKiloBytes && len(prg) != 2*SixteenKiloBytes {
+		return nil, InvalidMemorySizeProvided
+	}
+	return newUxRom(prg, false), nil
+}
+
+// NewUxRom returns an UxRom with the first bank selected. An InvalidMemorySizeProvided error
+// is returned unless the ROM is a multiple of 16K with at most 256 banks.
+func NewUxRom(prg []uint8) (*UxRom, error) {
+	if len(prg) == 0 || len(prg)%SixteenKiloBytes != 0 || len(prg) > 0x100*SixteenKiloBytes {
+		return nil, InvalidMemorySizeProvided
+	}
+	return newUxRom(prg, true), nil
+}
+
+func newUxRom(prg []uint8, switchable bool) *UxRom {
+	banks := len(prg) / SixteenKiloBytes
+	return &UxRom{
+		prg:        append([]uint8(nil), prg...),
+		banks:      banks,
+		fixed:      banks - 1,
+		switchable: switchable,
+	}
+}
+
+// Read returns the byte at the address, which is relative to $8000.
+func (r *UxRom) Read(address Address) uint8 {
+	bank := r.selected
+	if address&0x4000 != 0 {
+		bank = r.fixed
+	}
+	return r.prg[bank*SixteenKiloBytes+int(address&(SixteenKiloBytes-1))]
+}
+
+// Write selects the bank at $8000-$BFFF, wrapping around if there are fewer banks than the
+// value. The writes are ignored by NROM.
+func (r *UxRom) Write(_ Address, value uint8) {
+	if r.switchable {
+		r.selected = int(value) % r.banks
+	}
+}
+
+// Size returns the size of the address space of the ROM, which is always 32K.
+func (r *UxRom) Size() int {
+	return 2 * SixteenKiloBytes
+}
+
+// Banks returns the number of 16K banks.
+func (r *UxRom) Banks() int {
+	return r.banks
+}
+
+// Windows returns the banks at $8000-$BFFF and $C000-$FFFF. See BankSwitcher.
+func (r *UxRom) Windows() []BankWindow {
+	return []BankWindow{
+		{Start: 0x0000, End: 0x3FFF, Bank: r.selected, Name: fmt.Sprintf("PRG bank %d", r.selected)},
+		{Start: 0x4000, End: 0x7FFF, Bank: r.fixed, Name: fmt.Sprintf("PRG bank %d", r.fixed)},
+	}
+}
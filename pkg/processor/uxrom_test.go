@@ -0,0 +1,96 @@
+package processor
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// newPrg returns a program ROM of the number of 16K banks, each filled with its index.
+func newPrg(banks int) []uint8 {
+	prg := make([]uint8, banks*SixteenKiloBytes)
+	for i := range prg {
+		prg[i] = uint8(i / SixteenKiloBytes)
+	}
+	return prg
+}
+
+func TestNewUxRom(t *testing.T) {
+	tests := []struct {
+		name    string
+		prg     []uint8
+		nrom    bool
+		wantErr error
+	}{
+		{name: "NROM-128", prg: newPrg(1), nrom: true},
+		{name: "NROM-256", prg: newPrg(2), nrom: true},
+		{name: "NROM too large", prg: newPrg(3), nrom: true, wantErr: InvalidMemorySizeProvided},
+		{name: "UNROM", prg: newPrg(8)},
+		{name: "UxROM with 256 banks", prg: newPrg(0x100)},
+		{name: "UxROM too large", prg: newPrg(0x101), wantErr: InvalidMemorySizeProvided},
+		{name: "Not a multiple of 16K", prg: make([]uint8, 0x5000), wantErr: InvalidMemorySizeProvided},
+		{name: "Empty", wantErr: InvalidMemorySizeProvided},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRom := NewUxRom
+			if tt.nrom {
+				newRom = NewNRom
+			}
+			rom, err := newRom(tt.prg)
+			if err != tt.wantErr {
+				t.Fatalf("error got = %v, want = %v", err, tt.wantErr)
+			}
+			if err == nil && rom.Banks() != len(tt.prg)/SixteenKiloBytes {
+				t.Errorf("Banks() got = %v, want = %v", rom.Banks(), len(tt.prg)/SixteenKiloBytes)
+			}
+		})
+	}
+}
+
+func TestUxRom_Banks(t *testing.T) {
+	tests := []struct {
+		name     string
+		prg      []uint8
+		nrom     bool
+		write    uint8
+		wantLow  uint8 // The bank at $8000.
+		wantHigh uint8 // The bank at $C000.
+	}{
+		{name: "NROM-128 is mirrored", prg: newPrg(1), nrom: true, write: 1, wantLow: 0, wantHigh: 0},
+		{name: "NROM-256 ignores writes", prg: newPrg(2), nrom: true, write: 0, wantLow: 0, wantHigh: 1},
+		{name: "UxROM selects the low bank", prg: newPrg(8), write: 5, wantLow: 5, wantHigh: 7},
+		{name: "UxROM wraps around", prg: newPrg(8), write: 0x0B, wantLow: 3, wantHigh: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRom := NewUxRom
+			if tt.nrom {
+				newRom = NewNRom
+			}
+			rom, err := newRom(tt.prg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bus, err := NewBusBuilder().Map("PRG", 0x8000, 0xFFFF, rom).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bus.Write(0xC123, tt.write)
+			if got := bus.Read(0x8000); got != tt.wantLow {
+				t.Errorf("Read($8000) got = %v, want = %v", got, tt.wantLow)
+			}
+			if got := bus.Read(0xFFFF); got != tt.wantHigh {
+				t.Errorf("Read($FFFF) got = %v, want = %v", got, tt.wantHigh)
+			}
+			want := []BankWindow{
+				{Start: 0x0000, End: 0x3FFF, Bank: int(tt.wantLow), Name: fmt.Sprintf("PRG bank %d", tt.wantLow)},
+				{Start: 0x4000, End: 0x7FFF, Bank: int(tt.wantHigh), Name: fmt.Sprintf("PRG bank %d", tt.wantHigh)},
+			}
+			if got := rom.Windows(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Windows() got = %v, want = %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,523 @@
+package w65c816
+
+import (
+	"fmt"
+	"go6502/pkg/processor"
+)
+
+// Address is a 24-bit address; the high byte is the bank and the low 16 bits the address
+// within the bank. Only the low 24 bits are used.
+type Address uint32
+
+// addressMask is the mask of the 24 bits of an Address.
+const addressMask = 0xFFFFFF
+
+// MakeAddress combines a bank and a 16-bit address within that bank into an Address.
+func MakeAddress(bank uint8, address uint16) Address {
+	return Address(bank)<<16 | Address(address)
+}
+
+// Bank returns the bank of the address.
+func (a Address) Bank() uint8 {
+	return uint8(a >> 16)
+}
+
+// Offset returns the 16-bit address within the bank.
+func (a Address) Offset() uint16 {
+	return uint16(a)
+}
+
+// Memory is the 16MB address space of the 65C816.
+type Memory interface {
+	Read(Address) uint8
+	Write(Address, uint8)
+}
+
+// Addressing instances are generated as a result of executing an AddressingFunc function.
+// Unlike the 6502 the value is not read by the addressing mode, as the width of the value
+// depends on the operation; Read and Store are used to access the value instead.
+type Addressing struct {
+	// How much to change the program counter by as part of addressing. This is essentially
+	// how many bytes are read from memory as part of addressing.
+	ProgramCounterChange uint16
+
+	// Is this addressing mode using the Accumulator or Memory.
+	Accumulator bool
+
+	// The calculated effective address. For immediate addressing this is the address of
+	// the operand.
+	EffectiveAddress Address
+
+	// The high byte of a 16-bit value wraps within bank 0 rather than continuing at the
+	// following address. This is true for direct page and stack relative addressing.
+	Bank0 bool
+
+	// The operand of the block move instructions; the destination and source banks.
+	DestinationBank uint8
+	SourceBank      uint8
+
+	// Does the indexed EffectiveAddress cross a page boundary.
+	PageBoundaryCrossed bool
+
+	// The low byte of the direct page register is not zero, which costs an additional cycle
+	// in all the direct page addressing modes.
+	DirectPageUnaligned bool
+
+	// Set by a branch Operation when the branch is taken.
+	BranchTaken bool
+
+	// Set by an Operation that changes the run state of the Cpu, such as WAI and STP.
+	RunState processor.RunState
+
+	// Set by MVN and MVP when the move has not completed, so that the instruction is
+	// executed again.
+	Repeat bool
+
+	// The Memory that was used when generating Addressing.
+	Memory Memory
+}
+
+// next returns the address following address, wrapping within bank 0 if required.
+func (as Addressing) next(address Address) Address {
+	if as.Bank0 {
+		return Address(uint16(address + 1))
+	}
+	return (address + 1) & addressMask
+}
+
+// Read returns the 8-bit or 16-bit value from either the Accumulator or the Effective
+// Address in Memory.
+func (as Addressing) Read(state State, wide bool) (uint16, error) {
+	if as.Accumulator {
+		if wide {
+			return state.A, nil
+		}
+		return state.A & 0x00FF, nil
+	}
+	if as.Memory == nil {
+		return 0, processor.MemoryMustBeProvided
+	}
+
+	value := uint16(as.Memory.Read(as.EffectiveAddress))
+	if wide {
+		value |= uint16(as.Memory.Read(as.next(as.EffectiveAddress))) << 8
+	}
+	return value, nil
+}
+
+// Store writes the 8-bit or 16-bit value to either the Accumulator or the Effective Address
+// in Memory. Storing an 8-bit value in the Accumulator leaves the high byte (B) unchanged.
+func (as Addressing) Store(state State, value uint16, wide bool) (State, error) {
+	if as.Accumulator {
+		state.A = setRegister(state.A, value, wide)
+		return state, nil
+	}
+	if as.Memory == nil {
+		return state, processor.MemoryMustBeProvided
+	}
+
+	as.Memory.Write(as.EffectiveAddress, uint8(value))
+	if wide {
+		as.Memory.Write(as.next(as.EffectiveAddress), uint8(value>>8))
+	}
+	return state, nil
+}
+
+// PushByte saves the 8-bit value onto the stack, returning the new State. In emulation mode
+// the stack pointer wraps within page 1.
+func (as Addressing) PushByte(state State, value uint8) (State, error) {
+	if as.Memory == nil {
+		return state, processor.MemoryMustBeProvided
+	}
+
+	as.Memory.Write(Address(state.SP), value)
+	state.SP = state.stackPointer(state.SP - 1)
+
+	return state, nil
+}
+
+// PushWord saves the 16-bit value onto the stack, high byte first, returning the new State.
+func (as Addressing) PushWord(state State, value uint16) (State, error) {
+	state, err := as.PushByte(state, uint8(value>>8))
+	if err != nil {
+		return state, err
+	}
+	return as.PushByte(state, uint8(value))
+}
+
+// PullByte retrieves the 8-bit value from the stack, returning the new State and the value.
+func (as Addressing) PullByte(state State) (State, uint8, error) {
+	if as.Memory == nil {
+		return state, 0, processor.MemoryMustBeProvided
+	}
+
+	state.SP = state.stackPointer(state.SP + 1)
+	return state, as.Memory.Read(Address(state.SP)), nil
+}
+
+// PullWord retrieves the 16-bit value from the stack, low byte first, returning the new
+// State and the value.
+func (as Addressing) PullWord(state State) (State, uint16, error) {
+	state, low, err := as.PullByte(state)
+	if err != nil {
+		return state, 0, err
+	}
+	state, high, err := as.PullByte(state)
+	if err != nil {
+		return state, 0, err
+	}
+	return state, uint16(low) | uint16(high)<<8, nil
+}
+
+// Converts the Addressing instance into a canonical string form.
+func (as Addressing) String() string {
+	return fmt.Sprintf(
+		"Acc: %v, EA: %06X, PC-delta: %04X, PBC: %v, DPU: %v, BT: %v",
+		as.Accumulator, uint32(as.EffectiveAddress), as.ProgramCounterChange, as.PageBoundaryCrossed, as.DirectPageUnaligned, as.BranchTaken)
+}
+
+// ************************************************************
+// ********** AddressingFunc functions
+// ************************************************************
+
+// AddressingFunc performs the addressing mode phase of an instructions' execution.
+// AddressingFunc is always done before Operation as it will calculate the effective
+// address (if relevant). The PC of the State is the address of the first operand byte.
+type AddressingFunc func(State, Memory) (Addressing, error)
+
+// operand returns the byte of the instruction at offset from the PC.
+func operand(state State, memory Memory, offset uint16) uint8 {
+	return memory.Read(MakeAddress(state.PBR, state.PC+offset))
+}
+
+// operandWord returns the 16-bit value of the instruction at offset from the PC.
+func operandWord(state State, memory Memory, offset uint16) uint16 {
+	return uint16(operand(state, memory, offset)) | uint16(operand(state, memory, offset+1))<<8
+}
+
+// readWord returns the 16-bit value in bank 0 at address, wrapping within the bank.
+func readWord(memory Memory, address uint16) uint16 {
+	return uint16(memory.Read(Address(address))) | uint16(memory.Read(Address(address+1)))<<8
+}
+
+// directAddress returns the address in bank 0 of the offset from the direct page. In
+// emulation mode, if the direct page is page aligned, the address wraps within the page
+// like the zero page of the 6502.
+func directAddress(state State, offset uint16) uint16 {
+	if state.E && state.D&0x00FF == 0 {
+		return state.D | (offset & 0x00FF)
+	}
+	return state.D + offset
+}
+
+// directPointer returns the 16-bit pointer held in the direct page at offset.
+func directPointer(state State, memory Memory, offset uint16) uint16 {
+	low := memory.Read(Address(directAddress(state, offset)))
+	high := memory.Read(Address(directAddress(state, offset+1)))
+	return uint16(low) | uint16(high)<<8
+}
+
+// direct returns the Addressing of a direct page address.
+func direct(state State, memory Memory, offset uint16) Addressing {
+	return Addressing{
+		EffectiveAddress:     Address(directAddress(state, offset)),
+		Bank0:                true,
+		DirectPageUnaligned:  state.D&0x00FF != 0,
+		ProgramCounterChange: 1,
+		Memory:               memory,
+	}
+}
+
+// indexed returns the Addressing of a base address in the data bank to which the index
+// is added, carrying into the following bank if required.
+func indexed(state State, memory Memory, base uint16, index uint16, pcChange uint16) Addressing {
+	effectiveAddress := (MakeAddress(state.DBR, base) + Address(index)) & addressMask
+	return Addressing{
+		EffectiveAddress:     effectiveAddress,
+		PageBoundaryCrossed:  base&0xFF00 != uint16(effectiveAddress)&0xFF00,
+		ProgramCounterChange: pcChange,
+		Memory:               memory,
+	}
+}
+
+// Absolute addressing (a) uses the two bytes following the Opcode as an address in the
+// data bank. JMP, JSR and PEA only use the 16-bit address.
+func Absolute(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	result := indexed(state, memory, operandWord(state, memory, 0), 0, 2)
+	return result, nil
+}
+
+// AbsoluteX addressing (a,x) adds X to the absolute address.
+func AbsoluteX(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return indexed(state, memory, operandWord(state, memory, 0), state.X, 2), nil
+}
+
+// AbsoluteY addressing (a,y) adds Y to the absolute address.
+func AbsoluteY(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return indexed(state, memory, operandWord(state, memory, 0), state.Y, 2), nil
+}
+
+// AbsoluteLong addressing (al) uses the three bytes following the Opcode as a 24-bit address.
+func AbsoluteLong(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return Addressing{
+		EffectiveAddress:     MakeAddress(operand(state, memory, 2), operandWord(state, memory, 0)),
+		ProgramCounterChange: 3,
+		Memory:               memory,
+	}, nil
+}
+
+// AbsoluteLongX addressing (al,x) adds X to the 24-bit address.
+func AbsoluteLongX(state State, memory Memory) (Addressing, error) {
+	result, err := AbsoluteLong(state, memory)
+	result.EffectiveAddress = (result.EffectiveAddress + Address(state.X)) & addressMask
+	return result, err
+}
+
+// AbsoluteIndirect addressing ((a)) reads a 16-bit address from bank 0. This is only used by
+// JMP, which jumps within the program bank.
+func AbsoluteIndirect(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return Addressing{
+		EffectiveAddress:     MakeAddress(state.PBR, readWord(memory, operandWord(state, memory, 0))),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+// AbsoluteIndirectLong addressing ([a]) reads a 24-bit address from bank 0. This is only
+// used by JML.
+func AbsoluteIndirectLong(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	pointer := operandWord(state, memory, 0)
+	return Addressing{
+		EffectiveAddress:     MakeAddress(memory.Read(Address(pointer+2)), readWord(memory, pointer)),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+// AbsoluteXIndirect addressing ((a,x)) adds X to the absolute address and reads a 16-bit
+// address from the program bank. This is only used by JMP and JSR.
+func AbsoluteXIndirect(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	pointer := operandWord(state, memory, 0) + state.X
+	low := memory.Read(MakeAddress(state.PBR, pointer))
+	high := memory.Read(MakeAddress(state.PBR, pointer+1))
+	return Addressing{
+		EffectiveAddress:     MakeAddress(state.PBR, uint16(low)|uint16(high)<<8),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+// Accumulator addressing (A) operates on the accumulator.
+func Accumulator(_ State, memory Memory) (Addressing, error) {
+	return Addressing{
+		Accumulator: true,
+		Memory:      memory,
+	}, nil
+}
+
+// BlockMove addressing (xyc) reads the destination and source banks used by MVN and MVP.
+func BlockMove(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return Addressing{
+		DestinationBank:      operand(state, memory, 0),
+		SourceBank:           operand(state, memory, 1),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+// Direct addressing (d) uses the byte following the Opcode as an offset into the direct page.
+func Direct(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return direct(state, memory, uint16(operand(state, memory, 0))), nil
+}
+
+// DirectX addressing (d,x) adds X to the direct page offset.
+func DirectX(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return direct(state, memory, uint16(operand(state, memory, 0))+state.X), nil
+}
+
+// DirectY addressing (d,y) adds Y to the direct page offset.
+func DirectY(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return direct(state, memory, uint16(operand(state, memory, 0))+state.Y), nil
+}
+
+// DirectIndirect addressing ((d)) reads a 16-bit address in the data bank from the direct page.
+func DirectIndirect(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	result := indexed(state, memory, directPointer(state, memory, uint16(operand(state, memory, 0))), 0, 1)
+	result.DirectPageUnaligned = state.D&0x00FF != 0
+	return result, nil
+}
+
+// DirectXIndirect addressing ((d,x)) adds X to the direct page offset before reading the
+// 16-bit address in the data bank.
+func DirectXIndirect(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	result := indexed(state, memory, directPointer(state, memory, uint16(operand(state, memory, 0))+state.X), 0, 1)
+	result.DirectPageUnaligned = state.D&0x00FF != 0
+	return result, nil
+}
+
+// DirectIndirectY addressing ((d),y) reads a 16-bit address in the data bank from the
+// direct page and then adds Y.
+func DirectIndirectY(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	result := indexed(state, memory, directPointer(state, memory, uint16(operand(state, memory, 0))), state.Y, 1)
+	result.DirectPageUnaligned = state.D&0x00FF != 0
+	return result, nil
+}
+
+// DirectIndirectLong addressing ([d]) reads a 24-bit address from the direct page.
+func DirectIndirectLong(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	offset := uint16(operand(state, memory, 0))
+	pointer := state.D + offset
+	return Addressing{
+		EffectiveAddress:     MakeAddress(memory.Read(Address(pointer+2)), readWord(memory, pointer)),
+		DirectPageUnaligned:  state.D&0x00FF != 0,
+		ProgramCounterChange: 1,
+		Memory:               memory,
+	}, nil
+}
+
+// DirectIndirectLongY addressing ([d],y) reads a 24-bit address from the direct page and
+// then adds Y.
+func DirectIndirectLongY(state State, memory Memory) (Addressing, error) {
+	result, err := DirectIndirectLong(state, memory)
+	result.EffectiveAddress = (result.EffectiveAddress + Address(state.Y)) & addressMask
+	return result, err
+}
+
+// immediate returns the Addressing of an immediate operand of the given width.
+func immediate(state State, memory Memory, wide bool) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	result := Addressing{
+		EffectiveAddress:     MakeAddress(state.PBR, state.PC),
+		ProgramCounterChange: 1,
+		Memory:               memory,
+	}
+	if wide {
+		result.ProgramCounterChange = 2
+	}
+	return result, nil
+}
+
+// Immediate addressing (#) is a single byte operand, as used by REP, SEP, BRK and COP.
+func Immediate(state State, memory Memory) (Addressing, error) {
+	return immediate(state, memory, false)
+}
+
+// ImmediateMemory addressing (#) is an operand whose width is set by the M flag.
+func ImmediateMemory(state State, memory Memory) (Addressing, error) {
+	return immediate(state, memory, state.MemoryWide())
+}
+
+// ImmediateIndex addressing (#) is an operand whose width is set by the X flag.
+func ImmediateIndex(state State, memory Memory) (Addressing, error) {
+	return immediate(state, memory, state.IndexWide())
+}
+
+// Implied addressing does no calculations.
+func Implied(_ State, memory Memory) (Addressing, error) {
+	return Addressing{
+		Memory: memory,
+	}, nil
+}
+
+// Relative addressing (r) uses the byte following the Opcode as a signed offset from the
+// following instruction. The page boundary is crossed if the target is on a different page.
+func Relative(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	next := state.PC + 1
+	target := next + uint16(int8(operand(state, memory, 0)))
+	return Addressing{
+		EffectiveAddress:     MakeAddress(state.PBR, target),
+		PageBoundaryCrossed:  next&0xFF00 != target&0xFF00,
+		ProgramCounterChange: 1,
+		Memory:               memory,
+	}, nil
+}
+
+// RelativeLong addressing (rl) uses the two bytes following the Opcode as a signed offset
+// from the following instruction. This is used by BRL and PER.
+func RelativeLong(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	target := state.PC + 2 + operandWord(state, memory, 0)
+	return Addressing{
+		EffectiveAddress:     MakeAddress(state.PBR, target),
+		ProgramCounterChange: 2,
+		Memory:               memory,
+	}, nil
+}
+
+// StackRelative addressing (d,s) uses the byte following the Opcode as an offset from the
+// stack pointer in bank 0.
+func StackRelative(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	return Addressing{
+		EffectiveAddress:     Address(state.SP + uint16(operand(state, memory, 0))),
+		Bank0:                true,
+		ProgramCounterChange: 1,
+		Memory:               memory,
+	}, nil
+}
+
+// StackRelativeIndirectY addressing ((d,s),y) reads a 16-bit address in the data bank from
+// the stack and then adds Y.
+func StackRelativeIndirectY(state State, memory Memory) (Addressing, error) {
+	if memory == nil {
+		return Addressing{}, processor.MemoryMustBeProvided
+	}
+	pointer := readWord(memory, state.SP+uint16(operand(state, memory, 0)))
+	result := indexed(state, memory, pointer, state.Y, 1)
+	result.PageBoundaryCrossed = false
+	return result, nil
+}
@@ -0,0 +1,400 @@
+package w65c816
+
+import (
+	"go6502/pkg/processor"
+	"testing"
+)
+
+// ram is a sparse 16MB memory for the tests.
+type ram map[Address]uint8
+
+func (r ram) Read(address Address) uint8 {
+	return r[address]
+}
+
+func (r ram) Write(address Address, value uint8) {
+	r[address] = value
+}
+
+// load writes the bytes into memory starting at address.
+func (r ram) load(address Address, data ...uint8) ram {
+	for i, b := range data {
+		r[address+Address(i)] = b
+	}
+	return r
+}
+
+func TestMakeAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		bank    uint8
+		address uint16
+		want    Address
+	}{
+		{name: "All zeros"},
+		{name: "Only a bank", bank: 0x7E, want: 0x7E0000},
+		{name: "Only an address", address: 0x1234, want: 0x001234},
+		{name: "Both a bank and address", bank: 0xFF, address: 0xFFFF, want: 0xFFFFFF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MakeAddress(tt.bank, tt.address)
+			if got != tt.want {
+				t.Errorf("MakeAddress() = %06X, want %06X", uint32(got), uint32(tt.want))
+			}
+			if got.Bank() != tt.bank || got.Offset() != tt.address {
+				t.Errorf("Bank() and Offset() = %02X:%04X, want = %02X:%04X", got.Bank(), got.Offset(), tt.bank, tt.address)
+			}
+		})
+	}
+}
+
+func TestAddressingFuncs(t *testing.T) {
+	native := processor.Status(0)
+	tests := []struct {
+		name       string
+		addressing AddressingFunc
+		state      State
+		ram        ram
+		want       Addressing
+	}{
+		{
+			name:       "Absolute uses the data bank",
+			addressing: Absolute,
+			state:      State{PBR: 0x01, PC: 0x8000, DBR: 0x7E},
+			ram:        ram{}.load(0x018000, 0x34, 0x12),
+			want:       Addressing{EffectiveAddress: 0x7E1234, ProgramCounterChange: 2},
+		},
+		{
+			name:       "AbsoluteX carries into the next bank",
+			addressing: AbsoluteX,
+			state:      State{PC: 0x8000, DBR: 0x7E, X: 0x20},
+			ram:        ram{}.load(0x8000, 0xF0, 0xFF),
+			want:       Addressing{EffectiveAddress: 0x7F0010, ProgramCounterChange: 2, PageBoundaryCrossed: true},
+		},
+		{
+			name:       "AbsoluteY without a page boundary crossed",
+			addressing: AbsoluteY,
+			state:      State{PC: 0x8000, DBR: 0x7E, Y: 0x10},
+			ram:        ram{}.load(0x8000, 0x00, 0x12),
+			want:       Addressing{EffectiveAddress: 0x7E1210, ProgramCounterChange: 2},
+		},
+		{
+			name:       "AbsoluteLong",
+			addressing: AbsoluteLong,
+			state:      State{PC: 0x8000, DBR: 0x7E},
+			ram:        ram{}.load(0x8000, 0x56, 0x34, 0x12),
+			want:       Addressing{EffectiveAddress: 0x123456, ProgramCounterChange: 3},
+		},
+		{
+			name:       "AbsoluteLongX carries into the next bank",
+			addressing: AbsoluteLongX,
+			state:      State{PC: 0x8000, X: 0x0001},
+			ram:        ram{}.load(0x8000, 0xFF, 0xFF, 0x12),
+			want:       Addressing{EffectiveAddress: 0x130000, ProgramCounterChange: 3},
+		},
+		{
+			name:       "AbsoluteIndirect reads the pointer from bank 0",
+			addressing: AbsoluteIndirect,
+			state:      State{PBR: 0x01, PC: 0x8000, DBR: 0x7E},
+			ram:        ram{}.load(0x018000, 0x00, 0x20).load(0x002000, 0x00, 0x90),
+			want:       Addressing{EffectiveAddress: 0x019000, ProgramCounterChange: 2},
+		},
+		{
+			name:       "AbsoluteIndirectLong reads the 24-bit pointer from bank 0",
+			addressing: AbsoluteIndirectLong,
+			state:      State{PBR: 0x01, PC: 0x8000},
+			ram:        ram{}.load(0x018000, 0x00, 0x20).load(0x002000, 0x00, 0x90, 0x05),
+			want:       Addressing{EffectiveAddress: 0x059000, ProgramCounterChange: 2},
+		},
+		{
+			name:       "AbsoluteXIndirect reads the pointer from the program bank",
+			addressing: AbsoluteXIndirect,
+			state:      State{PBR: 0x01, PC: 0x8000, X: 0x02},
+			ram:        ram{}.load(0x018000, 0x00, 0x20).load(0x012002, 0x34, 0x12),
+			want:       Addressing{EffectiveAddress: 0x011234, ProgramCounterChange: 2},
+		},
+		{
+			name:       "Accumulator",
+			addressing: Accumulator,
+			want:       Addressing{Accumulator: true},
+		},
+		{
+			name:       "BlockMove reads the destination then the source bank",
+			addressing: BlockMove,
+			state:      State{PC: 0x8000},
+			ram:        ram{}.load(0x8000, 0x7E, 0x7F),
+			want:       Addressing{DestinationBank: 0x7E, SourceBank: 0x7F, ProgramCounterChange: 2},
+		},
+		{
+			name:       "Direct with an unaligned direct page",
+			addressing: Direct,
+			state:      State{PC: 0x8000, D: 0x1234, DBR: 0x7E, P: native},
+			ram:        ram{}.load(0x8000, 0x10),
+			want:       Addressing{EffectiveAddress: 0x001244, Bank0: true, DirectPageUnaligned: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectX wraps within the page in emulation mode",
+			addressing: DirectX,
+			state:      State{PC: 0x8000, D: 0x0200, X: 0x20, E: true},
+			ram:        ram{}.load(0x8000, 0xF0),
+			want:       Addressing{EffectiveAddress: 0x000210, Bank0: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectX wraps within bank 0 in native mode",
+			addressing: DirectX,
+			state:      State{PC: 0x8000, D: 0xFF00, X: 0x0120},
+			ram:        ram{}.load(0x8000, 0xF0),
+			want:       Addressing{EffectiveAddress: 0x000110, Bank0: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectY",
+			addressing: DirectY,
+			state:      State{PC: 0x8000, Y: 0x05},
+			ram:        ram{}.load(0x8000, 0x10),
+			want:       Addressing{EffectiveAddress: 0x000015, Bank0: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectIndirect uses the data bank",
+			addressing: DirectIndirect,
+			state:      State{PC: 0x8000, DBR: 0x7E},
+			ram:        ram{}.load(0x8000, 0x10).load(0x0010, 0x34, 0x12),
+			want:       Addressing{EffectiveAddress: 0x7E1234, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectXIndirect wraps the pointer within the page in emulation mode",
+			addressing: DirectXIndirect,
+			state:      State{PC: 0x8000, E: true},
+			ram:        ram{}.load(0x8000, 0xFF).load(0x0000, 0x12).load(0x00FF, 0x34),
+			want:       Addressing{EffectiveAddress: 0x001234, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectIndirectY crosses a page boundary",
+			addressing: DirectIndirectY,
+			state:      State{PC: 0x8000, DBR: 0x7E, Y: 0x20},
+			ram:        ram{}.load(0x8000, 0x10).load(0x0010, 0xF0, 0x12),
+			want:       Addressing{EffectiveAddress: 0x7E1310, PageBoundaryCrossed: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectIndirectLong",
+			addressing: DirectIndirectLong,
+			state:      State{PC: 0x8000, D: 0x0100, DBR: 0x7E},
+			ram:        ram{}.load(0x8000, 0x10).load(0x0110, 0x00, 0x80, 0x7F),
+			want:       Addressing{EffectiveAddress: 0x7F8000, ProgramCounterChange: 1},
+		},
+		{
+			name:       "DirectIndirectLongY",
+			addressing: DirectIndirectLongY,
+			state:      State{PC: 0x8000, Y: 0x10},
+			ram:        ram{}.load(0x8000, 0x10).load(0x0010, 0x00, 0x80, 0x7F),
+			want:       Addressing{EffectiveAddress: 0x7F8010, ProgramCounterChange: 1},
+		},
+		{
+			name:       "Immediate is always 8-bit",
+			addressing: Immediate,
+			state:      State{PBR: 0x01, PC: 0x8000, P: native},
+			want:       Addressing{EffectiveAddress: 0x018000, ProgramCounterChange: 1},
+		},
+		{
+			name:       "ImmediateMemory is 16-bit when M is clear",
+			addressing: ImmediateMemory,
+			state:      State{PBR: 0x01, PC: 0x8000, P: native},
+			want:       Addressing{EffectiveAddress: 0x018000, ProgramCounterChange: 2},
+		},
+		{
+			name:       "ImmediateMemory is 8-bit when M is set",
+			addressing: ImmediateMemory,
+			state:      State{PC: 0x8000, P: FlagMemory},
+			want:       Addressing{EffectiveAddress: 0x008000, ProgramCounterChange: 1},
+		},
+		{
+			name:       "ImmediateIndex is 8-bit in emulation mode",
+			addressing: ImmediateIndex,
+			state:      State{PC: 0x8000, E: true},
+			want:       Addressing{EffectiveAddress: 0x008000, ProgramCounterChange: 1},
+		},
+		{
+			name:       "Implied",
+			addressing: Implied,
+		},
+		{
+			name:       "Relative backwards within the page",
+			addressing: Relative,
+			state:      State{PBR: 0x01, PC: 0x8001},
+			ram:        ram{}.load(0x018001, 0xFE),
+			want:       Addressing{EffectiveAddress: 0x018000, ProgramCounterChange: 1},
+		},
+		{
+			name:       "Relative forwards across a page boundary",
+			addressing: Relative,
+			state:      State{PC: 0x80F0},
+			ram:        ram{}.load(0x80F0, 0x20),
+			want:       Addressing{EffectiveAddress: 0x008111, PageBoundaryCrossed: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "RelativeLong",
+			addressing: RelativeLong,
+			state:      State{PC: 0x8001},
+			ram:        ram{}.load(0x8001, 0x00, 0x10),
+			want:       Addressing{EffectiveAddress: 0x009003, ProgramCounterChange: 2},
+		},
+		{
+			name:       "StackRelative",
+			addressing: StackRelative,
+			state:      State{PC: 0x8000, SP: 0x01F0, DBR: 0x7E},
+			ram:        ram{}.load(0x8000, 0x03),
+			want:       Addressing{EffectiveAddress: 0x0001F3, Bank0: true, ProgramCounterChange: 1},
+		},
+		{
+			name:       "StackRelativeIndirectY",
+			addressing: StackRelativeIndirectY,
+			state:      State{PC: 0x8000, SP: 0x01F0, DBR: 0x02, Y: 0x05},
+			ram:        ram{}.load(0x8000, 0x01).load(0x01F1, 0x00, 0x20),
+			want:       Addressing{EffectiveAddress: 0x022005, ProgramCounterChange: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := tt.ram
+			if memory == nil {
+				memory = ram{}
+			}
+			got, err := tt.addressing(tt.state, memory)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if got.Memory == nil {
+				t.Errorf("the Memory was not set")
+			}
+			got.Memory = nil
+			if got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressingFuncs_MemoryMustBeProvided(t *testing.T) {
+	for _, addressing := range []AddressingFunc{
+		Absolute, AbsoluteX, AbsoluteY, AbsoluteLong, AbsoluteLongX, AbsoluteIndirect, AbsoluteIndirectLong,
+		AbsoluteXIndirect, BlockMove, Direct, DirectX, DirectY, DirectIndirect, DirectXIndirect, DirectIndirectY,
+		DirectIndirectLong, DirectIndirectLongY, Immediate, ImmediateMemory, ImmediateIndex, Relative,
+		RelativeLong, StackRelative, StackRelativeIndirectY,
+	} {
+		if _, err := addressing(State{}, nil); err != processor.MemoryMustBeProvided {
+			t.Errorf("error got = %v, want = %v", err, processor.MemoryMustBeProvided)
+		}
+	}
+}
+
+func TestAddressing_ReadStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		addressing Addressing
+		state      State
+		value      uint16
+		wide       bool
+		wantState  State
+		wantRam    ram
+	}{
+		{
+			name:       "An 8-bit accumulator keeps the high byte",
+			addressing: Addressing{Accumulator: true},
+			state:      State{A: 0x1234},
+			value:      0x00AB,
+			wantState:  State{A: 0x12AB},
+		},
+		{
+			name:       "A 16-bit accumulator",
+			addressing: Addressing{Accumulator: true},
+			state:      State{A: 0x1234},
+			value:      0xABCD,
+			wide:       true,
+			wantState:  State{A: 0xABCD},
+		},
+		{
+			name:       "An 8-bit value in memory",
+			addressing: Addressing{EffectiveAddress: 0x7EFFFF},
+			value:      0x00AB,
+			wantRam:    ram{0x7EFFFF: 0xAB},
+		},
+		{
+			name:       "A 16-bit value crosses into the next bank",
+			addressing: Addressing{EffectiveAddress: 0x7EFFFF},
+			value:      0xABCD,
+			wide:       true,
+			wantRam:    ram{0x7EFFFF: 0xCD, 0x7F0000: 0xAB},
+		},
+		{
+			name:       "A 16-bit value wraps within bank 0",
+			addressing: Addressing{EffectiveAddress: 0x00FFFF, Bank0: true},
+			value:      0xABCD,
+			wide:       true,
+			wantRam:    ram{0x00FFFF: 0xCD, 0x000000: 0xAB},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := ram{}
+			tt.addressing.Memory = memory
+
+			state, err := tt.addressing.Store(tt.state, tt.value, tt.wide)
+			if err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+			if state != tt.wantState {
+				t.Errorf("Store() State got = %v, want = %v", state, tt.wantState)
+			}
+			for address, want := range tt.wantRam {
+				if got := memory[address]; got != want {
+					t.Errorf("Store() RAM $%06X got = $%02X, want = $%02X", uint32(address), got, want)
+				}
+			}
+
+			got, err := tt.addressing.Read(state, tt.wide)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if got != tt.value {
+				t.Errorf("Read() got = $%04X, want = $%04X", got, tt.value)
+			}
+		})
+	}
+
+	if _, err := (Addressing{}).Read(State{}, false); err != processor.MemoryMustBeProvided {
+		t.Errorf("Read() error got = %v, want = %v", err, processor.MemoryMustBeProvided)
+	}
+	if _, err := (Addressing{}).Store(State{}, 0, false); err != processor.MemoryMustBeProvided {
+		t.Errorf("Store() error got = %v, want = %v", err, processor.MemoryMustBeProvided)
+	}
+}
+
+func TestAddressing_Stack(t *testing.T) {
+	memory := ram{}
+	addressing := Addressing{Memory: memory}
+
+	// In emulation mode the stack wraps within page 1.
+	state, err := addressing.PushWord(State{SP: 0x0100, E: true}, 0x1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SP != 0x01FE || memory[0x0100] != 0x12 || memory[0x01FF] != 0x34 {
+		t.Errorf("PushWord() in emulation mode got SP = $%04X, RAM = %v", state.SP, memory)
+	}
+	state, value, err := addressing.PullWord(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SP != 0x0100 || value != 0x1234 {
+		t.Errorf("PullWord() in emulation mode got SP = $%04X, value = $%04X", state.SP, value)
+	}
+
+	// In native mode the stack can be anywhere in bank 0.
+	state, err = addressing.PushByte(State{SP: 0x1000}, 0xAB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SP != 0x0FFF || memory[0x1000] != 0xAB {
+		t.Errorf("PushByte() in native mode got SP = $%04X, RAM = %v", state.SP, memory)
+	}
+}
@@ -0,0 +1,333 @@
+package w65c816
+
+import (
+	"fmt"
+	"go6502/pkg/processor"
+	"math"
+)
+
+// In native mode bits 5 and 4 of the status register select the width of the accumulator
+// and memory (M) and of the index registers (X). In emulation mode these are the constant
+// and break flags of the 6502 and are always set.
+const FlagMemory = processor.FlagConstant
+const FlagIndex = processor.FlagBreak
+
+// resetCycles is the number of cycles taken by the reset sequence.
+const resetCycles = 7
+
+// The interrupt vectors in bank 0. In native mode BRK has a vector of its own and in
+// emulation mode it shares the IRQ vector, as it does on the 6502.
+const (
+	NativeCopVector      uint16 = 0xFFE4
+	NativeBrkVector      uint16 = 0xFFE6
+	NativeAbortVector    uint16 = 0xFFE8
+	NativeNmiVector      uint16 = 0xFFEA
+	NativeIrqVector      uint16 = 0xFFEE
+	EmulationCopVector   uint16 = 0xFFF4
+	EmulationAbortVector uint16 = 0xFFF8
+	EmulationNmiVector   uint16 = 0xFFFA
+	ResetVector          uint16 = 0xFFFC
+	EmulationIrqVector   uint16 = 0xFFFE
+)
+
+// State represents the entire state of the Cpu at a specific point. The accumulator (C)
+// and index registers are always 16 bits wide; when the M or X flags select 8-bit
+// registers only the low byte is used. The high byte of the accumulator (B) is preserved
+// while the high bytes of the index registers are zero.
+type State struct {
+	PC  uint16 // The program counter within the program bank.
+	PBR uint8  // The program bank register (K).
+	DBR uint8  // The data bank register (B).
+	D   uint16 // The direct page register.
+	SP  uint16 // The stack pointer; always in page 1 in emulation mode.
+	A   uint16
+	X   uint16
+	Y   uint16
+	P   processor.Status
+	E   bool // Emulation mode.
+}
+
+func (s State) String() string {
+	return fmt.Sprintf(
+		"PC: 0x%02X:%04X, DBR: 0x%02X, D: 0x%04X, SP: 0x%04X, A: 0x%04X, X: 0x%04X, Y: 0x%04X, P: %v, E: %v",
+		s.PBR, s.PC, s.DBR, s.D, s.SP, s.A, s.X, s.Y, s.P.String(), s.E)
+}
+
+// MemoryWide returns true if the accumulator and memory are 16 bits wide.
+func (s State) MemoryWide() bool {
+	return !s.E && s.P&FlagMemory == 0
+}
+
+// IndexWide returns true if the index registers are 16 bits wide.
+func (s State) IndexWide() bool {
+	return !s.E && s.P&FlagIndex == 0
+}
+
+// ProgramAddress returns the 24-bit address of the program counter.
+func (s State) ProgramAddress() Address {
+	return MakeAddress(s.PBR, s.PC)
+}
+
+// stackPointer returns sp, which in emulation mode is always in page 1.
+func (s State) stackPointer(sp uint16) uint16 {
+	if s.E {
+		return 0x0100 | sp&0x00FF
+	}
+	return sp
+}
+
+// withStatus returns the State with the status register set to p. In emulation mode the
+// M and X flags are always set, and setting X clears the high bytes of the index registers.
+func (s State) withStatus(p processor.Status) State {
+	if s.E {
+		p |= FlagMemory | FlagIndex
+	}
+	if p&FlagIndex != 0 {
+		s.X &= 0x00FF
+		s.Y &= 0x00FF
+	}
+	s.P = p
+	return s
+}
+
+// withEmulation returns the State with the emulation mode set to e. Entering emulation
+// mode sets the M and X flags and moves the stack to page 1.
+func (s State) withEmulation(e bool) State {
+	s.E = e
+	if e {
+		s.SP = s.stackPointer(s.SP)
+	}
+	return s.withStatus(s.P)
+}
+
+// Cpu represents the actual Cpu
+type Cpu struct {
+	State          State
+	memory         Memory
+	instructionSet InstructionSet
+	runState       processor.RunState
+	addressing     Addressing // Passed to every Operation so that it is not allocated.
+}
+
+// NewCpu returns an initialised Cpu that supports the provided instruction set
+// and is connected to the supplied memory. The registers are all zero; Reset()
+// should be called on the newly constructed CPU to enter emulation mode and load
+// the reset vector.
+func NewCpu(is InstructionSet, memory Memory) (Cpu, error) {
+	if err := is.validate(); err != nil {
+		return Cpu{}, err
+	}
+	if memory == nil {
+		return Cpu{}, processor.MemoryMustBeProvided
+	}
+	return Cpu{memory: memory, instructionSet: is}, nil
+}
+
+// New65C816Cpu returns a Cpu with the WDC 65C816 instruction set.
+func New65C816Cpu(memory Memory) (Cpu, error) {
+	is, err := New65C816InstructionSet()
+	if err != nil {
+		return Cpu{}, err
+	}
+	return NewCpu(is, memory)
+}
+
+// Reset should be called before execution begins. It performs the 7 cycle reset
+// sequence, returning the number of cycles taken. The Cpu enters emulation mode with
+// the M, X and I flags set and the decimal flag cleared. The direct page, data bank and
+// program bank registers are zeroed, the stack is moved to page 1 and the high bytes of
+// the index registers are cleared. The PC is set to the reset vector that is stored in
+// bank 0 at 0xFFFC and 0xFFFD. The Cpu is left Running.
+func (c *Cpu) Reset() (uint, error) {
+	if c == nil {
+		return 0, processor.UninitialisedCpu
+	}
+	if c.memory == nil {
+		return 0, processor.MemoryMustBeProvided
+	}
+
+	state, err := Reset(c.State, c.newAddressing(Addressing{Memory: c.memory}))
+	if err != nil {
+		return resetCycles, err
+	}
+
+	c.State = state
+	c.runState = processor.Running
+
+	return resetCycles, nil
+}
+
+// newAddressing sets the Addressing passed to every Operation called by the Cpu and returns
+// it. Reusing the same Addressing avoids a heap allocation each time an Operation is called.
+func (c *Cpu) newAddressing(addressing Addressing) *Addressing {
+	c.addressing = addressing
+	return &c.addressing
+}
+
+// Step executes the next instruction, returning the number of cycles taken. If the Cpu
+// is Waiting for an interrupt then a single cycle elapses and processor.CpuWaiting is
+// returned. If the Cpu is Stopped then no cycles elapse and processor.CpuStopped is
+// returned. An opcode that is not in the instruction set returns an UnknownOpcodeError.
+// MVN and MVP move a single byte in each Step.
+func (c *Cpu) Step() (uint, error) {
+	if c == nil {
+		return 0, processor.UninitialisedCpu
+	}
+	if c.memory == nil {
+		return 0, processor.MemoryMustBeProvided
+	}
+
+	switch c.runState {
+	case processor.Waiting:
+		return 1, processor.CpuWaiting
+	case processor.Stopped:
+		return 0, processor.CpuStopped
+	}
+
+	address := c.State.ProgramAddress()
+	opcode := processor.Opcode(c.memory.Read(address))
+	c.State.PC++
+
+	instruction := c.instructionSet.lookup(opcode)
+	if instruction == nil {
+		return 1, UnknownOpcodeError{Address: address, Opcode: opcode, State: c.State}
+	}
+
+	// If there is an error executing the instruction (which should not happen)
+	// then we return an error and do not apply the instruction state changes.
+	newState, cycles, err := instruction.execute(c.State, c.memory, &c.addressing)
+	if err != nil {
+		return cycles + 1, err
+	}
+	c.State = newState
+
+	if c.addressing.RunState != processor.Running {
+		c.runState = c.addressing.RunState
+	}
+
+	return cycles + 1, nil
+}
+
+// UnknownOpcodeError is returned when the Cpu reads an opcode that is not in its instruction
+// set. It matches processor.OpCodeNotInInstructionSet using errors.Is.
+type UnknownOpcodeError struct {
+	Address Address // The address of the opcode.
+	Opcode  processor.Opcode
+	State   State // The State of the Cpu once the opcode had been read.
+}
+
+func (e UnknownOpcodeError) Error() string {
+	return fmt.Sprintf("the opcode $%02X at $%02X:%04X is not present in the instruction set",
+		uint8(e.Opcode), e.Address.Bank(), e.Address.Offset())
+}
+
+func (e UnknownOpcodeError) Is(target error) bool {
+	return target == processor.OpCodeNotInInstructionSet
+}
+
+// RunState returns whether the Cpu is Running, Waiting for an interrupt or Stopped.
+func (c *Cpu) RunState() processor.RunState {
+	if c == nil {
+		return processor.Stopped
+	}
+	return c.runState
+}
+
+// Execute will execute instructions until the specified number of cycles have been
+// passed; returning the actual number of cycles that have cycled. The number of cycles
+// actually executed may be more than those specified if the last instruction executed
+// takes it over the limit. Specifying a value of zero for cycles will let the CPU run
+// continuously. If an unknown instruction is executed then Execute also stops, as it
+// does if the Cpu is Waiting for an interrupt or is Stopped.
+func (c *Cpu) Execute(cycles uint) (uint, error) {
+	if c == nil {
+		return 0, processor.UninitialisedCpu
+	}
+
+	if cycles == 0 {
+		cycles = math.MaxUint - 100 // We need this to avoid overflow
+	}
+	elapsedCycles := uint(0)
+
+	for elapsedCycles < cycles {
+		stepCycles, err := c.Step()
+		elapsedCycles += stepCycles
+		if err != nil {
+			return elapsedCycles, err
+		}
+	}
+
+	return elapsedCycles, nil
+}
+
+// Nmi will trigger a non-maskable interrupt. In native mode the program bank register is
+// pushed to the stack first. The PC and the status register are pushed, the I flag is set,
+// the decimal flag cleared and the PC loaded from the NMI vector in bank 0. A Cpu that is
+// Waiting resumes but a Stopped Cpu ignores the interrupt.
+func (c *Cpu) Nmi() error {
+	if c == nil {
+		return processor.UninitialisedCpu
+	}
+
+	// A stopped CPU can only be restarted by a reset.
+	if c.runState == processor.Stopped {
+		return nil
+	}
+	c.runState = processor.Running
+
+	state, err := Nmi(c.State, c.newAddressing(Addressing{Memory: c.memory}))
+	if err != nil {
+		return err
+	}
+	c.State = state
+	return nil
+}
+
+// Interrupt will trigger a hardware interrupt in the same way as Nmi but using the IRQ
+// vector. If the processor status flag has the Interrupt flag set when calling this
+// method, it does nothing other than resume a Cpu that is Waiting. A Stopped Cpu ignores
+// the interrupt.
+func (c *Cpu) Interrupt() error {
+	if c == nil {
+		return processor.UninitialisedCpu
+	}
+
+	// A stopped CPU can only be restarted by a reset.
+	if c.runState == processor.Stopped {
+		return nil
+	}
+
+	// A waiting CPU resumes even if the interrupt disable flag is set; in which
+	// case execution continues with the instruction following the WAI.
+	c.runState = processor.Running
+
+	// If the interrupt disable flag is set then ignore the request.
+	if c.State.P&processor.FlagInterrupt != 0 {
+		return nil
+	}
+
+	state, err := Interrupt(c.State, c.newAddressing(Addressing{Memory: c.memory}))
+	if err != nil {
+		return err
+	}
+	c.State = state
+	return nil
+}
+
+// Opcodes returns a sorted slice of all the opcodes the Cpu has in its instruction set.
+func (c *Cpu) Opcodes() ([]processor.Opcode, error) {
+	if c == nil {
+		return []processor.Opcode{}, processor.UninitialisedCpu
+	}
+
+	return c.instructionSet.Opcodes(), nil
+}
+
+// Memory returns a reference to the memory attached to the CPU.
+func (c *Cpu) Memory() (Memory, error) {
+	if c == nil {
+		return nil, processor.UninitialisedCpu
+	}
+
+	return c.memory, nil
+}
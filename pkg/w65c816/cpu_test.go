@@ -0,0 +1,260 @@
+package w65c816
+
+import (
+	"errors"
+	"go6502/pkg/processor"
+	"testing"
+)
+
+func TestNewCpu(t *testing.T) {
+	is, err := New65C816InstructionSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewCpu(InstructionSet{}, ram{}); err != processor.InstructionSetEmpty {
+		t.Errorf("NewCpu() error got = %v, want = %v", err, processor.InstructionSetEmpty)
+	}
+	if _, err := NewCpu(is, nil); err != processor.MemoryMustBeProvided {
+		t.Errorf("NewCpu() error got = %v, want = %v", err, processor.MemoryMustBeProvided)
+	}
+
+	memory := ram{}
+	cpu, err := New65C816Cpu(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := cpu.Memory(); got == nil {
+		t.Errorf("Memory() returned nil")
+	}
+	if opcodes, _ := cpu.Opcodes(); len(opcodes) != 256 {
+		t.Errorf("Opcodes() got = %v, want = 256", len(opcodes))
+	}
+}
+
+func TestCpu_Reset(t *testing.T) {
+	memory := ram{}.load(0xFFFC, 0x00, 0x80)
+	cpu, err := New65C816Cpu(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu.State = State{PC: 0x1234, PBR: 0x01, DBR: 0x02, D: 0x1000, SP: 0x1FFF, P: processor.FlagDecimal}
+
+	cycles, err := cpu.Reset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cycles != 7 {
+		t.Errorf("Reset() cycles got = %v, want = 7", cycles)
+	}
+	want := State{PC: 0x8000, SP: 0x01FF, P: FlagMemory | FlagIndex | processor.FlagInterrupt, E: true}
+	if cpu.State != want {
+		t.Errorf("Reset() State got = %v, want = %v", cpu.State, want)
+	}
+}
+
+// runProgram resets a Cpu with the program at $00:8000 and executes it until STP.
+func runProgram(t *testing.T, memory ram, program ...uint8) Cpu {
+	memory.load(0xFFFC, 0x00, 0x80).load(0x8000, program...)
+	cpu, err := New65C816Cpu(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Execute(10000); !errors.Is(err, processor.CpuStopped) {
+		t.Fatalf("Execute() error got = %v, want = %v", err, processor.CpuStopped)
+	}
+	return cpu
+}
+
+func TestCpu_Programs(t *testing.T) {
+
+	t.Run("16-bit sum in native mode", func(t *testing.T) {
+		memory := ram{}
+		cpu := runProgram(t, memory,
+			0x18,       // CLC
+			0xFB,       // XCE
+			0xC2, 0x30, // REP #$30
+			0xA9, 0x00, 0x00, // LDA #$0000
+			0xA2, 0x64, 0x00, // LDX #100
+			0x86, 0x10, // loop: STX $10
+			0x18,       // CLC
+			0x65, 0x10, // ADC $10
+			0xCA,       // DEX
+			0xD0, 0xF8, // BNE loop
+			0x8F, 0x00, 0x00, 0x7E, // STA $7E0000
+			0xDB, // STP
+		)
+
+		if cpu.State.E || cpu.State.A != 5050 || cpu.State.X != 0 {
+			t.Errorf("State got = %v", cpu.State)
+		}
+		if memory[0x7E0000] != 0xBA || memory[0x7E0001] != 0x13 {
+			t.Errorf("RAM got = $%02X%02X, want = $13BA", memory[0x7E0001], memory[0x7E0000])
+		}
+	})
+
+	t.Run("MVN copies a block between banks", func(t *testing.T) {
+		memory := ram{}.load(0x7F1000, []uint8("HELLO")...)
+		cpu := runProgram(t, memory,
+			0x18, 0xFB, 0xC2, 0x30, // CLC, XCE, REP #$30
+			0xA9, 0x04, 0x00, // LDA #4
+			0xA2, 0x00, 0x10, // LDX #$1000
+			0xA0, 0x00, 0x20, // LDY #$2000
+			0x54, 0x7E, 0x7F, // MVN $7F,$7E
+			0xDB, // STP
+		)
+
+		for i, want := range []uint8("HELLO") {
+			if got := memory[0x7E2000+Address(i)]; got != want {
+				t.Errorf("RAM $%06X got = %q, want = %q", 0x7E2000+i, got, want)
+			}
+		}
+		if cpu.State.A != 0xFFFF || cpu.State.X != 0x1005 || cpu.State.Y != 0x2005 || cpu.State.DBR != 0x7E {
+			t.Errorf("State got = %v", cpu.State)
+		}
+	})
+
+	t.Run("JSL and RTL in emulation mode", func(t *testing.T) {
+		memory := ram{}.load(0x019000,
+			0xA9, 0x42, // LDA #$42
+			0x6B, // RTL
+		)
+		cpu := runProgram(t, memory,
+			0x22, 0x00, 0x90, 0x01, // JSL $019000
+			0x8D, 0x00, 0x02, // STA $0200
+			0xDB, // STP
+		)
+
+		if memory[0x000200] != 0x42 || cpu.State.PBR != 0x00 || cpu.State.SP != 0x0100 {
+			t.Errorf("State got = %v, RAM $0200 = $%02X", cpu.State, memory[0x000200])
+		}
+	})
+}
+
+func TestCpu_Interrupt(t *testing.T) {
+	memory := ram{}.
+		load(Address(NativeIrqVector), 0x00, 0x90).
+		load(Address(NativeNmiVector), 0x00, 0xA0).
+		load(Address(EmulationNmiVector), 0x00, 0xB0)
+	cpu, err := New65C816Cpu(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The interrupt is ignored if the I flag is set.
+	cpu.State = State{PBR: 0x02, PC: 0x1234, SP: 0x01FF, P: processor.FlagInterrupt}
+	if err := cpu.Interrupt(); err != nil {
+		t.Fatal(err)
+	}
+	if cpu.State.PC != 0x1234 {
+		t.Errorf("Interrupt() with I set got = %v", cpu.State)
+	}
+
+	// In native mode the program bank is pushed.
+	cpu.State.P = 0
+	if err := cpu.Interrupt(); err != nil {
+		t.Fatal(err)
+	}
+	want := State{PC: 0x9000, SP: 0x01FB, P: processor.FlagInterrupt}
+	if cpu.State != want || memory[0x01FF] != 0x02 || memory[0x01FE] != 0x12 || memory[0x01FD] != 0x34 {
+		t.Errorf("Interrupt() got = %v, want = %v", cpu.State, want)
+	}
+
+	if err := cpu.Nmi(); err != nil {
+		t.Fatal(err)
+	}
+	if cpu.State.PC != 0xA000 {
+		t.Errorf("Nmi() in native mode got = %v", cpu.State)
+	}
+
+	// In emulation mode the break flag is cleared in the pushed status register.
+	cpu.State = State{PC: 0x1234, SP: 0x01FF, P: FlagMemory | FlagIndex, E: true}
+	if err := cpu.Nmi(); err != nil {
+		t.Fatal(err)
+	}
+	if cpu.State.PC != 0xB000 || cpu.State.SP != 0x01FC || memory[0x01FD] != 0x20 {
+		t.Errorf("Nmi() in emulation mode got = %v, P = $%02X", cpu.State, memory[0x01FD])
+	}
+
+	var nilCpu *Cpu
+	if err := nilCpu.Interrupt(); err != processor.UninitialisedCpu {
+		t.Errorf("Interrupt() on nil error got = %v", err)
+	}
+	if err := nilCpu.Nmi(); err != processor.UninitialisedCpu {
+		t.Errorf("Nmi() on nil error got = %v", err)
+	}
+}
+
+func TestCpu_RunState(t *testing.T) {
+	memory := ram{}.
+		load(0xFFFC, 0x00, 0x80).
+		load(0x8000, 0xCB, 0xDB) // WAI, STP
+	cpu, err := New65C816Cpu(memory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cpu.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if cycles, err := cpu.Step(); err != processor.CpuWaiting || cycles != 1 || cpu.RunState() != processor.Waiting {
+		t.Errorf("Step() while waiting got = %v, %v", cycles, err)
+	}
+
+	// The I flag is set after a reset so execution continues after the WAI.
+	if err := cpu.Interrupt(); err != nil {
+		t.Fatal(err)
+	}
+	if cpu.RunState() != processor.Running || cpu.State.PC != 0x8001 {
+		t.Errorf("Interrupt() did not resume the Cpu: %v", cpu.State)
+	}
+
+	if _, err := cpu.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if cycles, err := cpu.Step(); err != processor.CpuStopped || cycles != 0 || cpu.RunState() != processor.Stopped {
+		t.Errorf("Step() while stopped got = %v, %v", cycles, err)
+	}
+
+	if _, err := cpu.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if cpu.RunState() != processor.Running {
+		t.Errorf("Reset() did not restart the Cpu")
+	}
+
+	if (*Cpu)(nil).RunState() != processor.Stopped {
+		t.Errorf("RunState() on nil did not return Stopped")
+	}
+}
+
+func TestCpu_UnknownOpcode(t *testing.T) {
+	is, err := NewInstructionSet(Instructions{{Opcode: 0xEA, Mnemonic: "NOP", AddressingFunc: Implied, Operation: NoOperation, Cycles: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu, err := NewCpu(is, ram{}.load(0x128000, 0x42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu.State = State{PBR: 0x12, PC: 0x8000, E: true}
+
+	_, err = cpu.Step()
+	var unknown UnknownOpcodeError
+	if !errors.Is(err, processor.OpCodeNotInInstructionSet) || !errors.As(err, &unknown) {
+		t.Fatalf("Step() error got = %v, want = %v", err, processor.OpCodeNotInInstructionSet)
+	}
+	want := UnknownOpcodeError{Address: 0x128000, Opcode: 0x42, State: State{PBR: 0x12, PC: 0x8001, E: true}}
+	if unknown != want {
+		t.Errorf("Step() error got = %+v, want = %+v", unknown, want)
+	}
+	if got := unknown.Error(); got != "the opcode $42 at $12:8000 is not present in the instruction set" {
+		t.Errorf("Error() got = %v", got)
+	}
+}
@@ -0,0 +1,18 @@
+// Package w65c816 is an emulation of the WDC 65C816, the 16-bit successor to the 65C02 used
+// by the SNES and the Apple IIgs. It follows the same structure as the processor package,
+// with each Instruction made up of an AddressingFunc and an Operation, but the registers
+// are 16 bits wide and addresses are 24 bits.
+//
+// After a reset the Cpu is in emulation mode, where it behaves like a 65C02. XCE switches
+// to native mode, where REP and SEP select 8-bit or 16-bit registers using the M and X flags.
+//
+// Resources and references used to build the 65C816 emulator:
+//
+//   - The W65C816S datasheet by the Western Design Center:
+//     https://www.westerndesigncenter.com/wdc/documentation/w65c816s.pdf
+//
+//   - Programming the 65816 by David Eyes and Ron Lichty.
+//
+//   - 65C816 opcodes by Bruce Clark:
+//     http://www.6502.org/tutorials/65c816opcodes.html
+package w65c816
@@ -0,0 +1,223 @@
+package w65c816
+
+import (
+	"fmt"
+	"go6502/pkg/processor"
+)
+
+// Width identifies which register width, if any, changes the number of cycles an
+// Instruction takes.
+type Width uint8
+
+const (
+	FixedWidth  Width = iota // The instruction takes the same number of cycles in every mode.
+	MemoryWidth              // An additional cycle when the accumulator and memory are 16 bits.
+	IndexWidth               // An additional cycle when the index registers are 16 bits.
+)
+
+// Instruction represents a single Cpu instruction.
+type Instruction struct {
+	Opcode         processor.Opcode
+	Mnemonic       string
+	AddressingFunc AddressingFunc
+	Operation      Operation
+
+	// The baseline number of cycles that this instruction normally requires in emulation
+	// mode (over and above the single cycle the CPU took to read the operation code first).
+	Cycles uint
+
+	// The register whose width adds a cycle for the additional byte that is accessed.
+	Width Width
+
+	// If true, the instruction reads and writes memory, so a 16-bit memory width adds two
+	// cycles rather than one.
+	ReadModifyWrite bool
+
+	// If true, this operation incurs an additional cycle if the index crosses a page
+	// boundary or the index registers are 16 bits.
+	PageBoundaryPenalty bool
+
+	// If true, this operation incurs an additional cycle if the Operation reports that a
+	// branch was taken, and a further cycle in emulation mode if it crossed a page boundary.
+	BranchTakenPenalty bool
+
+	// If true, this operation incurs an additional cycle in native mode for the program
+	// bank register that is pushed or pulled. This is only used by BRK, COP and RTI.
+	NativePenalty bool
+}
+
+type Instructions []Instruction
+
+// Converts the Instruction instance into a canonical string form.
+func (i Instruction) String() string {
+	return fmt.Sprintf(
+		"Opcode: $%02X, Mnemonic: %v, Cycles: %v, PBP: %v",
+		i.Opcode, i.Mnemonic, i.Cycles, i.PageBoundaryPenalty)
+}
+
+// Execute takes a starting State and returns the changed State after
+// executing the instruction operation. also returned are the number
+// of cycles taken to execute the operation.
+func (i Instruction) Execute(state State, memory Memory) (State, uint, error) {
+	var addressing Addressing
+	return i.execute(state, memory, &addressing)
+}
+
+// execute is the same as Execute but the Addressing used by the operation is stored
+// in addressing so the Cpu can act on anything reported by the Operation. The Cpu
+// reuses the same Addressing for every instruction so that it is not allocated.
+func (i *Instruction) execute(state State, memory Memory, addressing *Addressing) (State, uint, error) {
+	if memory == nil {
+		return State{}, 0, processor.MemoryMustBeProvided
+	}
+
+	if i.AddressingFunc == nil {
+		return State{}, 0, processor.NoAddressingModeFunction
+	}
+
+	if i.Operation == nil {
+		return State{}, 0, processor.NoOperationFunction
+	}
+
+	var err error
+	*addressing, err = i.AddressingFunc(state, memory)
+	if err != nil {
+		return State{}, 0, err
+	}
+
+	start := state
+
+	state.PC += addressing.ProgramCounterChange
+
+	state, err = i.Operation(state, addressing)
+	if err != nil {
+		return State{}, 0, err
+	}
+
+	return state, i.cycles(start, addressing), nil
+}
+
+// cycles returns the number of cycles the instruction took to execute, including any
+// penalties that were incurred by the register widths, the addressing mode or the
+// operation. The State is the one in effect when the instruction started.
+func (i *Instruction) cycles(state State, addressing *Addressing) uint {
+	cycles := i.Cycles
+
+	switch i.Width {
+	case MemoryWidth:
+		if state.MemoryWide() {
+			cycles++
+			if i.ReadModifyWrite {
+				cycles++
+			}
+		}
+	case IndexWidth:
+		if state.IndexWide() {
+			cycles++
+		}
+	}
+
+	if addressing.DirectPageUnaligned {
+		cycles++
+	}
+
+	if i.NativePenalty && !state.E {
+		cycles++
+	}
+
+	if i.BranchTakenPenalty {
+		if addressing.BranchTaken {
+			cycles++
+			if state.E && addressing.PageBoundaryCrossed {
+				cycles++
+			}
+		}
+		return cycles
+	}
+
+	if i.PageBoundaryPenalty && (addressing.PageBoundaryCrossed || state.IndexWide()) {
+		cycles++
+	}
+	return cycles
+}
+
+// InstructionSet is a fixed table of Instruction instances indexed by opcode. The table
+// is never changed once it has been built, so it is shared by all copies of the
+// InstructionSet.
+type InstructionSet struct {
+	instructions *[0x100]Instruction
+	defined      *[0x100]bool
+	count        int
+}
+
+// validate returns an error if the instruction set is empty.
+func (is InstructionSet) validate() error {
+	if is.count <= 0 {
+		return processor.InstructionSetEmpty
+	}
+	return nil
+}
+
+// Get returns the Instruction represented by the opcode from InstructionSet.
+// An error is returned if the opcode does not exist in the Instruction Set.
+func (is InstructionSet) Get(opcode processor.Opcode) (Instruction, error) {
+	if err := is.validate(); err != nil {
+		return Instruction{Opcode: opcode}, err
+	}
+
+	if instruction := is.lookup(opcode); instruction != nil {
+		return *instruction, nil
+	}
+
+	return Instruction{Opcode: opcode}, processor.OpCodeNotInInstructionSet
+}
+
+// lookup returns the Instruction represented by the opcode without copying it, or nil
+// if the opcode does not exist in the InstructionSet. The Instruction must not be changed.
+func (is InstructionSet) lookup(opcode processor.Opcode) *Instruction {
+	if is.count <= 0 || !is.defined[opcode] {
+		return nil
+	}
+	return &is.instructions[opcode]
+}
+
+// Opcodes returns a sorted slice of all the opcodes in the instruction set.
+func (is InstructionSet) Opcodes() []processor.Opcode {
+
+	opcodes := make([]processor.Opcode, 0, is.count)
+	for opcode := range 0x100 {
+		if is.count > 0 && is.defined[opcode] {
+			opcodes = append(opcodes, processor.Opcode(opcode))
+		}
+	}
+
+	return opcodes
+}
+
+// NewInstructionSet returns a correctly initialised InstructionSet based on the
+// passed in slice of Instructions.
+func NewInstructionSet(is Instructions) (InstructionSet, error) {
+
+	result := InstructionSet{
+		instructions: new([0x100]Instruction),
+		defined:      new([0x100]bool),
+	}
+	for _, instruction := range is {
+		if !result.defined[instruction.Opcode] {
+			result.count++
+		}
+		result.instructions[instruction.Opcode] = instruction
+		result.defined[instruction.Opcode] = true
+	}
+	if err := result.validate(); err != nil {
+		return InstructionSet{}, err
+	}
+
+	return result, nil
+}
+
+// New65C816InstructionSet returns the complete instruction set of the WDC 65C816. All 256
+// opcodes are used.
+func New65C816InstructionSet() (InstructionSet, error) {
+	return NewInstructionSet(All65C816Opcodes())
+}
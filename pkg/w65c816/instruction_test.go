@@ -0,0 +1,130 @@
+package w65c816
+
+import (
+	"go6502/pkg/processor"
+	"testing"
+)
+
+func TestNew65C816InstructionSet(t *testing.T) {
+	is, err := New65C816InstructionSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opcodes := is.Opcodes()
+	if len(opcodes) != 256 {
+		t.Fatalf("Opcodes() got = %v, want = 256", len(opcodes))
+	}
+	for i, opcode := range opcodes {
+		if opcode != processor.Opcode(i) {
+			t.Errorf("Opcodes()[%v] got = $%02X", i, opcode)
+		}
+		instruction, err := is.Get(opcode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if instruction.Mnemonic == "" || instruction.AddressingFunc == nil || instruction.Operation == nil || instruction.Cycles == 0 {
+			t.Errorf("Get($%02X) is incomplete: %v", opcode, instruction)
+		}
+	}
+}
+
+func TestNewInstructionSet(t *testing.T) {
+	if _, err := NewInstructionSet(Instructions{}); err != processor.InstructionSetEmpty {
+		t.Errorf("NewInstructionSet() error got = %v, want = %v", err, processor.InstructionSetEmpty)
+	}
+
+	is, err := NewInstructionSet(Instructions{{Opcode: 0xEA, Mnemonic: "NOP", AddressingFunc: Implied, Operation: NoOperation, Cycles: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := is.Get(0x00); err != processor.OpCodeNotInInstructionSet {
+		t.Errorf("Get() error got = %v, want = %v", err, processor.OpCodeNotInInstructionSet)
+	}
+}
+
+func TestInstruction_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction Instruction
+		memory      Memory
+		want        error
+	}{
+		{name: "No memory", instruction: Instruction{AddressingFunc: Implied, Operation: NoOperation}, want: processor.MemoryMustBeProvided},
+		{name: "No addressing function", instruction: Instruction{Operation: NoOperation}, memory: ram{}, want: processor.NoAddressingModeFunction},
+		{name: "No operation function", instruction: Instruction{AddressingFunc: Implied}, memory: ram{}, want: processor.NoOperationFunction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.instruction.Execute(State{}, tt.memory); err != tt.want {
+				t.Errorf("Execute() error got = %v, want = %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// The cycles include the opcode fetch and are taken from the W65C816S datasheet.
+func TestInstruction_Cycles(t *testing.T) {
+	const m, x = FlagMemory, FlagIndex
+	emulation := State{PC: 0x8000, P: m | x, E: true}
+	native8 := State{PC: 0x8000, P: m | x}
+	native16 := State{PC: 0x8000}
+
+	with := func(state State, change func(*State)) State {
+		change(&state)
+		return state
+	}
+
+	tests := []struct {
+		name    string
+		state   State
+		program []uint8
+		want    uint
+	}{
+		{name: "LDA # 8-bit", state: emulation, program: []uint8{0xA9, 0x12}, want: 2},
+		{name: "LDA # 16-bit", state: native16, program: []uint8{0xA9, 0x34, 0x12}, want: 3},
+		{name: "LDA d with an unaligned direct page", state: with(native8, func(s *State) { s.D = 0x0001 }), program: []uint8{0xA5, 0x10}, want: 4},
+		{name: "LDA a,x", state: emulation, program: []uint8{0xBD, 0x00, 0x10}, want: 4},
+		{name: "LDA a,x across a page", state: with(emulation, func(s *State) { s.X = 1 }), program: []uint8{0xBD, 0xFF, 0x10}, want: 5},
+		{name: "LDA a,x with 16-bit index registers", state: with(native8, func(s *State) { s.P = m }), program: []uint8{0xBD, 0x00, 0x10}, want: 5},
+		{name: "LDA [d],y", state: emulation, program: []uint8{0xB7, 0x10}, want: 6},
+		{name: "LDA al,x 16-bit", state: native16, program: []uint8{0xBF, 0x00, 0x10, 0x7E}, want: 6},
+		{name: "STA a,x", state: emulation, program: []uint8{0x9D, 0x00, 0x10}, want: 5},
+		{name: "STA (d,s),y", state: emulation, program: []uint8{0x93, 0x01}, want: 7},
+		{name: "ASL a 16-bit", state: native16, program: []uint8{0x0E, 0x00, 0x10}, want: 8},
+		{name: "ASL A 16-bit", state: native16, program: []uint8{0x0A}, want: 2},
+		{name: "INC a,x 16-bit", state: with(native16, func(s *State) { s.P = x }), program: []uint8{0xFE, 0x00, 0x10}, want: 9},
+		{name: "LDX # 16-bit", state: native16, program: []uint8{0xA2, 0x34, 0x12}, want: 3},
+		{name: "PHA 16-bit", state: with(native16, func(s *State) { s.SP = 0x01FF }), program: []uint8{0x48}, want: 4},
+		{name: "BNE not taken", state: with(emulation, func(s *State) { s.P |= processor.FlagZero }), program: []uint8{0xD0, 0x10}, want: 2},
+		{name: "BNE taken", state: emulation, program: []uint8{0xD0, 0x10}, want: 3},
+		{name: "BNE taken across a page in emulation mode", state: emulation, program: []uint8{0xD0, 0x80}, want: 4},
+		{name: "BNE taken across a page in native mode", state: native8, program: []uint8{0xD0, 0x80}, want: 3},
+		{name: "BRA", state: emulation, program: []uint8{0x80, 0x10}, want: 3},
+		{name: "BRL", state: emulation, program: []uint8{0x82, 0x00, 0x10}, want: 4},
+		{name: "BRK in emulation mode", state: with(emulation, func(s *State) { s.SP = 0x01FF }), program: []uint8{0x00, 0x00}, want: 7},
+		{name: "BRK in native mode", state: with(native8, func(s *State) { s.SP = 0x01FF }), program: []uint8{0x00, 0x00}, want: 8},
+		{name: "JSL", state: with(native8, func(s *State) { s.SP = 0x01FF }), program: []uint8{0x22, 0x00, 0x90, 0x01}, want: 8},
+		{name: "MVN", state: native16, program: []uint8{0x54, 0x00, 0x00}, want: 7},
+		{name: "PEI with an unaligned direct page", state: with(native8, func(s *State) { s.D = 0x0001; s.SP = 0x01FF }), program: []uint8{0xD4, 0x10}, want: 7},
+		{name: "XBA", state: emulation, program: []uint8{0xEB}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := ram{}.load(tt.state.ProgramAddress(), tt.program...)
+			cpu, err := New65C816Cpu(memory)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cpu.State = tt.state
+
+			got, err := cpu.Step()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Step() cycles got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package w65c816
+
+// All65C816Opcodes returns the complete instruction set of the WDC 65C816. The cycles are
+// those of emulation mode with 8-bit registers, a page aligned direct page and no page
+// boundary crossed; the penalties of the Instruction add the remaining cycles. See:
+//
+//	https://www.westerndesigncenter.com/wdc/documentation/w65c816s.pdf
+func All65C816Opcodes() Instructions {
+	return Instructions{
+		{Opcode: 0x00, Mnemonic: "BRK", AddressingFunc: Immediate, Operation: Break, Cycles: 6, NativePenalty: true},
+		{Opcode: 0x01, Mnemonic: "ORA", AddressingFunc: DirectXIndirect, Operation: OrWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x02, Mnemonic: "COP", AddressingFunc: Immediate, Operation: CoProcessor, Cycles: 6, NativePenalty: true},
+		{Opcode: 0x03, Mnemonic: "ORA", AddressingFunc: StackRelative, Operation: OrWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x04, Mnemonic: "TSB", AddressingFunc: Direct, Operation: TestAndSetBits, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x05, Mnemonic: "ORA", AddressingFunc: Direct, Operation: OrWithA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x06, Mnemonic: "ASL", AddressingFunc: Direct, Operation: ArithmeticShiftLeft, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x07, Mnemonic: "ORA", AddressingFunc: DirectIndirectLong, Operation: OrWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x08, Mnemonic: "PHP", AddressingFunc: Implied, Operation: PushP, Cycles: 2},
+		{Opcode: 0x09, Mnemonic: "ORA", AddressingFunc: ImmediateMemory, Operation: OrWithA, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0x0A, Mnemonic: "ASL", AddressingFunc: Accumulator, Operation: ArithmeticShiftLeft, Cycles: 1},
+		{Opcode: 0x0B, Mnemonic: "PHD", AddressingFunc: Implied, Operation: PushD, Cycles: 3},
+		{Opcode: 0x0C, Mnemonic: "TSB", AddressingFunc: Absolute, Operation: TestAndSetBits, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x0D, Mnemonic: "ORA", AddressingFunc: Absolute, Operation: OrWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x0E, Mnemonic: "ASL", AddressingFunc: Absolute, Operation: ArithmeticShiftLeft, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x0F, Mnemonic: "ORA", AddressingFunc: AbsoluteLong, Operation: OrWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x10, Mnemonic: "BPL", AddressingFunc: Relative, Operation: BranchOnPlus, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0x11, Mnemonic: "ORA", AddressingFunc: DirectIndirectY, Operation: OrWithA, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x12, Mnemonic: "ORA", AddressingFunc: DirectIndirect, Operation: OrWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x13, Mnemonic: "ORA", AddressingFunc: StackRelativeIndirectY, Operation: OrWithA, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0x14, Mnemonic: "TRB", AddressingFunc: Direct, Operation: TestAndResetBits, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x15, Mnemonic: "ORA", AddressingFunc: DirectX, Operation: OrWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x16, Mnemonic: "ASL", AddressingFunc: DirectX, Operation: ArithmeticShiftLeft, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x17, Mnemonic: "ORA", AddressingFunc: DirectIndirectLongY, Operation: OrWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x18, Mnemonic: "CLC", AddressingFunc: Implied, Operation: ClearCarry, Cycles: 1},
+		{Opcode: 0x19, Mnemonic: "ORA", AddressingFunc: AbsoluteY, Operation: OrWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x1A, Mnemonic: "INC", AddressingFunc: Accumulator, Operation: Increment, Cycles: 1},
+		{Opcode: 0x1B, Mnemonic: "TCS", AddressingFunc: Implied, Operation: TransferAtoSP, Cycles: 1},
+		{Opcode: 0x1C, Mnemonic: "TRB", AddressingFunc: Absolute, Operation: TestAndResetBits, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x1D, Mnemonic: "ORA", AddressingFunc: AbsoluteX, Operation: OrWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x1E, Mnemonic: "ASL", AddressingFunc: AbsoluteX, Operation: ArithmeticShiftLeft, Cycles: 6, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x1F, Mnemonic: "ORA", AddressingFunc: AbsoluteLongX, Operation: OrWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x20, Mnemonic: "JSR", AddressingFunc: Absolute, Operation: JumpSubRoutine, Cycles: 5},
+		{Opcode: 0x21, Mnemonic: "AND", AddressingFunc: DirectXIndirect, Operation: AndWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x22, Mnemonic: "JSL", AddressingFunc: AbsoluteLong, Operation: JumpSubRoutineLong, Cycles: 7},
+		{Opcode: 0x23, Mnemonic: "AND", AddressingFunc: StackRelative, Operation: AndWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x24, Mnemonic: "BIT", AddressingFunc: Direct, Operation: TestBitsInMemoryWithAccumulator, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x25, Mnemonic: "AND", AddressingFunc: Direct, Operation: AndWithA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x26, Mnemonic: "ROL", AddressingFunc: Direct, Operation: RotateLeft, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x27, Mnemonic: "AND", AddressingFunc: DirectIndirectLong, Operation: AndWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x28, Mnemonic: "PLP", AddressingFunc: Implied, Operation: PullP, Cycles: 3},
+		{Opcode: 0x29, Mnemonic: "AND", AddressingFunc: ImmediateMemory, Operation: AndWithA, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0x2A, Mnemonic: "ROL", AddressingFunc: Accumulator, Operation: RotateLeft, Cycles: 1},
+		{Opcode: 0x2B, Mnemonic: "PLD", AddressingFunc: Implied, Operation: PullD, Cycles: 4},
+		{Opcode: 0x2C, Mnemonic: "BIT", AddressingFunc: Absolute, Operation: TestBitsInMemoryWithAccumulator, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x2D, Mnemonic: "AND", AddressingFunc: Absolute, Operation: AndWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x2E, Mnemonic: "ROL", AddressingFunc: Absolute, Operation: RotateLeft, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x2F, Mnemonic: "AND", AddressingFunc: AbsoluteLong, Operation: AndWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x30, Mnemonic: "BMI", AddressingFunc: Relative, Operation: BranchOnMinus, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0x31, Mnemonic: "AND", AddressingFunc: DirectIndirectY, Operation: AndWithA, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x32, Mnemonic: "AND", AddressingFunc: DirectIndirect, Operation: AndWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x33, Mnemonic: "AND", AddressingFunc: StackRelativeIndirectY, Operation: AndWithA, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0x34, Mnemonic: "BIT", AddressingFunc: DirectX, Operation: TestBitsInMemoryWithAccumulator, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x35, Mnemonic: "AND", AddressingFunc: DirectX, Operation: AndWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x36, Mnemonic: "ROL", AddressingFunc: DirectX, Operation: RotateLeft, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x37, Mnemonic: "AND", AddressingFunc: DirectIndirectLongY, Operation: AndWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x38, Mnemonic: "SEC", AddressingFunc: Implied, Operation: SetCarry, Cycles: 1},
+		{Opcode: 0x39, Mnemonic: "AND", AddressingFunc: AbsoluteY, Operation: AndWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x3A, Mnemonic: "DEC", AddressingFunc: Accumulator, Operation: Decrement, Cycles: 1},
+		{Opcode: 0x3B, Mnemonic: "TSC", AddressingFunc: Implied, Operation: TransferSPtoA, Cycles: 1},
+		{Opcode: 0x3C, Mnemonic: "BIT", AddressingFunc: AbsoluteX, Operation: TestBitsInMemoryWithAccumulator, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x3D, Mnemonic: "AND", AddressingFunc: AbsoluteX, Operation: AndWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x3E, Mnemonic: "ROL", AddressingFunc: AbsoluteX, Operation: RotateLeft, Cycles: 6, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x3F, Mnemonic: "AND", AddressingFunc: AbsoluteLongX, Operation: AndWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x40, Mnemonic: "RTI", AddressingFunc: Implied, Operation: ReturnFromInterrupt, Cycles: 5, NativePenalty: true},
+		{Opcode: 0x41, Mnemonic: "EOR", AddressingFunc: DirectXIndirect, Operation: ExclusiveOrWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x42, Mnemonic: "WDM", AddressingFunc: Immediate, Operation: NoOperation, Cycles: 1},
+		{Opcode: 0x43, Mnemonic: "EOR", AddressingFunc: StackRelative, Operation: ExclusiveOrWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x44, Mnemonic: "MVP", AddressingFunc: BlockMove, Operation: MovePositive, Cycles: 6},
+		{Opcode: 0x45, Mnemonic: "EOR", AddressingFunc: Direct, Operation: ExclusiveOrWithA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x46, Mnemonic: "LSR", AddressingFunc: Direct, Operation: LogicalShiftRight, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x47, Mnemonic: "EOR", AddressingFunc: DirectIndirectLong, Operation: ExclusiveOrWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x48, Mnemonic: "PHA", AddressingFunc: Implied, Operation: PushA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x49, Mnemonic: "EOR", AddressingFunc: ImmediateMemory, Operation: ExclusiveOrWithA, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0x4A, Mnemonic: "LSR", AddressingFunc: Accumulator, Operation: LogicalShiftRight, Cycles: 1},
+		{Opcode: 0x4B, Mnemonic: "PHK", AddressingFunc: Implied, Operation: PushPBR, Cycles: 2},
+		{Opcode: 0x4C, Mnemonic: "JMP", AddressingFunc: Absolute, Operation: Jump, Cycles: 2},
+		{Opcode: 0x4D, Mnemonic: "EOR", AddressingFunc: Absolute, Operation: ExclusiveOrWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x4E, Mnemonic: "LSR", AddressingFunc: Absolute, Operation: LogicalShiftRight, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x4F, Mnemonic: "EOR", AddressingFunc: AbsoluteLong, Operation: ExclusiveOrWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x50, Mnemonic: "BVC", AddressingFunc: Relative, Operation: BranchOnOverflowClear, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0x51, Mnemonic: "EOR", AddressingFunc: DirectIndirectY, Operation: ExclusiveOrWithA, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x52, Mnemonic: "EOR", AddressingFunc: DirectIndirect, Operation: ExclusiveOrWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x53, Mnemonic: "EOR", AddressingFunc: StackRelativeIndirectY, Operation: ExclusiveOrWithA, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0x54, Mnemonic: "MVN", AddressingFunc: BlockMove, Operation: MoveNegative, Cycles: 6},
+		{Opcode: 0x55, Mnemonic: "EOR", AddressingFunc: DirectX, Operation: ExclusiveOrWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x56, Mnemonic: "LSR", AddressingFunc: DirectX, Operation: LogicalShiftRight, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x57, Mnemonic: "EOR", AddressingFunc: DirectIndirectLongY, Operation: ExclusiveOrWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x58, Mnemonic: "CLI", AddressingFunc: Implied, Operation: ClearInterrupt, Cycles: 1},
+		{Opcode: 0x59, Mnemonic: "EOR", AddressingFunc: AbsoluteY, Operation: ExclusiveOrWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x5A, Mnemonic: "PHY", AddressingFunc: Implied, Operation: PushY, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0x5B, Mnemonic: "TCD", AddressingFunc: Implied, Operation: TransferAtoD, Cycles: 1},
+		{Opcode: 0x5C, Mnemonic: "JML", AddressingFunc: AbsoluteLong, Operation: JumpLong, Cycles: 3},
+		{Opcode: 0x5D, Mnemonic: "EOR", AddressingFunc: AbsoluteX, Operation: ExclusiveOrWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x5E, Mnemonic: "LSR", AddressingFunc: AbsoluteX, Operation: LogicalShiftRight, Cycles: 6, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x5F, Mnemonic: "EOR", AddressingFunc: AbsoluteLongX, Operation: ExclusiveOrWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x60, Mnemonic: "RTS", AddressingFunc: Implied, Operation: ReturnFromSubroutine, Cycles: 5},
+		{Opcode: 0x61, Mnemonic: "ADC", AddressingFunc: DirectXIndirect, Operation: AddWithCarry, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x62, Mnemonic: "PER", AddressingFunc: RelativeLong, Operation: PushEffectiveAddress, Cycles: 5},
+		{Opcode: 0x63, Mnemonic: "ADC", AddressingFunc: StackRelative, Operation: AddWithCarry, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x64, Mnemonic: "STZ", AddressingFunc: Direct, Operation: StoreZero, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x65, Mnemonic: "ADC", AddressingFunc: Direct, Operation: AddWithCarry, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x66, Mnemonic: "ROR", AddressingFunc: Direct, Operation: RotateRight, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x67, Mnemonic: "ADC", AddressingFunc: DirectIndirectLong, Operation: AddWithCarry, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x68, Mnemonic: "PLA", AddressingFunc: Implied, Operation: PullA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x69, Mnemonic: "ADC", AddressingFunc: ImmediateMemory, Operation: AddWithCarry, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0x6A, Mnemonic: "ROR", AddressingFunc: Accumulator, Operation: RotateRight, Cycles: 1},
+		{Opcode: 0x6B, Mnemonic: "RTL", AddressingFunc: Implied, Operation: ReturnFromSubroutineLong, Cycles: 5},
+		{Opcode: 0x6C, Mnemonic: "JMP", AddressingFunc: AbsoluteIndirect, Operation: Jump, Cycles: 4},
+		{Opcode: 0x6D, Mnemonic: "ADC", AddressingFunc: Absolute, Operation: AddWithCarry, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x6E, Mnemonic: "ROR", AddressingFunc: Absolute, Operation: RotateRight, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x6F, Mnemonic: "ADC", AddressingFunc: AbsoluteLong, Operation: AddWithCarry, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x70, Mnemonic: "BVS", AddressingFunc: Relative, Operation: BranchOnOverflowSet, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0x71, Mnemonic: "ADC", AddressingFunc: DirectIndirectY, Operation: AddWithCarry, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x72, Mnemonic: "ADC", AddressingFunc: DirectIndirect, Operation: AddWithCarry, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x73, Mnemonic: "ADC", AddressingFunc: StackRelativeIndirectY, Operation: AddWithCarry, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0x74, Mnemonic: "STZ", AddressingFunc: DirectX, Operation: StoreZero, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x75, Mnemonic: "ADC", AddressingFunc: DirectX, Operation: AddWithCarry, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x76, Mnemonic: "ROR", AddressingFunc: DirectX, Operation: RotateRight, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x77, Mnemonic: "ADC", AddressingFunc: DirectIndirectLongY, Operation: AddWithCarry, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x78, Mnemonic: "SEI", AddressingFunc: Implied, Operation: SetInterrupt, Cycles: 1},
+		{Opcode: 0x79, Mnemonic: "ADC", AddressingFunc: AbsoluteY, Operation: AddWithCarry, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x7A, Mnemonic: "PLY", AddressingFunc: Implied, Operation: PullY, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0x7B, Mnemonic: "TDC", AddressingFunc: Implied, Operation: TransferDtoA, Cycles: 1},
+		{Opcode: 0x7C, Mnemonic: "JMP", AddressingFunc: AbsoluteXIndirect, Operation: Jump, Cycles: 5},
+		{Opcode: 0x7D, Mnemonic: "ADC", AddressingFunc: AbsoluteX, Operation: AddWithCarry, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0x7E, Mnemonic: "ROR", AddressingFunc: AbsoluteX, Operation: RotateRight, Cycles: 6, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0x7F, Mnemonic: "ADC", AddressingFunc: AbsoluteLongX, Operation: AddWithCarry, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x80, Mnemonic: "BRA", AddressingFunc: Relative, Operation: BranchAlways, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0x81, Mnemonic: "STA", AddressingFunc: DirectXIndirect, Operation: StoreA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x82, Mnemonic: "BRL", AddressingFunc: RelativeLong, Operation: BranchAlways, Cycles: 3},
+		{Opcode: 0x83, Mnemonic: "STA", AddressingFunc: StackRelative, Operation: StoreA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x84, Mnemonic: "STY", AddressingFunc: Direct, Operation: StoreY, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0x85, Mnemonic: "STA", AddressingFunc: Direct, Operation: StoreA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0x86, Mnemonic: "STX", AddressingFunc: Direct, Operation: StoreX, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0x87, Mnemonic: "STA", AddressingFunc: DirectIndirectLong, Operation: StoreA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x88, Mnemonic: "DEY", AddressingFunc: Implied, Operation: DecrementY, Cycles: 1},
+		{Opcode: 0x89, Mnemonic: "BIT", AddressingFunc: ImmediateMemory, Operation: TestBitsImmediate, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0x8A, Mnemonic: "TXA", AddressingFunc: Implied, Operation: TransferXtoA, Cycles: 1},
+		{Opcode: 0x8B, Mnemonic: "PHB", AddressingFunc: Implied, Operation: PushDBR, Cycles: 2},
+		{Opcode: 0x8C, Mnemonic: "STY", AddressingFunc: Absolute, Operation: StoreY, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0x8D, Mnemonic: "STA", AddressingFunc: Absolute, Operation: StoreA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x8E, Mnemonic: "STX", AddressingFunc: Absolute, Operation: StoreX, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0x8F, Mnemonic: "STA", AddressingFunc: AbsoluteLong, Operation: StoreA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x90, Mnemonic: "BCC", AddressingFunc: Relative, Operation: BranchOnCarryClear, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0x91, Mnemonic: "STA", AddressingFunc: DirectIndirectY, Operation: StoreA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x92, Mnemonic: "STA", AddressingFunc: DirectIndirect, Operation: StoreA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x93, Mnemonic: "STA", AddressingFunc: StackRelativeIndirectY, Operation: StoreA, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0x94, Mnemonic: "STY", AddressingFunc: DirectX, Operation: StoreY, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0x95, Mnemonic: "STA", AddressingFunc: DirectX, Operation: StoreA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x96, Mnemonic: "STX", AddressingFunc: DirectY, Operation: StoreX, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0x97, Mnemonic: "STA", AddressingFunc: DirectIndirectLongY, Operation: StoreA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0x98, Mnemonic: "TYA", AddressingFunc: Implied, Operation: TransferYtoA, Cycles: 1},
+		{Opcode: 0x99, Mnemonic: "STA", AddressingFunc: AbsoluteY, Operation: StoreA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x9A, Mnemonic: "TXS", AddressingFunc: Implied, Operation: TransferXtoSP, Cycles: 1},
+		{Opcode: 0x9B, Mnemonic: "TXY", AddressingFunc: Implied, Operation: TransferXtoY, Cycles: 1},
+		{Opcode: 0x9C, Mnemonic: "STZ", AddressingFunc: Absolute, Operation: StoreZero, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0x9D, Mnemonic: "STA", AddressingFunc: AbsoluteX, Operation: StoreA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x9E, Mnemonic: "STZ", AddressingFunc: AbsoluteX, Operation: StoreZero, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0x9F, Mnemonic: "STA", AddressingFunc: AbsoluteLongX, Operation: StoreA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xA0, Mnemonic: "LDY", AddressingFunc: ImmediateIndex, Operation: LoadY, Cycles: 1, Width: IndexWidth},
+		{Opcode: 0xA1, Mnemonic: "LDA", AddressingFunc: DirectXIndirect, Operation: LoadA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xA2, Mnemonic: "LDX", AddressingFunc: ImmediateIndex, Operation: LoadX, Cycles: 1, Width: IndexWidth},
+		{Opcode: 0xA3, Mnemonic: "LDA", AddressingFunc: StackRelative, Operation: LoadA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xA4, Mnemonic: "LDY", AddressingFunc: Direct, Operation: LoadY, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0xA5, Mnemonic: "LDA", AddressingFunc: Direct, Operation: LoadA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0xA6, Mnemonic: "LDX", AddressingFunc: Direct, Operation: LoadX, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0xA7, Mnemonic: "LDA", AddressingFunc: DirectIndirectLong, Operation: LoadA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xA8, Mnemonic: "TAY", AddressingFunc: Implied, Operation: TransferAtoY, Cycles: 1},
+		{Opcode: 0xA9, Mnemonic: "LDA", AddressingFunc: ImmediateMemory, Operation: LoadA, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0xAA, Mnemonic: "TAX", AddressingFunc: Implied, Operation: TransferAtoX, Cycles: 1},
+		{Opcode: 0xAB, Mnemonic: "PLB", AddressingFunc: Implied, Operation: PullDBR, Cycles: 3},
+		{Opcode: 0xAC, Mnemonic: "LDY", AddressingFunc: Absolute, Operation: LoadY, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xAD, Mnemonic: "LDA", AddressingFunc: Absolute, Operation: LoadA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xAE, Mnemonic: "LDX", AddressingFunc: Absolute, Operation: LoadX, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xAF, Mnemonic: "LDA", AddressingFunc: AbsoluteLong, Operation: LoadA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xB0, Mnemonic: "BCS", AddressingFunc: Relative, Operation: BranchOnCarrySet, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0xB1, Mnemonic: "LDA", AddressingFunc: DirectIndirectY, Operation: LoadA, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xB2, Mnemonic: "LDA", AddressingFunc: DirectIndirect, Operation: LoadA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xB3, Mnemonic: "LDA", AddressingFunc: StackRelativeIndirectY, Operation: LoadA, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0xB4, Mnemonic: "LDY", AddressingFunc: DirectX, Operation: LoadY, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xB5, Mnemonic: "LDA", AddressingFunc: DirectX, Operation: LoadA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xB6, Mnemonic: "LDX", AddressingFunc: DirectY, Operation: LoadX, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xB7, Mnemonic: "LDA", AddressingFunc: DirectIndirectLongY, Operation: LoadA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xB8, Mnemonic: "CLV", AddressingFunc: Implied, Operation: ClearOverflow, Cycles: 1},
+		{Opcode: 0xB9, Mnemonic: "LDA", AddressingFunc: AbsoluteY, Operation: LoadA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xBA, Mnemonic: "TSX", AddressingFunc: Implied, Operation: TransferSPtoX, Cycles: 1},
+		{Opcode: 0xBB, Mnemonic: "TYX", AddressingFunc: Implied, Operation: TransferYtoX, Cycles: 1},
+		{Opcode: 0xBC, Mnemonic: "LDY", AddressingFunc: AbsoluteX, Operation: LoadY, Cycles: 3, Width: IndexWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xBD, Mnemonic: "LDA", AddressingFunc: AbsoluteX, Operation: LoadA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xBE, Mnemonic: "LDX", AddressingFunc: AbsoluteY, Operation: LoadX, Cycles: 3, Width: IndexWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xBF, Mnemonic: "LDA", AddressingFunc: AbsoluteLongX, Operation: LoadA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xC0, Mnemonic: "CPY", AddressingFunc: ImmediateIndex, Operation: CompareWithY, Cycles: 1, Width: IndexWidth},
+		{Opcode: 0xC1, Mnemonic: "CMP", AddressingFunc: DirectXIndirect, Operation: CompareWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xC2, Mnemonic: "REP", AddressingFunc: Immediate, Operation: ResetStatusBits, Cycles: 2},
+		{Opcode: 0xC3, Mnemonic: "CMP", AddressingFunc: StackRelative, Operation: CompareWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xC4, Mnemonic: "CPY", AddressingFunc: Direct, Operation: CompareWithY, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0xC5, Mnemonic: "CMP", AddressingFunc: Direct, Operation: CompareWithA, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0xC6, Mnemonic: "DEC", AddressingFunc: Direct, Operation: Decrement, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xC7, Mnemonic: "CMP", AddressingFunc: DirectIndirectLong, Operation: CompareWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xC8, Mnemonic: "INY", AddressingFunc: Implied, Operation: IncrementY, Cycles: 1},
+		{Opcode: 0xC9, Mnemonic: "CMP", AddressingFunc: ImmediateMemory, Operation: CompareWithA, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0xCA, Mnemonic: "DEX", AddressingFunc: Implied, Operation: DecrementX, Cycles: 1},
+		{Opcode: 0xCB, Mnemonic: "WAI", AddressingFunc: Implied, Operation: WaitForInterrupt, Cycles: 2},
+		{Opcode: 0xCC, Mnemonic: "CPY", AddressingFunc: Absolute, Operation: CompareWithY, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xCD, Mnemonic: "CMP", AddressingFunc: Absolute, Operation: CompareWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xCE, Mnemonic: "DEC", AddressingFunc: Absolute, Operation: Decrement, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xCF, Mnemonic: "CMP", AddressingFunc: AbsoluteLong, Operation: CompareWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xD0, Mnemonic: "BNE", AddressingFunc: Relative, Operation: BranchOnNotEqual, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0xD1, Mnemonic: "CMP", AddressingFunc: DirectIndirectY, Operation: CompareWithA, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xD2, Mnemonic: "CMP", AddressingFunc: DirectIndirect, Operation: CompareWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xD3, Mnemonic: "CMP", AddressingFunc: StackRelativeIndirectY, Operation: CompareWithA, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0xD4, Mnemonic: "PEI", AddressingFunc: DirectIndirect, Operation: PushEffectiveAddress, Cycles: 5},
+		{Opcode: 0xD5, Mnemonic: "CMP", AddressingFunc: DirectX, Operation: CompareWithA, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xD6, Mnemonic: "DEC", AddressingFunc: DirectX, Operation: Decrement, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xD7, Mnemonic: "CMP", AddressingFunc: DirectIndirectLongY, Operation: CompareWithA, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xD8, Mnemonic: "CLD", AddressingFunc: Implied, Operation: ClearDecimal, Cycles: 1},
+		{Opcode: 0xD9, Mnemonic: "CMP", AddressingFunc: AbsoluteY, Operation: CompareWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xDA, Mnemonic: "PHX", AddressingFunc: Implied, Operation: PushX, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0xDB, Mnemonic: "STP", AddressingFunc: Implied, Operation: Stop, Cycles: 2},
+		{Opcode: 0xDC, Mnemonic: "JML", AddressingFunc: AbsoluteIndirectLong, Operation: JumpLong, Cycles: 5},
+		{Opcode: 0xDD, Mnemonic: "CMP", AddressingFunc: AbsoluteX, Operation: CompareWithA, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xDE, Mnemonic: "DEC", AddressingFunc: AbsoluteX, Operation: Decrement, Cycles: 6, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xDF, Mnemonic: "CMP", AddressingFunc: AbsoluteLongX, Operation: CompareWithA, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xE0, Mnemonic: "CPX", AddressingFunc: ImmediateIndex, Operation: CompareWithX, Cycles: 1, Width: IndexWidth},
+		{Opcode: 0xE1, Mnemonic: "SBC", AddressingFunc: DirectXIndirect, Operation: SubtractWithCarry, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xE2, Mnemonic: "SEP", AddressingFunc: Immediate, Operation: SetStatusBits, Cycles: 2},
+		{Opcode: 0xE3, Mnemonic: "SBC", AddressingFunc: StackRelative, Operation: SubtractWithCarry, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xE4, Mnemonic: "CPX", AddressingFunc: Direct, Operation: CompareWithX, Cycles: 2, Width: IndexWidth},
+		{Opcode: 0xE5, Mnemonic: "SBC", AddressingFunc: Direct, Operation: SubtractWithCarry, Cycles: 2, Width: MemoryWidth},
+		{Opcode: 0xE6, Mnemonic: "INC", AddressingFunc: Direct, Operation: Increment, Cycles: 4, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xE7, Mnemonic: "SBC", AddressingFunc: DirectIndirectLong, Operation: SubtractWithCarry, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xE8, Mnemonic: "INX", AddressingFunc: Implied, Operation: IncrementX, Cycles: 1},
+		{Opcode: 0xE9, Mnemonic: "SBC", AddressingFunc: ImmediateMemory, Operation: SubtractWithCarry, Cycles: 1, Width: MemoryWidth},
+		{Opcode: 0xEA, Mnemonic: "NOP", AddressingFunc: Implied, Operation: NoOperation, Cycles: 1},
+		{Opcode: 0xEB, Mnemonic: "XBA", AddressingFunc: Implied, Operation: ExchangeBA, Cycles: 2},
+		{Opcode: 0xEC, Mnemonic: "CPX", AddressingFunc: Absolute, Operation: CompareWithX, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xED, Mnemonic: "SBC", AddressingFunc: Absolute, Operation: SubtractWithCarry, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xEE, Mnemonic: "INC", AddressingFunc: Absolute, Operation: Increment, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xEF, Mnemonic: "SBC", AddressingFunc: AbsoluteLong, Operation: SubtractWithCarry, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xF0, Mnemonic: "BEQ", AddressingFunc: Relative, Operation: BranchOnEqual, Cycles: 1, BranchTakenPenalty: true},
+		{Opcode: 0xF1, Mnemonic: "SBC", AddressingFunc: DirectIndirectY, Operation: SubtractWithCarry, Cycles: 4, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xF2, Mnemonic: "SBC", AddressingFunc: DirectIndirect, Operation: SubtractWithCarry, Cycles: 4, Width: MemoryWidth},
+		{Opcode: 0xF3, Mnemonic: "SBC", AddressingFunc: StackRelativeIndirectY, Operation: SubtractWithCarry, Cycles: 6, Width: MemoryWidth},
+		{Opcode: 0xF4, Mnemonic: "PEA", AddressingFunc: Absolute, Operation: PushEffectiveAddress, Cycles: 4},
+		{Opcode: 0xF5, Mnemonic: "SBC", AddressingFunc: DirectX, Operation: SubtractWithCarry, Cycles: 3, Width: MemoryWidth},
+		{Opcode: 0xF6, Mnemonic: "INC", AddressingFunc: DirectX, Operation: Increment, Cycles: 5, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xF7, Mnemonic: "SBC", AddressingFunc: DirectIndirectLongY, Operation: SubtractWithCarry, Cycles: 5, Width: MemoryWidth},
+		{Opcode: 0xF8, Mnemonic: "SED", AddressingFunc: Implied, Operation: SetDecimal, Cycles: 1},
+		{Opcode: 0xF9, Mnemonic: "SBC", AddressingFunc: AbsoluteY, Operation: SubtractWithCarry, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xFA, Mnemonic: "PLX", AddressingFunc: Implied, Operation: PullX, Cycles: 3, Width: IndexWidth},
+		{Opcode: 0xFB, Mnemonic: "XCE", AddressingFunc: Implied, Operation: ExchangeCarryEmulation, Cycles: 1},
+		{Opcode: 0xFC, Mnemonic: "JSR", AddressingFunc: AbsoluteXIndirect, Operation: JumpSubRoutine, Cycles: 7},
+		{Opcode: 0xFD, Mnemonic: "SBC", AddressingFunc: AbsoluteX, Operation: SubtractWithCarry, Cycles: 3, Width: MemoryWidth, PageBoundaryPenalty: true},
+		{Opcode: 0xFE, Mnemonic: "INC", AddressingFunc: AbsoluteX, Operation: Increment, Cycles: 6, Width: MemoryWidth, ReadModifyWrite: true},
+		{Opcode: 0xFF, Mnemonic: "SBC", AddressingFunc: AbsoluteLongX, Operation: SubtractWithCarry, Cycles: 4, Width: MemoryWidth},
+	}
+}
@@ -0,0 +1,959 @@
+package w65c816
+
+import (
+	"go6502/pkg/processor"
+)
+
+// Operation performs the operation phase of an instructions' execution. It uses the
+// Addressing calculated by the AddressingFunc to read and write the operands, which are
+// 8 or 16 bits wide depending on the M and X flags of the State.
+type Operation func(State, *Addressing) (State, error)
+
+// ************************************************************
+// ********** Helper functions
+// ************************************************************
+
+// setRegister returns register with value stored in it. An 8-bit value only replaces the
+// low byte of the register.
+func setRegister(register, value uint16, wide bool) uint16 {
+	if wide {
+		return value
+	}
+	return register&0xFF00 | value&0x00FF
+}
+
+// signBit returns the bit that holds the sign of a value of the given width.
+func signBit(wide bool) uint16 {
+	if wide {
+		return 0x8000
+	}
+	return 0x0080
+}
+
+// widthMask returns the mask of a value of the given width.
+func widthMask(wide bool) uint16 {
+	if wide {
+		return 0xFFFF
+	}
+	return 0x00FF
+}
+
+// setFlag sets or clears the flag of the status register.
+func setFlag(p processor.Status, flag processor.Status, set bool) processor.Status {
+	if set {
+		return p | flag
+	}
+	return p &^ flag
+}
+
+// negativeZeroSet sets the negative and zero flags from a value of the given width.
+func negativeZeroSet(p processor.Status, value uint16, wide bool) processor.Status {
+	p = setFlag(p, processor.FlagNegative, value&signBit(wide) != 0)
+	return setFlag(p, processor.FlagZero, value&widthMask(wide) == 0)
+}
+
+// load reads the value of the given width and sets the negative and zero flags.
+func load(state State, addressing *Addressing, wide bool) (State, uint16, error) {
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, 0, err
+	}
+	state.P = negativeZeroSet(state.P, value, wide)
+	return state, value, nil
+}
+
+// compare compares the register with the value read, setting the carry, zero and
+// negative flags.
+func compare(state State, addressing *Addressing, register uint16, wide bool) (State, error) {
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	register &= widthMask(wide)
+	state.P = setFlag(state.P, processor.FlagCarry, register >= value)
+	state.P = negativeZeroSet(state.P, register-value, wide)
+	return state, nil
+}
+
+// readModifyWrite reads the value of the memory width, applies modify to it and stores
+// the result.
+func readModifyWrite(state State, addressing *Addressing, modify func(State, uint16, bool) (State, uint16)) (State, error) {
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	state, value = modify(state, value, wide)
+	return addressing.Store(state, value, wide)
+}
+
+// branch changes the PC to the EffectiveAddress if the condition is met.
+func branch(state State, addressing *Addressing, condition bool) (State, error) {
+	if condition {
+		addressing.BranchTaken = true
+		state.PC = addressing.EffectiveAddress.Offset()
+	}
+	return state, nil
+}
+
+// add returns the result of adding the 8-bit or 16-bit values with the carry along with
+// the carry and overflow. In decimal mode each nibble is adjusted in turn and the
+// overflow is that of the result before the most significant nibble is adjusted. If
+// subtract is set then value is subtracted instead, with the carry as the inverted borrow.
+func add(a, value uint16, carry, decimal, wide, subtract bool) (uint16, bool, bool) {
+	bits := 8
+	if wide {
+		bits = 16
+	}
+	mask := int(widthMask(wide))
+	if subtract {
+		value ^= uint16(mask)
+	}
+
+	c := 0
+	if carry {
+		c = 1
+	}
+
+	result := 0
+	if !decimal {
+		result = int(a) + int(value) + c
+	} else {
+		for shift := 0; shift < bits; shift += 4 {
+			lower := 1<<shift - 1
+			nibble := 0xF << shift
+			result = int(a)&nibble + int(value)&nibble + c<<shift + result&lower
+			if shift == bits-4 {
+				break
+			}
+			if subtract && result <= nibble|lower {
+				result -= 0x6 << shift
+			} else if !subtract && result > 0x9<<shift|lower {
+				result += 0x6 << shift
+			}
+			c = 0
+			if result > nibble|lower {
+				c = 1
+			}
+		}
+	}
+
+	sign := int(signBit(wide))
+	overflow := ^(int(a)^int(value))&(int(a)^result)&sign != 0
+
+	if decimal {
+		shift := bits - 4
+		if subtract && result <= mask {
+			result -= 0x6 << shift
+		} else if !subtract && result > 0x9<<shift|(1<<shift-1) {
+			result += 0x6 << shift
+		}
+	}
+
+	return uint16(result & mask), result > mask, overflow
+}
+
+// addWithCarry performs ADC or SBC on the accumulator.
+func addWithCarry(state State, addressing *Addressing, subtract bool) (State, error) {
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+
+	result, carry, overflow := add(state.A, value, state.P&processor.FlagCarry != 0, state.P&processor.FlagDecimal != 0, wide, subtract)
+	state.A = setRegister(state.A, result, wide)
+	state.P = setFlag(state.P, processor.FlagCarry, carry)
+	state.P = setFlag(state.P, processor.FlagOverflow, overflow)
+	state.P = negativeZeroSet(state.P, result, wide)
+	return state, nil
+}
+
+// interrupt pushes the program bank (in native mode), the PC and the status register
+// before setting the I flag, clearing the decimal flag and loading the PC from the vector
+// in bank 0.
+func interrupt(state State, addressing *Addressing, p processor.Status, vector uint16) (State, error) {
+	var err error
+	if !state.E {
+		if state, err = addressing.PushByte(state, state.PBR); err != nil {
+			return state, err
+		}
+	}
+	if state, err = addressing.PushWord(state, state.PC); err != nil {
+		return state, err
+	}
+	if state, err = addressing.PushByte(state, uint8(p)); err != nil {
+		return state, err
+	}
+
+	state.P |= processor.FlagInterrupt
+	state.P &^= processor.FlagDecimal
+	state.PBR = 0
+	state.PC = readWord(addressing.Memory, vector)
+	return state, nil
+}
+
+// vector returns the native or emulation mode vector.
+func vector(state State, native, emulation uint16) uint16 {
+	if state.E {
+		return emulation
+	}
+	return native
+}
+
+// ************************************************************
+// ********** Operation functions
+// ************************************************************
+
+// AddWithCarry (ADC): adds the value to the accumulator with the carry.
+func AddWithCarry(state State, addressing *Addressing) (State, error) {
+	return addWithCarry(state, addressing, false)
+}
+
+// AndWithA (AND): logical AND of the value with the accumulator.
+func AndWithA(state State, addressing *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	state.A = setRegister(state.A, state.A&value, wide)
+	state.P = negativeZeroSet(state.P, state.A, wide)
+	return state, nil
+}
+
+// ArithmeticShiftLeft (ASL): shifts the value left, moving bit 7 (or 15) into the carry.
+func ArithmeticShiftLeft(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		state.P = setFlag(state.P, processor.FlagCarry, value&signBit(wide) != 0)
+		value <<= 1
+		state.P = negativeZeroSet(state.P, value, wide)
+		return state, value
+	})
+}
+
+// BranchOnCarryClear (BCC).
+func BranchOnCarryClear(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagCarry == 0)
+}
+
+// BranchOnCarrySet (BCS).
+func BranchOnCarrySet(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagCarry != 0)
+}
+
+// BranchOnEqual (BEQ).
+func BranchOnEqual(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagZero != 0)
+}
+
+// BranchOnMinus (BMI).
+func BranchOnMinus(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagNegative != 0)
+}
+
+// BranchOnNotEqual (BNE).
+func BranchOnNotEqual(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagZero == 0)
+}
+
+// BranchOnPlus (BPL).
+func BranchOnPlus(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagNegative == 0)
+}
+
+// BranchAlways (BRA, BRL).
+func BranchAlways(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, true)
+}
+
+// BranchOnOverflowClear (BVC).
+func BranchOnOverflowClear(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagOverflow == 0)
+}
+
+// BranchOnOverflowSet (BVS).
+func BranchOnOverflowSet(state State, addressing *Addressing) (State, error) {
+	return branch(state, addressing, state.P&processor.FlagOverflow != 0)
+}
+
+// TestBitsInMemoryWithAccumulator (BIT): sets the zero flag from the accumulator AND the value, and the negative
+// and overflow flags from the top two bits of the value.
+func TestBitsInMemoryWithAccumulator(state State, addressing *Addressing) (State, error) {
+	state, err := TestBitsImmediate(state, addressing)
+	if err != nil {
+		return state, err
+	}
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	state.P = setFlag(state.P, processor.FlagNegative, value&signBit(wide) != 0)
+	state.P = setFlag(state.P, processor.FlagOverflow, value&(signBit(wide)>>1) != 0)
+	return state, nil
+}
+
+// TestBitsImmediate (BIT #): only sets the zero flag from the accumulator AND the value.
+func TestBitsImmediate(state State, addressing *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	state.P = setFlag(state.P, processor.FlagZero, state.A&value&widthMask(wide) == 0)
+	return state, nil
+}
+
+// Break (BRK): a software interrupt. In emulation mode the break flag is set in the
+// status register that is pushed and the IRQ vector is used.
+func Break(state State, addressing *Addressing) (State, error) {
+	p := state.P
+	if state.E {
+		p |= processor.FlagBreak
+	}
+	return interrupt(state, addressing, p, vector(state, NativeBrkVector, EmulationIrqVector))
+}
+
+// ClearCarry (CLC).
+func ClearCarry(state State, _ *Addressing) (State, error) {
+	state.P &^= processor.FlagCarry
+	return state, nil
+}
+
+// ClearDecimal (CLD).
+func ClearDecimal(state State, _ *Addressing) (State, error) {
+	state.P &^= processor.FlagDecimal
+	return state, nil
+}
+
+// ClearInterrupt (CLI).
+func ClearInterrupt(state State, _ *Addressing) (State, error) {
+	state.P &^= processor.FlagInterrupt
+	return state, nil
+}
+
+// ClearOverflow (CLV).
+func ClearOverflow(state State, _ *Addressing) (State, error) {
+	state.P &^= processor.FlagOverflow
+	return state, nil
+}
+
+// CompareWithA (CMP): compares the accumulator with the value.
+func CompareWithA(state State, addressing *Addressing) (State, error) {
+	return compare(state, addressing, state.A, state.MemoryWide())
+}
+
+// CoProcessor (COP): a software interrupt using the COP vector.
+func CoProcessor(state State, addressing *Addressing) (State, error) {
+	return interrupt(state, addressing, state.P, vector(state, NativeCopVector, EmulationCopVector))
+}
+
+// CompareWithX (CPX): compares the X register with the value.
+func CompareWithX(state State, addressing *Addressing) (State, error) {
+	return compare(state, addressing, state.X, state.IndexWide())
+}
+
+// CompareWithY (CPY): compares the Y register with the value.
+func CompareWithY(state State, addressing *Addressing) (State, error) {
+	return compare(state, addressing, state.Y, state.IndexWide())
+}
+
+// Decrement (DEC): subtracts one from the value.
+func Decrement(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		value--
+		state.P = negativeZeroSet(state.P, value, wide)
+		return state, value
+	})
+}
+
+// DecrementX (DEX).
+func DecrementX(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.X = (state.X - 1) & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.X, wide)
+	return state, nil
+}
+
+// DecrementY (DEY).
+func DecrementY(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.Y = (state.Y - 1) & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.Y, wide)
+	return state, nil
+}
+
+// ExclusiveOrWithA (EOR): logical exclusive OR of the value with the accumulator.
+func ExclusiveOrWithA(state State, addressing *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	state.A = setRegister(state.A, state.A^value, wide)
+	state.P = negativeZeroSet(state.P, state.A, wide)
+	return state, nil
+}
+
+// Increment (INC): adds one to the value.
+func Increment(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		value++
+		state.P = negativeZeroSet(state.P, value, wide)
+		return state, value
+	})
+}
+
+// IncrementX (INX).
+func IncrementX(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.X = (state.X + 1) & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.X, wide)
+	return state, nil
+}
+
+// IncrementY (INY).
+func IncrementY(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.Y = (state.Y + 1) & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.Y, wide)
+	return state, nil
+}
+
+// Jump (JMP): jumps to the address within the program bank.
+func Jump(state State, addressing *Addressing) (State, error) {
+	state.PC = addressing.EffectiveAddress.Offset()
+	return state, nil
+}
+
+// JumpLong (JML): jumps to the 24-bit address, changing the program bank.
+func JumpLong(state State, addressing *Addressing) (State, error) {
+	state.PBR = addressing.EffectiveAddress.Bank()
+	state.PC = addressing.EffectiveAddress.Offset()
+	return state, nil
+}
+
+// JumpSubRoutine (JSR): pushes the address of the last byte of the instruction and jumps
+// to the address within the program bank.
+func JumpSubRoutine(state State, addressing *Addressing) (State, error) {
+	state, err := addressing.PushWord(state, state.PC-1)
+	if err != nil {
+		return state, err
+	}
+	state.PC = addressing.EffectiveAddress.Offset()
+	return state, nil
+}
+
+// JumpSubRoutineLong (JSL): pushes the program bank and the address of the last byte of
+// the instruction before jumping to the 24-bit address.
+func JumpSubRoutineLong(state State, addressing *Addressing) (State, error) {
+	state, err := addressing.PushByte(state, state.PBR)
+	if err != nil {
+		return state, err
+	}
+	state, err = addressing.PushWord(state, state.PC-1)
+	if err != nil {
+		return state, err
+	}
+	state.PBR = addressing.EffectiveAddress.Bank()
+	state.PC = addressing.EffectiveAddress.Offset()
+	return state, nil
+}
+
+// LoadA (LDA).
+func LoadA(state State, addressing *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	state, value, err := load(state, addressing, wide)
+	state.A = setRegister(state.A, value, wide)
+	return state, err
+}
+
+// LoadX (LDX).
+func LoadX(state State, addressing *Addressing) (State, error) {
+	state, value, err := load(state, addressing, state.IndexWide())
+	state.X = value
+	return state, err
+}
+
+// LoadY (LDY).
+func LoadY(state State, addressing *Addressing) (State, error) {
+	state, value, err := load(state, addressing, state.IndexWide())
+	state.Y = value
+	return state, err
+}
+
+// LogicalShiftRight (LSR): shifts the value right, moving bit 0 into the carry.
+func LogicalShiftRight(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		state.P = setFlag(state.P, processor.FlagCarry, value&0x0001 != 0)
+		value >>= 1
+		state.P = negativeZeroSet(state.P, value, wide)
+		return state, value
+	})
+}
+
+// blockMove moves a single byte from the source bank at X to the destination bank at Y,
+// then changes X and Y by step and decrements the accumulator. The data bank register is
+// set to the destination bank. The instruction is executed again until the accumulator
+// wraps to 0xFFFF, so the accumulator holds the number of bytes to move less one.
+func blockMove(state State, addressing *Addressing, step uint16) (State, error) {
+	if addressing.Memory == nil {
+		return state, processor.MemoryMustBeProvided
+	}
+
+	value := addressing.Memory.Read(MakeAddress(addressing.SourceBank, state.X))
+	addressing.Memory.Write(MakeAddress(addressing.DestinationBank, state.Y), value)
+
+	mask := widthMask(state.IndexWide())
+	state.X = (state.X + step) & mask
+	state.Y = (state.Y + step) & mask
+	state.A--
+	state.DBR = addressing.DestinationBank
+
+	if state.A != 0xFFFF {
+		state.PC -= 3
+	}
+	return state, nil
+}
+
+// MoveNegative (MVN): moves a block of memory, incrementing X and Y. This is used when
+// the destination is below the source.
+func MoveNegative(state State, addressing *Addressing) (State, error) {
+	return blockMove(state, addressing, 1)
+}
+
+// MovePositive (MVP): moves a block of memory, decrementing X and Y. This is used when
+// the destination is above the source, with X and Y the addresses of the last byte.
+func MovePositive(state State, addressing *Addressing) (State, error) {
+	return blockMove(state, addressing, 0xFFFF)
+}
+
+// NoOperation (NOP, WDM).
+func NoOperation(state State, _ *Addressing) (State, error) {
+	return state, nil
+}
+
+// OrWithA (ORA): logical OR of the value with the accumulator.
+func OrWithA(state State, addressing *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	value, err := addressing.Read(state, wide)
+	if err != nil {
+		return state, err
+	}
+	state.A = setRegister(state.A, state.A|value, wide)
+	state.P = negativeZeroSet(state.P, state.A, wide)
+	return state, nil
+}
+
+// PushEffectiveAddress (PEA, PEI, PER): pushes the 16-bit effective address.
+func PushEffectiveAddress(state State, addressing *Addressing) (State, error) {
+	return addressing.PushWord(state, addressing.EffectiveAddress.Offset())
+}
+
+// PushA (PHA).
+func PushA(state State, addressing *Addressing) (State, error) {
+	if state.MemoryWide() {
+		return addressing.PushWord(state, state.A)
+	}
+	return addressing.PushByte(state, uint8(state.A))
+}
+
+// PushDBR (PHB).
+func PushDBR(state State, addressing *Addressing) (State, error) {
+	return addressing.PushByte(state, state.DBR)
+}
+
+// PushD (PHD).
+func PushD(state State, addressing *Addressing) (State, error) {
+	return addressing.PushWord(state, state.D)
+}
+
+// PushPBR (PHK).
+func PushPBR(state State, addressing *Addressing) (State, error) {
+	return addressing.PushByte(state, state.PBR)
+}
+
+// PushP (PHP). In emulation mode the break flag is always set.
+func PushP(state State, addressing *Addressing) (State, error) {
+	return addressing.PushByte(state, uint8(state.P))
+}
+
+// PushX (PHX).
+func PushX(state State, addressing *Addressing) (State, error) {
+	if state.IndexWide() {
+		return addressing.PushWord(state, state.X)
+	}
+	return addressing.PushByte(state, uint8(state.X))
+}
+
+// PushY (PHY).
+func PushY(state State, addressing *Addressing) (State, error) {
+	if state.IndexWide() {
+		return addressing.PushWord(state, state.Y)
+	}
+	return addressing.PushByte(state, uint8(state.Y))
+}
+
+// pull pulls a value of the given width from the stack and sets the negative and zero flags.
+func pull(state State, addressing *Addressing, wide bool) (State, uint16, error) {
+	var value uint16
+	var err error
+	if wide {
+		state, value, err = addressing.PullWord(state)
+	} else {
+		var b uint8
+		state, b, err = addressing.PullByte(state)
+		value = uint16(b)
+	}
+	if err != nil {
+		return state, 0, err
+	}
+	state.P = negativeZeroSet(state.P, value, wide)
+	return state, value, nil
+}
+
+// PullA (PLA).
+func PullA(state State, addressing *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	state, value, err := pull(state, addressing, wide)
+	state.A = setRegister(state.A, value, wide)
+	return state, err
+}
+
+// PullDBR (PLB).
+func PullDBR(state State, addressing *Addressing) (State, error) {
+	state, value, err := pull(state, addressing, false)
+	state.DBR = uint8(value)
+	return state, err
+}
+
+// PullD (PLD).
+func PullD(state State, addressing *Addressing) (State, error) {
+	state, value, err := pull(state, addressing, true)
+	state.D = value
+	return state, err
+}
+
+// PullP (PLP). In emulation mode the M and X flags remain set.
+func PullP(state State, addressing *Addressing) (State, error) {
+	state, p, err := addressing.PullByte(state)
+	if err != nil {
+		return state, err
+	}
+	return state.withStatus(processor.Status(p)), nil
+}
+
+// PullX (PLX).
+func PullX(state State, addressing *Addressing) (State, error) {
+	state, value, err := pull(state, addressing, state.IndexWide())
+	state.X = value
+	return state, err
+}
+
+// PullY (PLY).
+func PullY(state State, addressing *Addressing) (State, error) {
+	state, value, err := pull(state, addressing, state.IndexWide())
+	state.Y = value
+	return state, err
+}
+
+// ResetStatusBits (REP): clears the flags that are set in the value. In emulation mode
+// the M and X flags cannot be cleared.
+func ResetStatusBits(state State, addressing *Addressing) (State, error) {
+	value, err := addressing.Read(state, false)
+	if err != nil {
+		return state, err
+	}
+	return state.withStatus(state.P &^ processor.Status(value)), nil
+}
+
+// RotateLeft (ROL): rotates the value left through the carry.
+func RotateLeft(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		carry := state.P & processor.FlagCarry
+		state.P = setFlag(state.P, processor.FlagCarry, value&signBit(wide) != 0)
+		value = value<<1 | uint16(carry)
+		state.P = negativeZeroSet(state.P, value, wide)
+		return state, value
+	})
+}
+
+// RotateRight (ROR): rotates the value right through the carry.
+func RotateRight(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		carry := state.P&processor.FlagCarry != 0
+		state.P = setFlag(state.P, processor.FlagCarry, value&0x0001 != 0)
+		value >>= 1
+		if carry {
+			value |= signBit(wide)
+		}
+		state.P = negativeZeroSet(state.P, value, wide)
+		return state, value
+	})
+}
+
+// ReturnFromInterrupt (RTI): pulls the status register and the PC, along with the program
+// bank in native mode.
+func ReturnFromInterrupt(state State, addressing *Addressing) (State, error) {
+	state, p, err := addressing.PullByte(state)
+	if err != nil {
+		return state, err
+	}
+	state = state.withStatus(processor.Status(p))
+
+	state, state.PC, err = addressing.PullWord(state)
+	if err != nil {
+		return state, err
+	}
+
+	if !state.E {
+		state, state.PBR, err = addressing.PullByte(state)
+	}
+	return state, err
+}
+
+// ReturnFromSubroutine (RTS): pulls the PC within the program bank.
+func ReturnFromSubroutine(state State, addressing *Addressing) (State, error) {
+	state, pc, err := addressing.PullWord(state)
+	state.PC = pc + 1
+	return state, err
+}
+
+// ReturnFromSubroutineLong (RTL): pulls the PC and the program bank.
+func ReturnFromSubroutineLong(state State, addressing *Addressing) (State, error) {
+	state, pc, err := addressing.PullWord(state)
+	if err != nil {
+		return state, err
+	}
+	state.PC = pc + 1
+	state, state.PBR, err = addressing.PullByte(state)
+	return state, err
+}
+
+// SubtractWithCarry (SBC): subtracts the value from the accumulator with the carry as an
+// inverted borrow.
+func SubtractWithCarry(state State, addressing *Addressing) (State, error) {
+	return addWithCarry(state, addressing, true)
+}
+
+// SetCarry (SEC).
+func SetCarry(state State, _ *Addressing) (State, error) {
+	state.P |= processor.FlagCarry
+	return state, nil
+}
+
+// SetDecimal (SED).
+func SetDecimal(state State, _ *Addressing) (State, error) {
+	state.P |= processor.FlagDecimal
+	return state, nil
+}
+
+// SetInterrupt (SEI).
+func SetInterrupt(state State, _ *Addressing) (State, error) {
+	state.P |= processor.FlagInterrupt
+	return state, nil
+}
+
+// SetStatusBits (SEP): sets the flags that are set in the value. Setting the X flag
+// clears the high bytes of the index registers.
+func SetStatusBits(state State, addressing *Addressing) (State, error) {
+	value, err := addressing.Read(state, false)
+	if err != nil {
+		return state, err
+	}
+	return state.withStatus(state.P | processor.Status(value)), nil
+}
+
+// StoreA (STA).
+func StoreA(state State, addressing *Addressing) (State, error) {
+	return addressing.Store(state, state.A, state.MemoryWide())
+}
+
+// Stop (STP): stops the Cpu until it is reset.
+func Stop(state State, addressing *Addressing) (State, error) {
+	addressing.RunState = processor.Stopped
+	return state, nil
+}
+
+// StoreX (STX).
+func StoreX(state State, addressing *Addressing) (State, error) {
+	return addressing.Store(state, state.X, state.IndexWide())
+}
+
+// StoreY (STY).
+func StoreY(state State, addressing *Addressing) (State, error) {
+	return addressing.Store(state, state.Y, state.IndexWide())
+}
+
+// StoreZero (STZ).
+func StoreZero(state State, addressing *Addressing) (State, error) {
+	return addressing.Store(state, 0, state.MemoryWide())
+}
+
+// TransferAtoX (TAX).
+func TransferAtoX(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.X = state.A & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.X, wide)
+	return state, nil
+}
+
+// TransferAtoY (TAY).
+func TransferAtoY(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.Y = state.A & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.Y, wide)
+	return state, nil
+}
+
+// TransferAtoD (TCD): transfers all 16 bits of the accumulator.
+func TransferAtoD(state State, _ *Addressing) (State, error) {
+	state.D = state.A
+	state.P = negativeZeroSet(state.P, state.D, true)
+	return state, nil
+}
+
+// TransferAtoSP (TCS): transfers all 16 bits of the accumulator. In
+// emulation mode the stack remains in page 1.
+func TransferAtoSP(state State, _ *Addressing) (State, error) {
+	state.SP = state.stackPointer(state.A)
+	return state, nil
+}
+
+// TransferDtoA (TDC): transfers to all 16 bits of the accumulator.
+func TransferDtoA(state State, _ *Addressing) (State, error) {
+	state.A = state.D
+	state.P = negativeZeroSet(state.P, state.A, true)
+	return state, nil
+}
+
+// TestAndResetBits (TRB): clears the bits of the value that are set in the accumulator.
+func TestAndResetBits(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		state.P = setFlag(state.P, processor.FlagZero, state.A&value&widthMask(wide) == 0)
+		return state, value &^ state.A
+	})
+}
+
+// TestAndSetBits (TSB): sets the bits of the value that are set in the accumulator.
+func TestAndSetBits(state State, addressing *Addressing) (State, error) {
+	return readModifyWrite(state, addressing, func(state State, value uint16, wide bool) (State, uint16) {
+		state.P = setFlag(state.P, processor.FlagZero, state.A&value&widthMask(wide) == 0)
+		return state, value | state.A
+	})
+}
+
+// TransferSPtoA (TSC): transfers to all 16 bits of the accumulator.
+func TransferSPtoA(state State, _ *Addressing) (State, error) {
+	state.A = state.SP
+	state.P = negativeZeroSet(state.P, state.A, true)
+	return state, nil
+}
+
+// TransferSPtoX (TSX).
+func TransferSPtoX(state State, _ *Addressing) (State, error) {
+	wide := state.IndexWide()
+	state.X = state.SP & widthMask(wide)
+	state.P = negativeZeroSet(state.P, state.X, wide)
+	return state, nil
+}
+
+// TransferXtoA (TXA).
+func TransferXtoA(state State, _ *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	state.A = setRegister(state.A, state.X, wide)
+	state.P = negativeZeroSet(state.P, state.A, wide)
+	return state, nil
+}
+
+// TransferXtoSP (TXS). In emulation mode the stack remains in page 1.
+func TransferXtoSP(state State, _ *Addressing) (State, error) {
+	state.SP = state.stackPointer(state.X)
+	return state, nil
+}
+
+// TransferXtoY (TXY).
+func TransferXtoY(state State, _ *Addressing) (State, error) {
+	state.Y = state.X
+	state.P = negativeZeroSet(state.P, state.Y, state.IndexWide())
+	return state, nil
+}
+
+// TransferYtoA (TYA).
+func TransferYtoA(state State, _ *Addressing) (State, error) {
+	wide := state.MemoryWide()
+	state.A = setRegister(state.A, state.Y, wide)
+	state.P = negativeZeroSet(state.P, state.A, wide)
+	return state, nil
+}
+
+// TransferYtoX (TYX).
+func TransferYtoX(state State, _ *Addressing) (State, error) {
+	state.X = state.Y
+	state.P = negativeZeroSet(state.P, state.X, state.IndexWide())
+	return state, nil
+}
+
+// WaitForInterrupt (WAI): waits for an interrupt.
+func WaitForInterrupt(state State, addressing *Addressing) (State, error) {
+	addressing.RunState = processor.Waiting
+	return state, nil
+}
+
+// ExchangeBA (XBA): swaps the high (B) and low (A) bytes of the accumulator. The negative
+// and zero flags are set from the new low byte.
+func ExchangeBA(state State, _ *Addressing) (State, error) {
+	state.A = state.A<<8 | state.A>>8
+	state.P = negativeZeroSet(state.P, state.A, false)
+	return state, nil
+}
+
+// ExchangeCarryEmulation (XCE): swaps the carry flag and the emulation mode. Entering
+// emulation mode sets the M and X flags, clears the high bytes of the index registers
+// and moves the stack to page 1.
+func ExchangeCarryEmulation(state State, _ *Addressing) (State, error) {
+	carry := state.P&processor.FlagCarry != 0
+	state.P = setFlag(state.P, processor.FlagCarry, state.E)
+	return state.withEmulation(carry), nil
+}
+
+// ************************************************************
+// ********** Interrupt and reset operations
+// ************************************************************
+
+// Interrupt is a hardware interrupt (IRQ). In emulation mode the break flag is cleared in
+// the status register that is pushed.
+func Interrupt(state State, addressing *Addressing) (State, error) {
+	p := state.P
+	if state.E {
+		p &^= processor.FlagBreak
+	}
+	return interrupt(state, addressing, p, vector(state, NativeIrqVector, EmulationIrqVector))
+}
+
+// Nmi is a non-maskable interrupt.
+func Nmi(state State, addressing *Addressing) (State, error) {
+	p := state.P
+	if state.E {
+		p &^= processor.FlagBreak
+	}
+	return interrupt(state, addressing, p, vector(state, NativeNmiVector, EmulationNmiVector))
+}
+
+// Reset enters emulation mode and loads the PC from the reset vector. The accumulator and
+// the low bytes of the index registers are unchanged.
+func Reset(state State, addressing *Addressing) (State, error) {
+	if addressing.Memory == nil {
+		return state, processor.MemoryMustBeProvided
+	}
+
+	state.D = 0
+	state.DBR = 0
+	state.PBR = 0
+	state.P |= processor.FlagInterrupt
+	state.P &^= processor.FlagDecimal
+	state = state.withEmulation(true)
+	state.PC = readWord(addressing.Memory, ResetVector)
+	return state, nil
+}
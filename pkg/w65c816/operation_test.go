@@ -0,0 +1,463 @@
+package w65c816
+
+import (
+	"go6502/pkg/processor"
+	"testing"
+)
+
+func Test_add(t *testing.T) {
+	tests := []struct {
+		name                      string
+		a, value                  uint16
+		carry, decimal, wide, sub bool
+		want                      uint16
+		wantCarry, wantOverflow   bool
+	}{
+		{name: "8-bit binary", a: 0x7F, value: 0x01, want: 0x80, wantOverflow: true},
+		{name: "8-bit binary carry", a: 0xFF, value: 0x01, want: 0x00, wantCarry: true},
+		{name: "16-bit binary", a: 0x7FFF, value: 0x0001, wide: true, want: 0x8000, wantOverflow: true},
+		{name: "16-bit binary carry", a: 0xFFFF, value: 0x0000, carry: true, wide: true, want: 0x0000, wantCarry: true},
+		{name: "8-bit binary subtract", a: 0x00, value: 0x01, carry: true, sub: true, want: 0xFF},
+		{name: "16-bit binary subtract", a: 0x8000, value: 0x0001, carry: true, wide: true, sub: true, want: 0x7FFF, wantCarry: true, wantOverflow: true},
+		{name: "8-bit decimal overflow before the adjust", a: 0x58, value: 0x46, carry: true, decimal: true, want: 0x05, wantCarry: true, wantOverflow: true},
+		{name: "8-bit decimal 99+01", a: 0x99, value: 0x01, decimal: true, want: 0x00, wantCarry: true},
+		{name: "16-bit decimal", a: 0x1999, value: 0x0001, decimal: true, wide: true, want: 0x2000},
+		{name: "16-bit decimal carry", a: 0x9999, value: 0x0001, decimal: true, wide: true, want: 0x0000, wantCarry: true},
+		{name: "8-bit decimal subtract", a: 0x10, value: 0x01, carry: true, decimal: true, sub: true, want: 0x09, wantCarry: true},
+		{name: "16-bit decimal subtract", a: 0x1000, value: 0x0001, carry: true, decimal: true, wide: true, sub: true, want: 0x0999, wantCarry: true},
+		{name: "16-bit decimal subtract borrow", a: 0x0000, value: 0x0001, carry: true, decimal: true, wide: true, sub: true, want: 0x9999},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, carry, overflow := add(tt.a, tt.value, tt.carry, tt.decimal, tt.wide, tt.sub)
+			if got != tt.want || carry != tt.wantCarry || overflow != tt.wantOverflow {
+				t.Errorf("add() got = $%04X, %v, %v, want = $%04X, %v, %v", got, carry, overflow, tt.want, tt.wantCarry, tt.wantOverflow)
+			}
+		})
+	}
+}
+
+func TestOperations(t *testing.T) {
+	const m, x = FlagMemory, FlagIndex
+	tests := []struct {
+		name       string
+		addressing AddressingFunc
+		operation  Operation
+		state      State
+		ram        ram
+		wantState  State
+		wantRam    ram
+	}{
+		{
+			name:       "LDA # 16-bit",
+			addressing: ImmediateMemory,
+			operation:  LoadA,
+			state:      State{PC: 0x8000},
+			ram:        ram{}.load(0x8000, 0x34, 0x92),
+			wantState:  State{PC: 0x8002, A: 0x9234, P: processor.FlagNegative},
+		},
+		{
+			name:       "LDA # 8-bit keeps the high byte",
+			addressing: ImmediateMemory,
+			operation:  LoadA,
+			state:      State{PC: 0x8000, A: 0xAB00, P: m},
+			ram:        ram{}.load(0x8000, 0x00),
+			wantState:  State{PC: 0x8001, A: 0xAB00, P: m | processor.FlagZero},
+		},
+		{
+			name:       "ADC # 16-bit decimal",
+			addressing: ImmediateMemory,
+			operation:  AddWithCarry,
+			state:      State{PC: 0x8000, A: 0x1999, P: processor.FlagDecimal},
+			ram:        ram{}.load(0x8000, 0x01, 0x00),
+			wantState:  State{PC: 0x8002, A: 0x2000, P: processor.FlagDecimal},
+		},
+		{
+			name:       "SBC # 16-bit decimal",
+			addressing: ImmediateMemory,
+			operation:  SubtractWithCarry,
+			state:      State{PC: 0x8000, A: 0x1000, P: processor.FlagDecimal | processor.FlagCarry},
+			ram:        ram{}.load(0x8000, 0x01, 0x00),
+			wantState:  State{PC: 0x8002, A: 0x0999, P: processor.FlagDecimal | processor.FlagCarry},
+		},
+		{
+			name:       "CMP # 16-bit",
+			addressing: ImmediateMemory,
+			operation:  CompareWithA,
+			state:      State{PC: 0x8000, A: 0x1000, P: processor.FlagCarry},
+			ram:        ram{}.load(0x8000, 0x00, 0x20),
+			wantState:  State{PC: 0x8002, A: 0x1000, P: processor.FlagNegative},
+		},
+		{
+			name:       "CPX # 8-bit ignores the high byte of the operand",
+			addressing: ImmediateIndex,
+			operation:  CompareWithX,
+			state:      State{PC: 0x8000, X: 0x0010, P: x},
+			ram:        ram{}.load(0x8000, 0x10, 0xFF),
+			wantState:  State{PC: 0x8001, X: 0x0010, P: x | processor.FlagZero | processor.FlagCarry},
+		},
+		{
+			name:       "BIT # only changes the zero flag",
+			addressing: ImmediateMemory,
+			operation:  TestBitsImmediate,
+			state:      State{PC: 0x8000},
+			ram:        ram{}.load(0x8000, 0x00, 0xC0),
+			wantState:  State{PC: 0x8002, P: processor.FlagZero},
+		},
+		{
+			name:       "BIT d 16-bit sets the negative and overflow flags",
+			addressing: Direct,
+			operation:  TestBitsInMemoryWithAccumulator,
+			state:      State{PC: 0x8000},
+			ram:        ram{}.load(0x8000, 0x10).load(0x0010, 0x00, 0xC0),
+			wantState:  State{PC: 0x8001, P: processor.FlagZero | processor.FlagNegative | processor.FlagOverflow},
+		},
+		{
+			name:       "ASL d 16-bit",
+			addressing: Direct,
+			operation:  ArithmeticShiftLeft,
+			state:      State{PC: 0x8000},
+			ram:        ram{}.load(0x8000, 0x10).load(0x0010, 0x00, 0x80),
+			wantState:  State{PC: 0x8001, P: processor.FlagZero | processor.FlagCarry},
+			wantRam:    ram{0x0010: 0x00, 0x0011: 0x00},
+		},
+		{
+			name:       "ROR A 8-bit keeps the high byte",
+			addressing: Accumulator,
+			operation:  RotateRight,
+			state:      State{A: 0x1202, P: m | x | processor.FlagCarry, E: true},
+			wantState:  State{A: 0x1281, P: m | x | processor.FlagNegative, E: true},
+		},
+		{
+			name:       "TSB a 16-bit",
+			addressing: Absolute,
+			operation:  TestAndSetBits,
+			state:      State{PC: 0x8000, A: 0x00F0, DBR: 0x7E},
+			ram:        ram{}.load(0x8000, 0x00, 0x20).load(0x7E2000, 0x00, 0x0F),
+			wantState:  State{PC: 0x8002, A: 0x00F0, DBR: 0x7E, P: processor.FlagZero},
+			wantRam:    ram{0x7E2000: 0xF0, 0x7E2001: 0x0F},
+		},
+		{
+			name:       "STZ a 16-bit crosses into the next bank",
+			addressing: Absolute,
+			operation:  StoreZero,
+			state:      State{PC: 0x8000, DBR: 0x7E},
+			ram:        ram{}.load(0x8000, 0xFF, 0xFF).load(0x7EFFFF, 0xAA).load(0x7F0000, 0xBB),
+			wantState:  State{PC: 0x8002, DBR: 0x7E},
+			wantRam:    ram{0x7EFFFF: 0x00, 0x7F0000: 0x00},
+		},
+		{
+			name:       "REP cannot clear M and X in emulation mode",
+			addressing: Immediate,
+			operation:  ResetStatusBits,
+			state:      State{PC: 0x8000, P: m | x | processor.FlagCarry, E: true},
+			ram:        ram{}.load(0x8000, 0x31),
+			wantState:  State{PC: 0x8001, P: m | x, E: true},
+		},
+		{
+			name:       "REP clears M and X in native mode",
+			addressing: Immediate,
+			operation:  ResetStatusBits,
+			state:      State{PC: 0x8000, P: m | x},
+			ram:        ram{}.load(0x8000, 0x30),
+			wantState:  State{PC: 0x8001},
+		},
+		{
+			name:       "SEP X clears the high bytes of the index registers",
+			addressing: Immediate,
+			operation:  SetStatusBits,
+			state:      State{PC: 0x8000, A: 0x1234, X: 0x1234, Y: 0xABCD},
+			ram:        ram{}.load(0x8000, 0x10),
+			wantState:  State{PC: 0x8001, A: 0x1234, X: 0x0034, Y: 0x00CD, P: x},
+		},
+		{
+			name:       "XCE enters native mode",
+			addressing: Implied,
+			operation:  ExchangeCarryEmulation,
+			state:      State{SP: 0x01FF, P: m | x, E: true},
+			wantState:  State{SP: 0x01FF, P: m | x | processor.FlagCarry},
+		},
+		{
+			name:       "XCE enters emulation mode",
+			addressing: Implied,
+			operation:  ExchangeCarryEmulation,
+			state:      State{SP: 0x1FF0, X: 0x1234, Y: 0x5678, A: 0xABCD, P: processor.FlagCarry},
+			wantState:  State{SP: 0x01F0, X: 0x0034, Y: 0x0078, A: 0xABCD, P: m | x, E: true},
+		},
+		{
+			name:       "TXA 16-bit accumulator with 8-bit index registers",
+			addressing: Implied,
+			operation:  TransferXtoA,
+			state:      State{A: 0xFFFF, X: 0x0034, P: x},
+			wantState:  State{A: 0x0034, X: 0x0034, P: x},
+		},
+		{
+			name:       "TAX 8-bit index registers",
+			addressing: Implied,
+			operation:  TransferAtoX,
+			state:      State{A: 0x1280, X: 0x0034, P: x},
+			wantState:  State{A: 0x1280, X: 0x0080, P: x | processor.FlagNegative},
+		},
+		{
+			name:       "TXS stays in page 1 in emulation mode",
+			addressing: Implied,
+			operation:  TransferXtoSP,
+			state:      State{X: 0x0080, SP: 0x01FF, P: m | x, E: true},
+			wantState:  State{X: 0x0080, SP: 0x0180, P: m | x, E: true},
+		},
+		{
+			name:       "TCS in native mode",
+			addressing: Implied,
+			operation:  TransferAtoSP,
+			state:      State{A: 0x1FFF, SP: 0x01FF},
+			wantState:  State{A: 0x1FFF, SP: 0x1FFF},
+		},
+		{
+			name:       "TCD transfers all 16 bits",
+			addressing: Implied,
+			operation:  TransferAtoD,
+			state:      State{A: 0x8000, P: m | x},
+			wantState:  State{A: 0x8000, D: 0x8000, P: m | x | processor.FlagNegative},
+		},
+		{
+			name:       "XBA sets the flags from the new low byte",
+			addressing: Implied,
+			operation:  ExchangeBA,
+			state:      State{A: 0x00FF, P: m | x},
+			wantState:  State{A: 0xFF00, P: m | x | processor.FlagZero},
+		},
+		{
+			name:       "DEX 16-bit",
+			addressing: Implied,
+			operation:  DecrementX,
+			state:      State{X: 0x0000},
+			wantState:  State{X: 0xFFFF, P: processor.FlagNegative},
+		},
+		{
+			name:       "INY 8-bit",
+			addressing: Implied,
+			operation:  IncrementY,
+			state:      State{Y: 0x00FF, P: x},
+			wantState:  State{Y: 0x0000, P: x | processor.FlagZero},
+		},
+		{
+			name:       "MVN moves a byte and repeats",
+			addressing: BlockMove,
+			operation:  MoveNegative,
+			state:      State{PC: 0x8001, A: 0x0001, X: 0x1000, Y: 0x2000},
+			ram:        ram{}.load(0x8001, 0x7E, 0x7F).load(0x7F1000, 0x55),
+			wantState:  State{PC: 0x8000, A: 0x0000, X: 0x1001, Y: 0x2001, DBR: 0x7E},
+			wantRam:    ram{0x7E2000: 0x55},
+		},
+		{
+			name:       "MVN completes when the count wraps",
+			addressing: BlockMove,
+			operation:  MoveNegative,
+			state:      State{PC: 0x8001, A: 0x0000, X: 0x1000, Y: 0x2000},
+			ram:        ram{}.load(0x8001, 0x7E, 0x7F).load(0x7F1000, 0x55),
+			wantState:  State{PC: 0x8003, A: 0xFFFF, X: 0x1001, Y: 0x2001, DBR: 0x7E},
+			wantRam:    ram{0x7E2000: 0x55},
+		},
+		{
+			name:       "MVP moves a byte downwards",
+			addressing: BlockMove,
+			operation:  MovePositive,
+			state:      State{PC: 0x8001, A: 0x0000, X: 0x1000, Y: 0x2000},
+			ram:        ram{}.load(0x8001, 0x7E, 0x7F).load(0x7F1000, 0x55),
+			wantState:  State{PC: 0x8003, A: 0xFFFF, X: 0x0FFF, Y: 0x1FFF, DBR: 0x7E},
+			wantRam:    ram{0x7E2000: 0x55},
+		},
+		{
+			name:       "MVN with 8-bit index registers",
+			addressing: BlockMove,
+			operation:  MoveNegative,
+			state:      State{PC: 0x8001, A: 0x0000, X: 0x00FF, Y: 0x0010, P: x},
+			ram:        ram{}.load(0x8001, 0x00, 0x00).load(0x0000FF, 0x55),
+			wantState:  State{PC: 0x8003, A: 0xFFFF, X: 0x0000, Y: 0x0011, P: x},
+			wantRam:    ram{0x000010: 0x55},
+		},
+		{
+			name:       "JSL pushes the program bank",
+			addressing: AbsoluteLong,
+			operation:  JumpSubRoutineLong,
+			state:      State{PBR: 0x01, PC: 0x8001, SP: 0x1FFF},
+			ram:        ram{}.load(0x018001, 0x00, 0x90, 0x02),
+			wantState:  State{PBR: 0x02, PC: 0x9000, SP: 0x1FFC},
+			wantRam:    ram{0x1FFF: 0x01, 0x1FFE: 0x80, 0x1FFD: 0x03},
+		},
+		{
+			name:       "RTL pulls the program bank",
+			addressing: Implied,
+			operation:  ReturnFromSubroutineLong,
+			state:      State{PBR: 0x02, PC: 0x9001, SP: 0x1FFC},
+			ram:        ram{}.load(0x1FFD, 0x03, 0x80, 0x01),
+			wantState:  State{PBR: 0x01, PC: 0x8004, SP: 0x1FFF},
+		},
+		{
+			name:       "JMP (a,x) stays in the program bank",
+			addressing: AbsoluteXIndirect,
+			operation:  Jump,
+			state:      State{PBR: 0x01, PC: 0x8001, X: 0x0002},
+			ram:        ram{}.load(0x018001, 0x00, 0x20).load(0x012002, 0x00, 0x90),
+			wantState:  State{PBR: 0x01, PC: 0x9000, X: 0x0002},
+		},
+		{
+			name:       "JML [a]",
+			addressing: AbsoluteIndirectLong,
+			operation:  JumpLong,
+			state:      State{PBR: 0x01, PC: 0x8001},
+			ram:        ram{}.load(0x018001, 0x00, 0x20).load(0x002000, 0x00, 0x90, 0x05),
+			wantState:  State{PBR: 0x05, PC: 0x9000},
+		},
+		{
+			name:       "BRL",
+			addressing: RelativeLong,
+			operation:  BranchAlways,
+			state:      State{PC: 0x8001},
+			ram:        ram{}.load(0x8001, 0xFD, 0xFF),
+			wantState:  State{PC: 0x8000},
+		},
+		{
+			name:       "BRK in native mode pushes the program bank",
+			addressing: Immediate,
+			operation:  Break,
+			state:      State{PBR: 0x02, PC: 0x8001, SP: 0x01FF, P: processor.FlagDecimal},
+			ram:        ram{}.load(0x00FFE6, 0x00, 0x90),
+			wantState:  State{PC: 0x9000, SP: 0x01FB, P: processor.FlagInterrupt},
+			wantRam:    ram{0x01FF: 0x02, 0x01FE: 0x80, 0x01FD: 0x02, 0x01FC: 0x08},
+		},
+		{
+			name:       "BRK in emulation mode uses the IRQ vector",
+			addressing: Immediate,
+			operation:  Break,
+			state:      State{PC: 0x0201, SP: 0x01FF, P: m | x, E: true},
+			ram:        ram{}.load(0x00FFFE, 0x00, 0x90),
+			wantState:  State{PC: 0x9000, SP: 0x01FC, P: m | x | processor.FlagInterrupt, E: true},
+			wantRam:    ram{0x01FF: 0x02, 0x01FE: 0x02, 0x01FD: 0x30},
+		},
+		{
+			name:       "COP in native mode",
+			addressing: Immediate,
+			operation:  CoProcessor,
+			state:      State{PBR: 0x02, PC: 0x8001, SP: 0x01FF},
+			ram:        ram{}.load(0x00FFE4, 0x00, 0x90),
+			wantState:  State{PC: 0x9000, SP: 0x01FB, P: processor.FlagInterrupt},
+			wantRam:    ram{0x01FF: 0x02, 0x01FE: 0x80, 0x01FD: 0x02, 0x01FC: 0x00},
+		},
+		{
+			name:       "RTI in native mode pulls the program bank",
+			addressing: Implied,
+			operation:  ReturnFromInterrupt,
+			state:      State{PC: 0x9001, SP: 0x01FB, P: processor.FlagInterrupt},
+			ram:        ram{}.load(0x01FC, 0x08, 0x02, 0x80, 0x02),
+			wantState:  State{PBR: 0x02, PC: 0x8002, SP: 0x01FF, P: processor.FlagDecimal},
+		},
+		{
+			name:       "PLP keeps M and X set in emulation mode",
+			addressing: Implied,
+			operation:  PullP,
+			state:      State{SP: 0x01FE, P: m | x, E: true},
+			ram:        ram{}.load(0x01FF, 0x00),
+			wantState:  State{SP: 0x01FF, P: m | x, E: true},
+		},
+		{
+			name:       "PHA wraps within page 1 in emulation mode",
+			addressing: Implied,
+			operation:  PushA,
+			state:      State{A: 0x1234, SP: 0x0100, P: m | x, E: true},
+			wantState:  State{A: 0x1234, SP: 0x01FF, P: m | x, E: true},
+			wantRam:    ram{0x0100: 0x34},
+		},
+		{
+			name:       "PHX 16-bit",
+			addressing: Implied,
+			operation:  PushX,
+			state:      State{X: 0x1234, SP: 0x01FF},
+			wantState:  State{X: 0x1234, SP: 0x01FD},
+			wantRam:    ram{0x01FF: 0x12, 0x01FE: 0x34},
+		},
+		{
+			name:       "PLB",
+			addressing: Implied,
+			operation:  PullDBR,
+			state:      State{SP: 0x01FE},
+			ram:        ram{}.load(0x01FF, 0x80),
+			wantState:  State{SP: 0x01FF, DBR: 0x80, P: processor.FlagNegative},
+		},
+		{
+			name:       "PLD",
+			addressing: Implied,
+			operation:  PullD,
+			state:      State{SP: 0x01FD, P: m | x},
+			ram:        ram{}.load(0x01FE, 0x00, 0x20),
+			wantState:  State{SP: 0x01FF, D: 0x2000, P: m | x},
+		},
+		{
+			name:       "PEA",
+			addressing: Absolute,
+			operation:  PushEffectiveAddress,
+			state:      State{PC: 0x8001, SP: 0x01FF, DBR: 0x7E},
+			ram:        ram{}.load(0x8001, 0x34, 0x12),
+			wantState:  State{PC: 0x8003, SP: 0x01FD, DBR: 0x7E},
+			wantRam:    ram{0x01FF: 0x12, 0x01FE: 0x34},
+		},
+		{
+			name:       "PEI",
+			addressing: DirectIndirect,
+			operation:  PushEffectiveAddress,
+			state:      State{PC: 0x8001, SP: 0x01FF},
+			ram:        ram{}.load(0x8001, 0x10).load(0x0010, 0x34, 0x12),
+			wantState:  State{PC: 0x8002, SP: 0x01FD},
+			wantRam:    ram{0x01FF: 0x12, 0x01FE: 0x34},
+		},
+		{
+			name:       "PER",
+			addressing: RelativeLong,
+			operation:  PushEffectiveAddress,
+			state:      State{PC: 0x8001, SP: 0x01FF},
+			ram:        ram{}.load(0x8001, 0x10, 0x00),
+			wantState:  State{PC: 0x8003, SP: 0x01FD},
+			wantRam:    ram{0x01FF: 0x80, 0x01FE: 0x13},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := tt.ram
+			if memory == nil {
+				memory = ram{}
+			}
+			instruction := Instruction{AddressingFunc: tt.addressing, Operation: tt.operation}
+			got, _, err := instruction.Execute(tt.state, memory)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if got != tt.wantState {
+				t.Errorf("State got = %v, want = %v", got, tt.wantState)
+			}
+			for address, want := range tt.wantRam {
+				if got := memory[address]; got != want {
+					t.Errorf("RAM $%06X got = $%02X, want = $%02X", uint32(address), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	memory := ram{}.load(0xFFFC, 0x00, 0x80)
+	state := State{PC: 0x1234, PBR: 0x01, DBR: 0x02, D: 0x1000, SP: 0x1234, A: 0x1234, X: 0x5678, Y: 0x9ABC, P: processor.FlagDecimal}
+
+	got, err := Reset(state, &Addressing{Memory: memory})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := State{PC: 0x8000, SP: 0x0134, A: 0x1234, X: 0x0078, Y: 0x00BC, P: FlagMemory | FlagIndex | processor.FlagInterrupt, E: true}
+	if got != want {
+		t.Errorf("Reset() got = %v, want = %v", got, want)
+	}
+
+	if _, err := Reset(state, &Addressing{}); err != processor.MemoryMustBeProvided {
+		t.Errorf("Reset() error got = %v, want = %v", err, processor.MemoryMustBeProvided)
+	}
+}